@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"github.com/joho/godotenv"
 	"os"
 	"os/signal"
 	"pvz/configs"
 	"pvz/internal"
 	"pvz/internal/bootstrap"
 	"pvz/internal/logger"
+	"pvz/internal/tracing"
 	"syscall"
 	"time"
 )
@@ -19,13 +21,28 @@ func main() {
 		os.Exit(1)
 	}
 
-	logger.Init(cfg.LogLvl)
-	logger.Log.Info("logger initialized", "level", cfg.LogLvl)
+	if err = logger.Setup(cfg.Log); err != nil {
+		logger.Log.Error("failed to initialize logger", "err", err)
+		os.Exit(1)
+	}
+	defer logger.Close()
+	logger.Log.Info("logger initialized", "level", logger.Level(), "format", cfg.Log.Format, "file", cfg.Log.FileEnabled)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		exit(runMigrate(os.Args[2:]))
+	}
+
+	shutdownTracing, err := tracing.Init(cfg.Tracing)
+	if err != nil {
+		logger.Log.Error("failed to initialize tracing", "err", err)
+		exit(1)
+	}
+	logger.Log.Info("tracing initialized", "exporter", cfg.Tracing.Exporter)
 
 	deps, err := bootstrap.InitDeps()
 	if err != nil {
 		logger.Log.Error("failed to initialize dependencies", "err", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	server := internal.NewServer(cfg, deps)
@@ -39,13 +56,14 @@ func main() {
 	}()
 
 	signalCh := make(chan os.Signal, 1)
-	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	waitForShutdown(signalCh, errCh)
 
-	select {
-	case sig := <-signalCh:
-		logger.Log.Warn("shutdown signal received", "signal", sig)
-	case err := <-errCh:
-		logger.Log.Error("server encountered an error", "err", err)
+	deps.HealthService.MarkShuttingDown()
+	if cfg.Health.ShutdownDrainDelay > 0 {
+		logger.Log.Info("waiting for load balancers to drain", "delay", cfg.Health.ShutdownDrainDelay)
+		time.Sleep(cfg.Health.ShutdownDrainDelay)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -54,8 +72,49 @@ func main() {
 	logger.Log.Info("shutting down server")
 	if shutdownErr := server.Shutdown(ctx); shutdownErr != nil {
 		logger.Log.Error("graceful shutdown failed", "err", shutdownErr)
-		os.Exit(1)
+		exit(1)
+	}
+
+	if tracingErr := shutdownTracing(ctx); tracingErr != nil {
+		logger.Log.Error("failed to flush traces", "err", tracingErr)
 	}
 
 	logger.Log.Info("server gracefully stopped")
 }
+
+// exit closes the logger before os.Exit, which skips the deferred Close in main.
+func exit(code int) {
+	logger.Close()
+	os.Exit(code)
+}
+
+func waitForShutdown(signalCh <-chan os.Signal, errCh <-chan error) {
+	for {
+		select {
+		case sig := <-signalCh:
+			if sig == syscall.SIGHUP {
+				reloadLogLevel()
+				continue
+			}
+			logger.Log.Warn("shutdown signal received", "signal", sig)
+			return
+		case err := <-errCh:
+			logger.Log.Error("server encountered an error", "err", err)
+			return
+		}
+	}
+}
+
+// reloadLogLevel re-reads LOG_LEVEL from the .env file, falling back to the process environment.
+func reloadLogLevel() {
+	lvl := os.Getenv("LOG_LEVEL")
+	if envFile, err := godotenv.Read(); err == nil && envFile["LOG_LEVEL"] != "" {
+		lvl = envFile["LOG_LEVEL"]
+	}
+
+	if err := logger.SetLevel(lvl); err != nil {
+		logger.Log.Error("failed to reload log level", "err", err)
+		return
+	}
+	logger.Log.Warn("log level reloaded", "level", logger.Level())
+}
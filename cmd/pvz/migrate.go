@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"pvz/configs"
+	"pvz/internal/bootstrap"
+	"pvz/internal/logger"
+	"pvz/internal/migrations"
+	"strconv"
+)
+
+const migrateUsage = `usage: pvz migrate <command>
+
+commands:
+  up             apply all pending migrations
+  down [N|all]   roll back N migrations (default 1) or all of them
+  status         print the current and latest schema versions
+  force V        set the schema version to V and clear the dirty flag`
+
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	db, err := bootstrap.OpenDB()
+	if err != nil {
+		return 1
+	}
+	defer db.Close()
+
+	migrator, err := migrations.New(context.Background(), db, configs.AppConfiguration.DB.MigrateLockTimeout)
+	if err != nil {
+		logger.Log.Error("failed to initialize migrator", "err", err)
+		return 1
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = parseSteps(args[1]); err != nil {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				return 2
+			}
+		}
+		err = migrator.Down(steps)
+	case "force":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		err = migrator.Force(version)
+	case "status":
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	if err != nil {
+		logger.Log.Error("migration failed", "command", args[0], "err", err)
+		return 1
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		logger.Log.Error("failed to read migration status", "err", err)
+		return 1
+	}
+	fmt.Printf("version: %d\ndirty: %t\nlatest: %d\npending: %v\n", status.Version, status.Dirty, status.Latest, status.Pending)
+	return 0
+}
+
+// parseSteps maps "all" to 0, which Migrator.Down treats as a full rollback.
+func parseSteps(arg string) (int, error) {
+	if arg == "all" {
+		return 0, nil
+	}
+	steps, err := strconv.Atoi(arg)
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("invalid number of steps %q", arg)
+	}
+	return steps, nil
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"os"
+	"path/filepath"
+	"pvz/configs"
+	"pvz/internal/handlers"
+	"pvz/internal/importer"
+	"pvz/internal/models/auth"
+	"pvz/internal/models/pvz"
+	"pvz/internal/models/stats"
+	"pvz/internal/models/user"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+var validate = validator.New()
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func userCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user create")
+	email := fs.String("email", "", "user email")
+	passwordFile := fs.String("password-file", "", "file holding the password, at least 8 characters; read from stdin when not given")
+	role := fs.String("role", string(auth.Employee), "employee or moderator")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	password, err := readPassword(*passwordFile, a.in)
+	if err != nil {
+		return err
+	}
+
+	req := auth.RegisterRequest{Email: *email, Password: password, Role: *role}
+	if err := validate.Struct(req); err != nil {
+		return err
+	}
+
+	resp, err := a.deps.AuthService.CreateUser(ctx, a.meta, req)
+	if err != nil {
+		return err
+	}
+	return a.out.print(resp, []string{"ID", "EMAIL", "ROLE"}, [][]string{{resp.Id, resp.Email, resp.Role}})
+}
+
+// readPassword takes the first line of the file or of stdin. Passwords are never accepted as arguments,
+// which end up in ps output and shell history.
+func readPassword(file string, stdin io.Reader) (string, error) {
+	in := stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		in = f
+	} else if f, ok := stdin.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			fmt.Fprint(os.Stderr, "password: ")
+		}
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func userList(ctx context.Context, a *app, args []string) error {
+	if err := newFlagSet("user list").Parse(args); err != nil {
+		return err
+	}
+
+	users, err := a.deps.UserService.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, []string{u.Id, u.Email, u.Role, strings.Join(u.PvzIds, ",")})
+	}
+	return a.out.print(users, []string{"ID", "EMAIL", "ROLE", "PVZS"}, rows)
+}
+
+func userSetPassword(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user set-password")
+	email := fs.String("email", "", "user email")
+	passwordFile := fs.String("password-file", "", "file holding the new password, at least 8 characters; read from stdin when not given")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	password, err := readPassword(*passwordFile, a.in)
+	if err != nil {
+		return err
+	}
+
+	req := user.SetPasswordRequest{Email: *email, Password: password}
+	if err := validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := a.deps.UserService.SetPassword(ctx, a.meta, req); err != nil {
+		return err
+	}
+	result := map[string]string{"email": req.Email, "status": "password updated"}
+	return a.out.print(result, []string{"EMAIL", "STATUS"}, [][]string{{result["email"], result["status"]}})
+}
+
+func userAssignPvz(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user assign-pvz")
+	email := fs.String("email", "", "user email")
+	var pvzIds stringList
+	fs.Var(&pvzIds, "pvz", "pvz id, may be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := user.AssignPvzsRequest{Email: *email, PvzIds: pvzIds}
+	if err := validate.Struct(req); err != nil {
+		return err
+	}
+
+	resp, err := a.deps.UserService.AssignPvzs(ctx, a.meta, req)
+	if err != nil {
+		return err
+	}
+	return a.out.print(resp, []string{"ID", "EMAIL", "ROLE", "PVZS"},
+		[][]string{{resp.Id, resp.Email, resp.Role, strings.Join(resp.PvzIds, ",")}})
+}
+
+func pvzList(ctx context.Context, a *app, args []string) error {
+	if err := newFlagSet("pvz list").Parse(args); err != nil {
+		return err
+	}
+
+	pvzs, err := a.deps.PvzService.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(pvzs))
+	for _, p := range pvzs {
+		rows = append(rows, []string{p.Id, p.City, p.RegistrationDate.Format(timeLayout)})
+	}
+	return a.out.print(pvzs, []string{"ID", "CITY", "REGISTERED"}, rows)
+}
+
+// pvzImport exits with an error when any row fails, after printing the result of every row.
+func pvzImport(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("pvz import")
+	file := fs.String("file", "", "csv file with a header or json array of pvzs")
+	format := fs.String("format", "", "csv or json, taken from the file extension by default")
+	dryRun := fs.Bool("dry-run", false, "check the file without importing it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := importer.Read(*format, f, configs.DefaultImportMaxRows, handlers.NewApiValidator().ValidateRequest)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.deps.PvzService.Import(ctx, a.meta, pvz.ImportRequest{Rows: rows, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	result := make([][]string, 0, len(resp.Rows))
+	for _, row := range resp.Rows {
+		id, status := "-", "imported"
+		if *dryRun {
+			status = "valid"
+		}
+		if row.Pvz != nil {
+			id = row.Pvz.Id
+		}
+		if len(row.Errors) > 0 {
+			messages := make([]string, 0, len(row.Errors))
+			for _, fieldError := range row.Errors {
+				messages = append(messages, fieldError.Message)
+			}
+			status = strings.Join(messages, "; ")
+		}
+		result = append(result, []string{strconv.Itoa(row.Row), id, status})
+	}
+	if err := a.out.print(resp, []string{"ROW", "ID", "RESULT"}, result); err != nil {
+		return err
+	}
+
+	if resp.Failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", resp.Failed, resp.Total)
+	}
+	return nil
+}
+
+func receptionStuck(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("reception stuck")
+	olderThan := fs.Duration("older-than", 24*time.Hour, "minimum time a reception has been in progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	recs, err := a.deps.ReceptionService.ListStuck(ctx, *olderThan)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(recs))
+	for _, rec := range recs {
+		rows = append(rows, []string{rec.Id, rec.PvzId, rec.DateTime.Format(timeLayout), valueOrDash(rec.OpenedBy)})
+	}
+	return a.out.print(recs, []string{"ID", "PVZ", "OPENED", "OPENED BY"}, rows)
+}
+
+func receptionClose(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("reception close")
+	pvzId := fs.String("pvz", "", "pvz id whose in-progress reception should be closed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validate.Var(*pvzId, "required,uuid"); err != nil {
+		return fmt.Errorf("-pvz: %w", err)
+	}
+
+	resp, err := a.deps.PvzService.CLoseLastReception(ctx, a.meta, *pvzId)
+	if err != nil {
+		return err
+	}
+	return a.out.print(resp, []string{"ID", "PVZ", "STATUS"}, [][]string{{resp.Id, resp.PvzId, resp.Status}})
+}
+
+func receptionCloseStuck(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("reception close-stuck")
+	olderThan := fs.Duration("older-than", 24*time.Hour, "minimum time a reception has been in progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	recs, err := a.deps.ReceptionService.ListStuck(ctx, *olderThan)
+	if err != nil {
+		return err
+	}
+
+	closed := make([]pvz.CloseLastProductResponse, 0, len(recs))
+	rows := make([][]string, 0, len(recs))
+	for _, rec := range recs {
+		resp, err := a.deps.PvzService.CLoseLastReception(ctx, a.meta, rec.PvzId)
+		if err != nil {
+			return fmt.Errorf("close reception %s: %w", rec.Id, err)
+		}
+		closed = append(closed, resp)
+		rows = append(rows, []string{resp.Id, resp.PvzId, resp.Status})
+	}
+	return a.out.print(closed, []string{"ID", "PVZ", "STATUS"}, rows)
+}
+
+type pvzReport struct {
+	PvzId          string         `json:"pvzId"`
+	City           string         `json:"city"`
+	Receptions     int            `json:"receptions"`
+	OpenReceptions int            `json:"openReceptions"`
+	Products       int            `json:"products"`
+	ProductsByType map[string]int `json:"productsByType"`
+}
+
+// report summarises receptions and products per PVZ for receptions opened in [from, to].
+func report(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("report")
+	fromArg := fs.String("from", time.Now().AddDate(0, -1, 0).Format(dateLayout), "first day of the period")
+	toArg := fs.String("to", time.Now().Format(dateLayout), "last day of the period")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	from, err := time.Parse(dateLayout, *fromArg)
+	if err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	to, err := time.Parse(dateLayout, *toArg)
+	if err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+	to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	// counts come from the stats aggregate, once per pvz and once per pvz and product type
+	window := stats.Request{StartDate: &from, EndDate: &to}
+	window.GroupBy = []string{stats.GroupPvz, stats.GroupCity}
+	perPvz, err := a.deps.StatsService.Get(ctx, window)
+	if err != nil {
+		return err
+	}
+	window.GroupBy = []string{stats.GroupPvz, stats.GroupType}
+	perType, err := a.deps.StatsService.Get(ctx, window)
+	if err != nil {
+		return err
+	}
+
+	reports := make(map[string]*pvzReport, len(perPvz.Rows))
+	for _, row := range perPvz.Rows {
+		reports[*row.PvzId] = &pvzReport{
+			PvzId:          *row.PvzId,
+			City:           *row.City,
+			Receptions:     row.Receptions,
+			OpenReceptions: row.OpenReceptions,
+			Products:       row.Products,
+			ProductsByType: map[string]int{},
+		}
+	}
+	for _, row := range perType.Rows {
+		r, ok := reports[*row.PvzId]
+		if !ok || row.Type == nil || row.Products == 0 {
+			continue
+		}
+		r.ProductsByType[*row.Type] = row.Products
+	}
+
+	result := make([]pvzReport, 0, len(reports))
+	for _, r := range reports {
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].City != result[j].City {
+			return result[i].City < result[j].City
+		}
+		return result[i].PvzId < result[j].PvzId
+	})
+
+	rows := make([][]string, 0, len(result))
+	for _, r := range result {
+		rows = append(rows, []string{
+			r.PvzId, r.City, strconv.Itoa(r.Receptions), strconv.Itoa(r.OpenReceptions),
+			strconv.Itoa(r.Products), formatCounts(r.ProductsByType),
+		})
+	}
+	return a.out.print(result, []string{"PVZ", "CITY", "RECEPTIONS", "OPEN", "PRODUCTS", "BY TYPE"}, rows)
+}
+
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%d", k, counts[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func valueOrDash(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/bootstrap"
+	"pvz/internal/mocks"
+	"pvz/internal/models/auth"
+	"pvz/internal/models/user"
+	"pvz/pkg/errors"
+)
+
+func TestReadPassword(t *testing.T) {
+	password, err := readPassword("", strings.NewReader("secret123\r\nignored\n"))
+	require.NoError(t, err)
+	require.Equal(t, "secret123", password)
+
+	password, err = readPassword("", strings.NewReader("no-newline"))
+	require.NoError(t, err)
+	require.Equal(t, "no-newline", password)
+
+	file := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(file, []byte("from-file1\n"), 0o600))
+	password, err = readPassword(file, strings.NewReader("from-stdin\n"))
+	require.NoError(t, err)
+	require.Equal(t, "from-file1", password)
+
+	_, err = readPassword(filepath.Join(t.TempDir(), "missing"), strings.NewReader(""))
+	require.Error(t, err)
+}
+
+func TestCliActor(t *testing.T) {
+	ctx := context.Background()
+
+	actor, err := cliActor(ctx, bootstrap.Deps{}, "")
+	require.NoError(t, err)
+	require.Equal(t, auth.Principal{Role: cliRole}, actor)
+
+	users := mocks.NewUserService(t)
+	users.On("GetByEmail", mock.Anything, "admin@avito.ru").Return(user.Response{Id: "user1", Email: "admin@avito.ru", Role: "moderator"}, nil).Once()
+	users.On("GetByEmail", mock.Anything, "ghost@avito.ru").Return(user.Response{}, errors.NewObjectNotFound("user")).Once()
+	deps := bootstrap.Deps{UserService: users}
+
+	actor, err = cliActor(ctx, deps, "admin@avito.ru")
+	require.NoError(t, err)
+	require.Equal(t, auth.Principal{UserId: "user1", Role: auth.Moderator}, actor)
+
+	_, err = cliActor(ctx, deps, "ghost@avito.ru")
+	require.ErrorAs(t, err, &errors.ObjectNotFound{})
+}
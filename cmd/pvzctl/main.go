@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"os/user"
+	"pvz/configs"
+	"pvz/internal/bootstrap"
+	"pvz/internal/logger"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
+)
+
+const usage = `usage: pvzctl [-o table|json] [-as EMAIL] <command> [flags]
+
+commands:
+  user create -email E [-password-file F] -role employee|moderator
+  user list
+  user set-password -email E [-password-file F]
+  user assign-pvz -email E -pvz ID [-pvz ID ...]
+  pvz list
+  pvz import -file F [-format csv|json] [-dry-run]
+  reception stuck [-older-than 24h]
+  reception close -pvz ID
+  reception close-stuck [-older-than 24h]
+  report [-from 2006-01-02] [-to 2006-01-02]
+
+passwords are read from -password-file or from the first line of stdin
+-as records changes in the audit log under that user, otherwise under the os account`
+
+// cliRole marks audit entries written by pvzctl without -as; the os account is kept in the request id
+const cliRole auth.Role = "pvzctl"
+
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]command{
+	"user create":           userCreate,
+	"user list":             userList,
+	"user set-password":     userSetPassword,
+	"user assign-pvz":       userAssignPvz,
+	"pvz list":              pvzList,
+	"pvz import":            pvzImport,
+	"reception stuck":       receptionStuck,
+	"reception close":       receptionClose,
+	"reception close-stuck": receptionCloseStuck,
+	"report":                report,
+}
+
+type app struct {
+	deps bootstrap.Deps
+	in   io.Reader
+	out  printer
+	meta audit.Meta
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	global := flag.NewFlagSet("pvzctl", flag.ContinueOnError)
+	format := global.String("o", formatTable, "output format: table or json")
+	actingAs := global.String("as", "", "email of the user the changes are made on behalf of")
+	global.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if *format != formatTable && *format != formatJson {
+		fmt.Fprintf(os.Stderr, "unknown output format %q\n", *format)
+		return 2
+	}
+
+	cmd, cmdArgs, ok := lookupCommand(global.Args())
+	if !ok {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		return 1
+	}
+	// Stdout is reserved for command output, and per-query info logs would drown it on stderr too.
+	cfg.Log.Level = "warn"
+	if err = logger.SetupTo(cfg.Log, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to initialize logger:", err)
+		return 1
+	}
+	defer logger.Close()
+
+	deps, err := bootstrap.InitDeps()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to initialize dependencies:", err)
+		return 1
+	}
+
+	requestId := "pvzctl-" + osUser() + "-" + uuid.NewString()
+	ctx := logger.WithContext(context.Background(), logger.Log.With("request_id", requestId, "scope", "pvzctl"))
+
+	actor, err := cliActor(ctx, deps, *actingAs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	a := &app{
+		deps: deps,
+		in:   os.Stdin,
+		out:  printer{format: *format, out: os.Stdout},
+		meta: audit.Meta{Actor: actor, RequestId: requestId},
+	}
+
+	if err = cmd(ctx, a, cmdArgs); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
+
+// cliActor is the user given with -as, or a principal with cliRole when pvzctl runs on its own,
+// e.g. to create the first moderator.
+func cliActor(ctx context.Context, deps bootstrap.Deps, email string) (auth.Principal, error) {
+	if email == "" {
+		return auth.Principal{Role: cliRole}, nil
+	}
+	u, err := deps.UserService.GetByEmail(ctx, email)
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("-as %s: %w", email, err)
+	}
+	return auth.Principal{UserId: u.Id, Role: auth.Role(u.Role)}, nil
+}
+
+func osUser() string {
+	// request ids are limited to 100 characters
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username[:min(len(current.Username), 32)]
+	}
+	return "unknown"
+}
+
+// lookupCommand matches two-word commands first, so "user list" wins over a hypothetical "user".
+func lookupCommand(args []string) (command, []string, bool) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:], true
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[1:], true
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrinter(t *testing.T) {
+	value := []map[string]string{{"id": "1", "email": "a@b.c"}}
+	headers := []string{"ID", "EMAIL"}
+	rows := [][]string{{"1", "a@b.c"}}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer{format: formatTable, out: &buf}.print(value, headers, rows))
+		require.Equal(t, "ID  EMAIL\n1   a@b.c\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printer{format: formatJson, out: &buf}.print(value, headers, rows))
+		require.JSONEq(t, `[{"id":"1","email":"a@b.c"}]`, buf.String())
+	})
+}
+
+func TestLookupCommand(t *testing.T) {
+	_, rest, ok := lookupCommand([]string{"user", "create", "-email", "a@b.c"})
+	require.True(t, ok)
+	require.Equal(t, []string{"-email", "a@b.c"}, rest)
+
+	_, rest, ok = lookupCommand([]string{"report", "-from", "2025-01-01"})
+	require.True(t, ok)
+	require.Equal(t, []string{"-from", "2025-01-01"}, rest)
+
+	_, _, ok = lookupCommand([]string{"user", "delete"})
+	require.False(t, ok)
+}
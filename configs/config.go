@@ -1,50 +1,242 @@
 package configs
 
 import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v10"
 	"github.com/joho/godotenv"
+
+	"pvz/internal/i18n"
 )
 
 const (
 	DefaultAPIRequestTimeout = time.Millisecond * 150
+	DefaultEventsKeepAlive   = time.Second * 15
+	DefaultExportBatchSize   = 500
+	// an import is inserted in one transaction, so its file is bounded both in bytes and in rows
+	DefaultImportMaxBodySize = 10 << 20
+	DefaultImportMaxRows     = 10000
+	// DefaultIdempotencyRenewInterval is how often a request that may outlive it renews its idempotency key
+	DefaultIdempotencyRenewInterval = time.Second * 20
+)
+
+const (
+	RegistrationEmployees = "employees"
+	RegistrationDisabled  = "disabled"
+)
+
+const (
+	RateLimitMemory   = "memory"
+	RateLimitPostgres = "postgres"
+)
+
+const (
+	MailerLog  = "log"
+	MailerFile = "file"
+	MailerSmtp = "smtp"
 )
 
 var AppConfiguration *AppConfig
 
+// Version is set at build time with -ldflags "-X pvz/configs.Version=...".
+var Version = "dev"
+
 type AppConfig struct {
-	HttpPort int    `env:"HTTP_PORT" envDefault:"8080"`
-	LogLvl   string `env:"LOG_LEVEL" envDefault:"info"`
-	DB       DBConfig
-	Auth     AuthConfig
+	HttpPort        int     `env:"HTTP_PORT" envDefault:"8080"`
+	PublicUrl       string  `env:"PUBLIC_URL" envDefault:"http://localhost:8080"`
+	LegacyErrors    bool    `env:"LEGACY_ERRORS" envDefault:"false"`
+	DefaultLanguage string  `env:"DEFAULT_LANGUAGE" envDefault:"en"`
+	TrustedProxies  Proxies `env:"TRUSTED_PROXIES"`
+	Log             LogConfig
+	DB              DBConfig
+	Auth            AuthConfig
+	Events          EventsConfig
+	Health          HealthConfig
+	Mailer          MailerConfig
+	Invitations     InvitationsConfig
+	RateLimit       RateLimitConfig
+	Idempotency     IdempotencyConfig
+	Tracing         TracingConfig
+}
+
+type LogConfig struct {
+	Level              string        `env:"LOG_LEVEL" envDefault:"info"`
+	Format             string        `env:"LOG_FORMAT" envDefault:"text"`
+	FileEnabled        bool          `env:"LOG_FILE_ENABLED" envDefault:"true"`
+	File               string        `env:"LOG_FILE" envDefault:"default.log"`
+	FileMaxSizeMb      int           `env:"LOG_FILE_MAX_SIZE_MB" envDefault:"100"`
+	FileMaxAgeDays     int           `env:"LOG_FILE_MAX_AGE_DAYS" envDefault:"7"`
+	FileMaxBackups     int           `env:"LOG_FILE_MAX_BACKUPS" envDefault:"5"`
+	FileCompress       bool          `env:"LOG_FILE_COMPRESS" envDefault:"false"`
+	FileRotateInterval time.Duration `env:"LOG_FILE_ROTATE_INTERVAL" envDefault:"0"`
 }
 
 type DBConfig struct {
-	Host           string `env:"DB_HOST,required"`
-	Port           int    `env:"DB_PORT" envDefault:"5432"`
-	Name           string `env:"DB_NAME,required"`
-	DriverName     string `env:"DB_DRIVER" envDefault:"postgres"`
-	User           string `env:"DB_USER,required"`
-	Password       string `env:"DB_PASSWORD,required"`
-	MaxConnections int    `env:"MAX_CONNECTIONS" envDefault:"10"`
-	SslMode        string `env:"DB_SSLMODE" envDefault:"disable"`
+	Host               string        `env:"DB_HOST,required"`
+	Port               int           `env:"DB_PORT" envDefault:"5432"`
+	Name               string        `env:"DB_NAME,required"`
+	DriverName         string        `env:"DB_DRIVER" envDefault:"postgres"`
+	User               string        `env:"DB_USER,required"`
+	Password           string        `env:"DB_PASSWORD,required"`
+	MaxConnections     int           `env:"MAX_CONNECTIONS" envDefault:"10"`
+	SslMode            string        `env:"DB_SSLMODE" envDefault:"disable"`
+	MigrateOnStart     bool          `env:"MIGRATE_ON_START" envDefault:"false"`
+	MigrateLockTimeout time.Duration `env:"MIGRATE_LOCK_TIMEOUT" envDefault:"1m"`
 }
 
 type AuthConfig struct {
-	JwtSecret  string        `env:"JWT_SECRET,required"`
-	Expiration time.Duration `env:"TOKEN_EXPIRATION" envDefault:"24h"`
+	JwtSecret    string        `env:"JWT_SECRET,required"`
+	Expiration   time.Duration `env:"TOKEN_EXPIRATION" envDefault:"24h"`
+	Registration string        `env:"REGISTRATION" envDefault:"employees"`
+	DevMode      bool          `env:"DEV_MODE" envDefault:"false"`
+	ResetTtl     time.Duration `env:"PASSWORD_RESET_TTL" envDefault:"1h"`
+	Login        LoginProtectionConfig
+}
+
+// LoginProtectionConfig throttles password guessing. Zero limits or delays switch the matching check off.
+type LoginProtectionConfig struct {
+	MaxEmailFailures int           `env:"LOGIN_MAX_EMAIL_FAILURES" envDefault:"10"`
+	MaxIpFailures    int           `env:"LOGIN_MAX_IP_FAILURES" envDefault:"50"`
+	DelayAfter       int           `env:"LOGIN_DELAY_AFTER" envDefault:"3"`
+	DelayBase        time.Duration `env:"LOGIN_DELAY_BASE" envDefault:"1s"`
+	FailureWindow    time.Duration `env:"LOGIN_FAILURE_WINDOW" envDefault:"15m"`
+	Lockout          time.Duration `env:"LOGIN_LOCKOUT" envDefault:"15m"`
+}
+
+type EventsConfig struct {
+	BufferSize int `env:"EVENTS_BUFFER_SIZE" envDefault:"64"`
+}
+
+type MailerConfig struct {
+	Kind         string        `env:"MAILER" envDefault:"log"`
+	From         string        `env:"MAIL_FROM" envDefault:"pvz@localhost"`
+	FileDir      string        `env:"MAILER_FILE_DIR" envDefault:"mail"`
+	SmtpHost     string        `env:"SMTP_HOST"`
+	SmtpPort     int           `env:"SMTP_PORT" envDefault:"587"`
+	SmtpUser     string        `env:"SMTP_USER"`
+	SmtpPassword string        `env:"SMTP_PASSWORD"`
+	Timeout      time.Duration `env:"MAILER_TIMEOUT" envDefault:"10s"`
+}
+
+type InvitationsConfig struct {
+	Ttl time.Duration `env:"INVITATION_TTL" envDefault:"72h"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool   `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	Backend string `env:"RATE_LIMIT_BACKEND" envDefault:"memory"`
+	Default Budget `env:"RATE_LIMIT_DEFAULT" envDefault:"20:40"`
+	Auth    Budget `env:"RATE_LIMIT_AUTH" envDefault:"1:10"`
+	Product Budget `env:"RATE_LIMIT_PRODUCT" envDefault:"10:20"`
+}
+
+// Budget is a token bucket written as "rate:burst": rate tokens per second refill a bucket of burst tokens.
+type Budget struct {
+	Rate  float64
+	Burst int
+}
+
+func (b *Budget) UnmarshalText(text []byte) error {
+	rate, burst, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return fmt.Errorf("rate limit budget %q must be rate:burst", text)
+	}
+	var err error
+	if b.Rate, err = strconv.ParseFloat(rate, 64); err != nil || b.Rate <= 0 {
+		return fmt.Errorf("rate limit budget %q has invalid rate", text)
+	}
+	if b.Burst, err = strconv.Atoi(burst); err != nil || b.Burst < 1 {
+		return fmt.Errorf("rate limit budget %q has invalid burst", text)
+	}
+	return nil
+}
+
+// Proxies are the reverse proxies whose X-Forwarded-For and X-Real-IP headers are trusted, written as a
+// comma separated list of addresses or CIDR ranges.
+type Proxies []netip.Prefix
+
+func (p *Proxies) UnmarshalText(text []byte) error {
+	*p = nil
+	for _, entry := range strings.Split(string(text), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return fmt.Errorf("trusted proxy %q is not an address or CIDR range", entry)
+			}
+			*p = append(*p, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return fmt.Errorf("trusted proxy %q is not an address or CIDR range", entry)
+		}
+		*p = append(*p, prefix.Masked())
+	}
+	return nil
+}
+
+func (p Proxies) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+type IdempotencyConfig struct {
+	Ttl time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+}
+
+type HealthConfig struct {
+	DBTimeout          time.Duration `env:"HEALTH_DB_TIMEOUT" envDefault:"1s"`
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"0s"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `env:"TRACING_EXPORTER" envDefault:"none"`
+	File         string  `env:"TRACING_FILE" envDefault:"traces.json"`
+	OtlpEndpoint string  `env:"TRACING_OTLP_ENDPOINT" envDefault:"localhost:4318"`
+	OtlpInsecure bool    `env:"TRACING_OTLP_INSECURE" envDefault:"true"`
+	SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+	ServiceName  string  `env:"TRACING_SERVICE_NAME" envDefault:"pvz"`
 }
 
 func LoadConfig() (*AppConfig, error) {
 	_ = godotenv.Load()
 	cfg := AppConfig{}
+	cfg.Log = LogConfig{}
 	cfg.DB = DBConfig{}
 	cfg.Auth = AuthConfig{}
+	cfg.Events = EventsConfig{}
+	cfg.Health = HealthConfig{}
+	cfg.Mailer = MailerConfig{}
+	cfg.Invitations = InvitationsConfig{}
+	cfg.RateLimit = RateLimitConfig{}
+	cfg.Idempotency = IdempotencyConfig{}
+	cfg.Tracing = TracingConfig{}
 
 	if err := env.Parse(&cfg); err != nil {
 		return nil, err
 	}
+	if cfg.Auth.Registration != RegistrationEmployees && cfg.Auth.Registration != RegistrationDisabled {
+		return nil, fmt.Errorf("REGISTRATION must be %q or %q, got %q", RegistrationEmployees, RegistrationDisabled, cfg.Auth.Registration)
+	}
+	if !i18n.Supported(cfg.DefaultLanguage) {
+		return nil, fmt.Errorf("DEFAULT_LANGUAGE must be %q or %q, got %q", i18n.English, i18n.Russian, cfg.DefaultLanguage)
+	}
+	if cfg.RateLimit.Backend != RateLimitMemory && cfg.RateLimit.Backend != RateLimitPostgres {
+		return nil, fmt.Errorf("RATE_LIMIT_BACKEND must be %q or %q, got %q", RateLimitMemory, RateLimitPostgres, cfg.RateLimit.Backend)
+	}
 	AppConfiguration = &cfg
 	return &cfg, nil
 }
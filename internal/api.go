@@ -2,6 +2,7 @@ package internal
 
 import (
 	"github.com/labstack/echo"
+	"pvz/configs"
 	"pvz/internal/bootstrap"
 	"pvz/internal/handlers"
 	"pvz/internal/middleware"
@@ -14,26 +15,90 @@ const (
 )
 
 func createApi(e *echo.Echo, deps bootstrap.Deps) {
-	api := e.Group(apiPrefix, middleware.SetApiTimeout, middleware.HandleError)
+	healthHandler := handlers.NewHealthHandler(deps)
+	e.GET("/healthz", healthHandler.Live)
+	e.GET("/readyz", healthHandler.Ready)
+
+	checkSession := middleware.CheckSession(deps.PasswordService)
+	api := e.Group(apiPrefix, middleware.SetApiTimeout, middleware.HandleError, middleware.Authenticate, checkSession)
+
+	// every group draws from its own bucket per caller, so a flood of one kind of request
+	// does not starve the others
+	limits := configs.AppConfiguration.RateLimit
+	limitAuth := middleware.RateLimit(deps.RateLimiter, "auth", limits.Auth)
+	limitProduct := middleware.RateLimit(deps.RateLimiter, "product", limits.Product)
+	limitDefault := middleware.RateLimit(deps.RateLimiter, "default", limits.Default)
+
+	// idempotency keys are kept for business writes only and checked after the rate limit, so a flood
+	// of rejected requests does not reach the key store. Login and password responses carry tokens
+	// and are never stored.
+	idempotent := middleware.Idempotent(deps.IdempotencyService)
 
 	authHandler := handlers.NewAuthHandler(deps)
-	auth := api.Group("", middleware.SetApiTimeout)
+	auth := api.Group("", middleware.SetApiTimeout, limitAuth)
 	auth.POST("/login", authHandler.Login)
 	auth.POST("/register", authHandler.Register)
 	auth.POST("/dummyLogin", authHandler.DummyLogin)
+	auth.POST("/users", authHandler.CreateUser, middleware.AllowRoles(aModel.Moderator), idempotent)
+	auth.PUT("/users/me/language", authHandler.SetLanguage, middleware.AllowRoles(aModel.Employee, aModel.Moderator))
+
+	passwordHandler := handlers.NewPasswordHandler(deps)
+	password := api.Group("/password", middleware.SetApiTimeout, limitAuth)
+	password.POST("/change", passwordHandler.Change, middleware.AllowRoles(aModel.Employee, aModel.Moderator))
+	password.POST("/reset", passwordHandler.RequestReset)
+	password.POST("/reset/confirm", passwordHandler.ConfirmReset)
+
+	invitationHandler := handlers.NewInvitationHandler(deps)
+	invitations := api.Group("/invitations", middleware.SetApiTimeout, limitDefault)
+	invitations.POST("", invitationHandler.Create, middleware.AllowRoles(aModel.Moderator), idempotent)
+	invitations.POST("/:token/accept", invitationHandler.Accept)
 
 	pvzHandler := handlers.NewPvzHandler(deps)
-	pvz := api.Group("/pvz", middleware.SetApiTimeout)
+	pvz := api.Group("/pvz", middleware.SetApiTimeout, limitDefault, idempotent)
 	pvz.POST("", pvzHandler.Create, middleware.AllowRoles(aModel.Moderator))
 	pvz.POST("/:pvzId/delete_last_product", pvzHandler.DeleteLastProduct, middleware.AllowRoles(aModel.Employee))
 	pvz.POST("/:pvzId/close_last_reception", pvzHandler.CloseLastReception, middleware.AllowRoles(aModel.Employee))
 	pvz.GET("", pvzHandler.ListWithFilterDate)
+	pvz.GET("/nearby", pvzHandler.Nearby)
+	pvz.PUT("/:pvzId", pvzHandler.Update, middleware.AllowRoles(aModel.Moderator))
 
 	receptionHandler := handlers.NewReceptionHandler(deps)
-	reception := api.Group("/receptions", middleware.SetApiTimeout)
+	pvz.GET("/:pvzId/receptions/current", receptionHandler.GetCurrent, middleware.AllowRoles(aModel.Employee, aModel.Moderator))
+
+	reception := api.Group("/receptions", middleware.SetApiTimeout, limitDefault, idempotent)
 	reception.POST("", receptionHandler.Create, middleware.AllowRoles(aModel.Employee))
 
 	productHandler := handlers.NewProductHandler(deps)
-	product := api.Group("/product", middleware.SetApiTimeout)
+	product := api.Group("/product", middleware.SetApiTimeout, limitProduct, idempotent)
 	product.POST("", productHandler.AddInReception, middleware.AllowRoles(aModel.Employee))
+
+	auditHandler := handlers.NewAuditHandler(deps)
+	audit := api.Group("/audit", middleware.SetApiTimeout, limitDefault)
+	audit.GET("", auditHandler.List, middleware.AllowRoles(aModel.Moderator))
+
+	statsHandler := handlers.NewStatsHandler(deps)
+	statsApi := api.Group("/stats", middleware.SetApiTimeout, limitDefault)
+	statsApi.GET("", statsHandler.Get, middleware.AllowRoles(aModel.Moderator))
+
+	adminHandler := handlers.NewAdminHandler()
+	adminApi := api.Group("/admin", middleware.SetApiTimeout, limitDefault)
+	adminApi.GET("/log-level", adminHandler.GetLogLevel, middleware.AllowRoles(aModel.Moderator))
+	adminApi.PUT("/log-level", adminHandler.SetLogLevel, middleware.AllowRoles(aModel.Moderator))
+
+	// the detailed report shows the build revision and the connection pool, so it stays off the public probes
+	e.GET("/health", healthHandler.Details,
+		middleware.HandleError, middleware.Authenticate, checkSession, limitDefault, middleware.AllowRoles(aModel.Moderator))
+
+	// event streams are long-lived, so they are registered outside the groups with SetApiTimeout
+	eventsHandler := handlers.NewEventsHandler(deps)
+	e.GET(apiPrefix+"/pvz/:pvzId/events", eventsHandler.Stream,
+		middleware.HandleError, middleware.Authenticate, checkSession, middleware.AllowRoles(aModel.Employee, aModel.Moderator))
+	// an import inserts its whole file in one transaction, which does not fit the request timeout;
+	// the body limit is checked before idempotent reads the body into memory
+	e.POST(apiPrefix+"/pvz/import", pvzHandler.Import,
+		middleware.HandleError, middleware.Authenticate, checkSession, limitDefault, middleware.AllowRoles(aModel.Moderator),
+		middleware.BodyLimit(configs.DefaultImportMaxBodySize), idempotent)
+	// exports stream until the whole window is read, so the request timeout does not apply either
+	e.GET(apiPrefix+"/pvz/export", pvzHandler.Export,
+		middleware.HandleError, middleware.Authenticate, checkSession, limitDefault, middleware.AllowRoles(aModel.Moderator))
 }
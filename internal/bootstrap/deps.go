@@ -1,21 +1,35 @@
 package bootstrap
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/lib/pq"
 	"pvz/configs"
+	"pvz/internal/events"
 	"pvz/internal/logger"
+	"pvz/internal/mailer"
+	"pvz/internal/migrations"
+	"pvz/internal/ratelimit"
 	"pvz/internal/repositories"
 	"pvz/internal/services"
 	"pvz/pkg/errors"
 )
 
 type Deps struct {
-	AuthService      services.AuthService
-	PvzService       services.PvzService
-	ReceptionService services.ReceptionService
-	ProductService   services.ProductService
+	AuthService        services.AuthService
+	PvzService         services.PvzService
+	ReceptionService   services.ReceptionService
+	ProductService     services.ProductService
+	AuditService       services.AuditService
+	HealthService      services.HealthService
+	UserService        services.UserService
+	InvitationService  services.InvitationService
+	PasswordService    services.PasswordService
+	IdempotencyService services.IdempotencyService
+	StatsService       services.StatsService
+	EventBus           events.Bus
+	RateLimiter        ratelimit.Limiter
 }
 
 func InitDeps() (Deps, error) {
@@ -23,45 +37,123 @@ func InitDeps() (Deps, error) {
 
 	log.Info("initializing dependencies")
 
-	dbDataSourceName, err := getDataSourceName()
-	if err != nil {
-		log.Error("failed to get data source name", "err", err)
-		return Deps{}, err
-	}
-
-	db, err := sql.Open(configs.AppConfiguration.DB.DriverName, dbDataSourceName)
+	db, err := OpenDB()
 	if err != nil {
-		log.Error("failed to open database connection", "err", err)
 		return Deps{}, err
 	}
 
-	if err = db.Ping(); err != nil {
-		log.Error("failed to ping database", "err", err)
-		return Deps{}, err
+	if configs.AppConfiguration.DB.MigrateOnStart {
+		if err = migrateOnStart(db); err != nil {
+			log.Error("failed to apply migrations", "err", err)
+			return Deps{}, err
+		}
 	}
-	log.Info("successfully connected to the database")
 
 	log.Info("initializing repositories")
 	userRepo := repositories.NewUserRepository(db)
 	pvzRepo := repositories.NewPvzRepository(db)
 	receptionRepo := repositories.NewReceptionRepository(db)
 	productRepo := repositories.NewProductRepository(db)
+	auditRepo := repositories.NewAuditRepository(db)
+	migrationRepo := repositories.NewMigrationRepository(db)
+	invitationRepo := repositories.NewInvitationRepository(db)
+	passwordResetRepo := repositories.NewPasswordResetRepository(db)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(db)
+	rateLimitRepo := repositories.NewRateLimitRepository(db)
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	statsRepo := repositories.NewStatsRepository(db)
+
+	log.Info("initializing mailer", "kind", configs.AppConfiguration.Mailer.Kind)
+	mail, err := mailer.New(configs.AppConfiguration.Mailer)
+	if err != nil {
+		log.Error("failed to initialize mailer", "err", err)
+		return Deps{}, err
+	}
+
+	log.Info("initializing rate limiter", "enabled", configs.AppConfiguration.RateLimit.Enabled, "backend", configs.AppConfiguration.RateLimit.Backend)
+	rateLimiter, err := ratelimit.New(configs.AppConfiguration.RateLimit, rateLimitRepo, db)
+	if err != nil {
+		log.Error("failed to initialize rate limiter", "err", err)
+		return Deps{}, err
+	}
+
+	log.Info("initializing event bus")
+	eventBus := events.NewBus(configs.AppConfiguration.Events.BufferSize)
 
 	log.Info("initializing services")
-	authService := services.NewAuthService(userRepo, db)
-	pvzService := services.NewPvzService(pvzRepo, productRepo, receptionRepo, db)
-	receptionService := services.NewReceptionService(receptionRepo, pvzRepo, db)
-	productService := services.NewProductService(productRepo, pvzRepo, receptionRepo, db)
+	authService := services.NewAuthService(userRepo, loginAttemptRepo, auditRepo, configs.AppConfiguration.Auth, db)
+	pvzService := services.NewPvzService(pvzRepo, productRepo, receptionRepo, auditRepo, eventBus, db)
+	receptionService := services.NewReceptionService(receptionRepo, pvzRepo, productRepo, auditRepo, eventBus, db)
+	auditService := services.NewAuditService(auditRepo, db)
+	healthService := services.NewHealthService(migrationRepo, migrations.LatestVersion, configs.AppConfiguration.Health.DBTimeout, db)
+	userService := services.NewUserService(userRepo, pvzRepo, auditRepo, db)
+	invitationService := services.NewInvitationService(invitationRepo, userRepo, pvzRepo, auditRepo, mail,
+		configs.AppConfiguration.Invitations.Ttl, configs.AppConfiguration.PublicUrl, db)
+	passwordService := services.NewPasswordService(userRepo, passwordResetRepo, auditRepo, mail,
+		configs.AppConfiguration.Auth.ResetTtl, configs.AppConfiguration.PublicUrl, db)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, configs.AppConfiguration.Idempotency.Ttl, db)
+	productService := services.NewProductService(productRepo, pvzRepo, receptionRepo, auditRepo, eventBus, db)
+	statsService := services.NewStatsService(statsRepo, db)
 
 	log.Info("all dependencies initialized successfully")
 	return Deps{
-		AuthService:      authService,
-		PvzService:       pvzService,
-		ReceptionService: receptionService,
-		ProductService:   productService,
+		AuthService:        authService,
+		PvzService:         pvzService,
+		ReceptionService:   receptionService,
+		ProductService:     productService,
+		AuditService:       auditService,
+		HealthService:      healthService,
+		UserService:        userService,
+		InvitationService:  invitationService,
+		PasswordService:    passwordService,
+		IdempotencyService: idempotencyService,
+		StatsService:       statsService,
+		EventBus:           eventBus,
+		RateLimiter:        rateLimiter,
 	}, nil
 }
 
+func OpenDB() (*sql.DB, error) {
+	log := logger.Log.With("scope", "bootstrap", "func", "OpenDB")
+
+	dbDataSourceName, err := getDataSourceName()
+	if err != nil {
+		log.Error("failed to get data source name", "err", err)
+		return nil, err
+	}
+
+	db, err := sql.Open(configs.AppConfiguration.DB.DriverName, dbDataSourceName)
+	if err != nil {
+		log.Error("failed to open database connection", "err", err)
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		log.Error("failed to ping database", "err", err)
+		_ = db.Close()
+		return nil, err
+	}
+	log.Info("successfully connected to the database")
+	return db, nil
+}
+
+func migrateOnStart(db *sql.DB) error {
+	log := logger.Log.With("scope", "bootstrap", "func", "migrateOnStart")
+
+	log.Info("applying migrations", "latestVersion", migrations.LatestVersion)
+	migrator, err := migrations.New(context.Background(), db, configs.AppConfiguration.DB.MigrateLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err = migrator.Up(); err != nil {
+		return err
+	}
+	log.Info("database schema is up to date")
+	return nil
+}
+
 func getDataSourceName() (string, error) {
 	log := logger.Log.With("scope", "bootstrap", "func", "getDataSourceName")
 
@@ -0,0 +1,121 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Type string
+
+const (
+	ReceptionOpened Type = "reception_opened"
+	ReceptionClosed Type = "reception_closed"
+	ProductAdded    Type = "product_added"
+	ProductDeleted  Type = "product_deleted"
+)
+
+type Event struct {
+	Id          uint64    `json:"-"`
+	Type        Type      `json:"type"`
+	PvzId       string    `json:"pvzId"`
+	ReceptionId string    `json:"receptionId"`
+	ProductId   string    `json:"productId,omitempty"`
+	ProductType string    `json:"productType,omitempty"`
+	DateTime    time.Time `json:"dateTime"`
+}
+
+type Publisher interface {
+	Publish(event Event)
+}
+
+type Bus interface {
+	Publisher
+	Subscribe(pvzId string) *Subscription
+	Unsubscribe(sub *Subscription)
+}
+
+// Subscription is closed for lagging when its buffer overflows, so a slow
+// client never blocks publishers and is expected to reconnect.
+type Subscription struct {
+	pvzId      string
+	events     chan Event
+	lagged     chan struct{}
+	laggedOnce sync.Once
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+func (s *Subscription) markLagged() {
+	s.laggedOnce.Do(func() {
+		close(s.lagged)
+	})
+}
+
+type busImpl struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*Subscription]struct{}
+	bufferSize  int
+	lastId      atomic.Uint64
+}
+
+func NewBus(bufferSize int) Bus {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &busImpl{
+		subscribers: map[string]map[*Subscription]struct{}{},
+		bufferSize:  bufferSize,
+	}
+}
+
+func (b *busImpl) Publish(event Event) {
+	event.Id = b.lastId.Add(1)
+	if event.DateTime.IsZero() {
+		event.DateTime = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[event.PvzId] {
+		select {
+		case sub.events <- event:
+		default:
+			sub.markLagged()
+		}
+	}
+}
+
+func (b *busImpl) Subscribe(pvzId string) *Subscription {
+	sub := &Subscription{
+		pvzId:  pvzId,
+		events: make(chan Event, b.bufferSize),
+		lagged: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[pvzId] == nil {
+		b.subscribers[pvzId] = map[*Subscription]struct{}{}
+	}
+	b.subscribers[pvzId][sub] = struct{}{}
+	return sub
+}
+
+func (b *busImpl) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers[sub.pvzId], sub)
+	if len(b.subscribers[sub.pvzId]) == 0 {
+		delete(b.subscribers, sub.pvzId)
+	}
+}
@@ -0,0 +1,59 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/events"
+)
+
+func TestBus(t *testing.T) {
+	pvzID := "550e8400-e29b-41d4-a716-446655440000"
+
+	t.Run("delivers events only to subscribers of the pvz", func(t *testing.T) {
+		bus := events.NewBus(4)
+		sub := bus.Subscribe(pvzID)
+		other := bus.Subscribe("other-pvz")
+		defer bus.Unsubscribe(sub)
+		defer bus.Unsubscribe(other)
+
+		bus.Publish(events.Event{Type: events.ReceptionOpened, PvzId: pvzID, ReceptionId: "rec1"})
+
+		select {
+		case ev := <-sub.Events():
+			require.Equal(t, events.ReceptionOpened, ev.Type)
+			require.Equal(t, "rec1", ev.ReceptionId)
+			require.NotZero(t, ev.Id)
+			require.False(t, ev.DateTime.IsZero())
+		default:
+			t.Fatal("expected event")
+		}
+		require.Len(t, other.Events(), 0)
+	})
+
+	t.Run("slow subscriber is marked lagged instead of blocking", func(t *testing.T) {
+		bus := events.NewBus(1)
+		sub := bus.Subscribe(pvzID)
+		defer bus.Unsubscribe(sub)
+
+		bus.Publish(events.Event{Type: events.ProductAdded, PvzId: pvzID})
+		bus.Publish(events.Event{Type: events.ProductAdded, PvzId: pvzID})
+
+		select {
+		case <-sub.Lagged():
+		default:
+			t.Fatal("expected subscription to be lagged")
+		}
+	})
+
+	t.Run("unsubscribed subscriber receives nothing", func(t *testing.T) {
+		bus := events.NewBus(1)
+		sub := bus.Subscribe(pvzID)
+		bus.Unsubscribe(sub)
+
+		bus.Publish(events.Event{Type: events.ProductDeleted, PvzId: pvzID})
+
+		require.Len(t, sub.Events(), 0)
+	})
+}
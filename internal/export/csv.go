@@ -0,0 +1,23 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvTable struct {
+	w *csv.Writer
+}
+
+func newCsvTable(w io.Writer) *csvTable {
+	return &csvTable{w: csv.NewWriter(w)}
+}
+
+func (ct *csvTable) WriteRecord(record []string) error {
+	return ct.w.Write(record)
+}
+
+func (ct *csvTable) Close() error {
+	ct.w.Flush()
+	return ct.w.Error()
+}
@@ -0,0 +1,210 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	eModel "pvz/internal/models/export"
+	"pvz/internal/models/pvz"
+	"strconv"
+	"time"
+)
+
+const (
+	RecordPvz       = "pvz"
+	RecordReception = "reception"
+	RecordProduct   = "product"
+)
+
+// Writer encodes export rows as they come. Close must be called to flush what is buffered and finish the document.
+type Writer interface {
+	Write(row eModel.Row) error
+	Close() error
+}
+
+// table is a sink for formats made of rows of cells.
+type table interface {
+	WriteRecord(record []string) error
+	Close() error
+}
+
+var columns = []string{
+	"pvzId", "pvzRegistrationDate", "pvzCity", "pvzAddress", "pvzLatitude", "pvzLongitude", "pvzWorkingHours", "pvzPhone",
+	"receptionId", "receptionDateTime", "receptionStatus", "receptionOpenedBy", "receptionClosedBy", "receptionClosedAt",
+	"productId", "productDateTime", "productType", "productAcceptedBy",
+}
+
+func NewWriter(format, layout string, w io.Writer) (Writer, error) {
+	if layout != eModel.LayoutFlat && layout != eModel.LayoutNested {
+		return nil, fmt.Errorf("unknown export layout %q", layout)
+	}
+
+	var t table
+	switch format {
+	case eModel.FormatNdjson:
+		if layout == eModel.LayoutNested {
+			return newNestedJsonWriter(w), nil
+		}
+		return newFlatJsonWriter(w), nil
+	case eModel.FormatCsv:
+		t = newCsvTable(w)
+	case eModel.FormatXlsx:
+		t = newXlsxTable(w)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+
+	if layout == eModel.LayoutNested {
+		return &nestedTableWriter{table: t}, nil
+	}
+	return &flatTableWriter{table: t}, nil
+}
+
+func ContentType(format string) string {
+	switch format {
+	case eModel.FormatCsv:
+		return "text/csv; charset=utf-8"
+	case eModel.FormatNdjson:
+		return "application/x-ndjson"
+	case eModel.FormatXlsx:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// flatTableWriter writes a line per product; a reception without products takes one line with empty product cells.
+type flatTableWriter struct {
+	table         table
+	headerWritten bool
+}
+
+func (fw *flatTableWriter) Write(row eModel.Row) error {
+	if err := fw.writeHeader(); err != nil {
+		return err
+	}
+	return fw.table.WriteRecord(flatRecord(row))
+}
+
+func (fw *flatTableWriter) Close() error {
+	if err := fw.writeHeader(); err != nil {
+		return err
+	}
+	return fw.table.Close()
+}
+
+func (fw *flatTableWriter) writeHeader() error {
+	if fw.headerWritten {
+		return nil
+	}
+	fw.headerWritten = true
+	return fw.table.WriteRecord(columns)
+}
+
+// nestedTableWriter writes a pvz line, then a line per reception of the pvz, each followed by lines of its products.
+// The record column tells which one a line is; only the cells of that level and the ids of its parents are filled.
+type nestedTableWriter struct {
+	table         table
+	headerWritten bool
+	pvzId         string
+	receptionId   string
+}
+
+func (nw *nestedTableWriter) Write(row eModel.Row) error {
+	if err := nw.writeHeader(); err != nil {
+		return err
+	}
+
+	record := flatRecord(row)
+	if row.Pvz.Id != nw.pvzId {
+		nw.pvzId, nw.receptionId = row.Pvz.Id, ""
+		if err := nw.table.WriteRecord(nestedRecord(RecordPvz, record)); err != nil {
+			return err
+		}
+	}
+	if row.Reception.Id != nw.receptionId {
+		nw.receptionId = row.Reception.Id
+		if err := nw.table.WriteRecord(nestedRecord(RecordReception, record)); err != nil {
+			return err
+		}
+	}
+	if row.Product == nil {
+		return nil
+	}
+	return nw.table.WriteRecord(nestedRecord(RecordProduct, record))
+}
+
+func (nw *nestedTableWriter) Close() error {
+	if err := nw.writeHeader(); err != nil {
+		return err
+	}
+	return nw.table.Close()
+}
+
+func (nw *nestedTableWriter) writeHeader() error {
+	if nw.headerWritten {
+		return nil
+	}
+	nw.headerWritten = true
+	return nw.table.WriteRecord(append([]string{"record"}, columns...))
+}
+
+// nestedCells are the columns each record kind fills in the nested layout.
+var nestedCells = map[string][]int{
+	RecordPvz:       {0, 1, 2, 3, 4, 5, 6, 7},
+	RecordReception: {0, 8, 9, 10, 11, 12, 13},
+	RecordProduct:   {0, 8, 14, 15, 16, 17},
+}
+
+func nestedRecord(kind string, record []string) []string {
+	result := make([]string, len(record)+1)
+	result[0] = kind
+	for _, i := range nestedCells[kind] {
+		result[i+1] = record[i]
+	}
+	return result
+}
+
+func flatRecord(row eModel.Row) []string {
+	record := []string{
+		row.Pvz.Id, formatTime(row.Pvz.RegistrationDate), row.Pvz.City, stringOrEmpty(row.Pvz.Address),
+		floatOrEmpty(row.Pvz.Latitude), floatOrEmpty(row.Pvz.Longitude), stringOrEmpty(row.Pvz.WorkingHours), stringOrEmpty(row.Pvz.Phone),
+		row.Reception.Id, formatTime(row.Reception.DateTime), row.Reception.Status,
+		stringOrEmpty(row.Reception.OpenedBy), stringOrEmpty(row.Reception.ClosedBy), timeOrEmpty(row.Reception.ClosedAt),
+		"", "", "", "",
+	}
+	if p := row.Product; p != nil {
+		record[14], record[15], record[16], record[17] = p.Id, formatTime(p.DateTime), p.Type, stringOrEmpty(p.AcceptedBy)
+	}
+	return record
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func timeOrEmpty(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func floatOrEmpty(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func productsOf(row eModel.Row) []pvz.Product {
+	if row.Product == nil {
+		return []pvz.Product{}
+	}
+	return []pvz.Product{*row.Product}
+}
@@ -0,0 +1,188 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/export"
+	eModel "pvz/internal/models/export"
+	"pvz/internal/models/pvz"
+)
+
+func testRows() []eModel.Row {
+	regDate := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	recDate := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	closedAt := recDate.Add(time.Hour)
+	employee := "emp1"
+	address, hours, phone := "Тверская, 1", "09:00-21:00", "+74951234567"
+	latitude, longitude := 55.7558, 37.6173
+
+	pvz1 := pvz.Pvz{Id: "pvz1", RegistrationDate: regDate, City: "Москва", Address: &address,
+		Latitude: &latitude, Longitude: &longitude, WorkingHours: &hours, Phone: &phone}
+	pvz2 := pvz.Pvz{Id: "pvz2", RegistrationDate: regDate, City: "Казань"}
+	rec1 := pvz.Reception{Id: "rec1", DateTime: recDate, PvzId: "pvz1", Status: "close", OpenedBy: &employee, ClosedBy: &employee, ClosedAt: &closedAt}
+	rec2 := pvz.Reception{Id: "rec2", DateTime: recDate, PvzId: "pvz2", Status: "in_progress", OpenedBy: &employee}
+
+	return []eModel.Row{
+		{Pvz: pvz1, Reception: rec1, Product: &pvz.Product{Id: "prod1", DateTime: recDate, Type: "обувь", ReceptionId: "rec1"}},
+		{Pvz: pvz1, Reception: rec1, Product: &pvz.Product{Id: "prod2", DateTime: recDate, Type: "одежда", ReceptionId: "rec1", AcceptedBy: &employee}},
+		{Pvz: pvz2, Reception: rec2},
+	}
+}
+
+func writeAll(t *testing.T, format, layout string, rows []eModel.Row) []byte {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(format, layout, &buf)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func readCsv(t *testing.T, body []byte) [][]string {
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestCsvFlat(t *testing.T) {
+	records := readCsv(t, writeAll(t, eModel.FormatCsv, eModel.LayoutFlat, testRows()))
+
+	require.Len(t, records, 4)
+	assert.Equal(t, "pvzId", records[0][0])
+	assert.Equal(t, "productAcceptedBy", records[0][17])
+	assert.Equal(t, []string{
+		"pvz1", "2024-12-01T10:00:00Z", "Москва", "Тверская, 1", "55.7558", "37.6173", "09:00-21:00", "+74951234567",
+		"rec1", "2025-01-10T09:00:00Z", "close", "emp1", "emp1", "2025-01-10T10:00:00Z",
+		"prod1", "2025-01-10T09:00:00Z", "обувь", "",
+	}, records[1])
+	assert.Equal(t, "prod2", records[2][14])
+	assert.Equal(t, []string{"", "", "", "", ""}, records[3][3:8], "pvz without location")
+	assert.Equal(t, []string{"", "", "", ""}, records[3][14:])
+}
+
+func TestCsvNested(t *testing.T) {
+	records := readCsv(t, writeAll(t, eModel.FormatCsv, eModel.LayoutNested, testRows()))
+
+	kinds := make([]string, 0, len(records))
+	for _, record := range records {
+		kinds = append(kinds, record[0])
+	}
+	assert.Equal(t, []string{"record", "pvz", "reception", "product", "product", "pvz", "reception"}, kinds)
+
+	assert.Equal(t, "Москва", records[1][3])
+	assert.Equal(t, "+74951234567", records[1][8])
+	assert.Empty(t, records[1][9])
+	assert.Equal(t, []string{"reception", "pvz1", "", "", "", "", "", "", "", "rec1"}, records[2][:10])
+	assert.Empty(t, records[2][15])
+	assert.Equal(t, "pvz1", records[3][1])
+	assert.Equal(t, "rec1", records[3][9])
+	assert.Empty(t, records[3][10])
+	assert.Equal(t, "prod1", records[3][15])
+}
+
+func TestCsvEmpty(t *testing.T) {
+	records := readCsv(t, writeAll(t, eModel.FormatCsv, eModel.LayoutFlat, nil))
+
+	require.Len(t, records, 1)
+	assert.Equal(t, "pvzId", records[0][0])
+}
+
+func TestNdjsonFlat(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(writeAll(t, eModel.FormatNdjson, eModel.LayoutFlat, testRows()))), "\n")
+	require.Len(t, lines, 3)
+
+	var first map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "pvz1", first["pvzId"])
+	assert.Equal(t, "Тверская, 1", first["pvzAddress"])
+	assert.Equal(t, 55.7558, first["pvzLatitude"])
+	assert.Equal(t, "prod1", first["productId"])
+	assert.Nil(t, first["productAcceptedBy"])
+
+	var last map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &last))
+	assert.Equal(t, "rec2", last["receptionId"])
+	assert.Nil(t, last["pvzLatitude"])
+	assert.Nil(t, last["productId"])
+}
+
+func TestNdjsonNested(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(writeAll(t, eModel.FormatNdjson, eModel.LayoutNested, testRows()))), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second pvz.ListResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "pvz1", first.Pvz.Id)
+	require.NotNil(t, first.Pvz.Phone)
+	assert.Equal(t, "+74951234567", *first.Pvz.Phone)
+	require.Len(t, first.Receptions, 1)
+	assert.Len(t, first.Receptions[0].Products, 2)
+
+	assert.Equal(t, "pvz2", second.Pvz.Id)
+	require.Len(t, second.Receptions, 1)
+	assert.NotNil(t, second.Receptions[0].Products)
+	assert.Empty(t, second.Receptions[0].Products)
+}
+
+func TestXlsx(t *testing.T) {
+	body := writeAll(t, eModel.FormatXlsx, eModel.LayoutFlat, testRows())
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "xl/workbook.xml")
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t>pvzId</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="R1" t="inlineStr"><is><t>productAcceptedBy</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C2" t="inlineStr"><is><t>Москва</t></is></c>`)
+	assert.Contains(t, sheet, `<row r="4">`)
+	assert.True(t, strings.HasSuffix(sheet, `</sheetData></worksheet>`))
+}
+
+func TestXlsxEscapesText(t *testing.T) {
+	rows := testRows()[:1]
+	rows[0].Pvz.City = `<Тверь & "Ко">`
+	body := writeAll(t, eModel.FormatXlsx, eModel.LayoutFlat, rows)
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	rc, err := zr.Open("xl/worksheets/sheet1.xml")
+	require.NoError(t, err)
+	sheet, err := io.ReadAll(rc)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(sheet), `&lt;Тверь &amp; &#34;Ко&#34;&gt;`)
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	_, err := export.NewWriter("pdf", eModel.LayoutFlat, io.Discard)
+	assert.Error(t, err)
+
+	_, err = export.NewWriter(eModel.FormatCsv, "tree", io.Discard)
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	eModel "pvz/internal/models/export"
+	"pvz/internal/models/pvz"
+	"time"
+)
+
+type flatLine struct {
+	PvzId               string     `json:"pvzId"`
+	PvzRegistrationDate time.Time  `json:"pvzRegistrationDate"`
+	PvzCity             string     `json:"pvzCity"`
+	PvzAddress          *string    `json:"pvzAddress"`
+	PvzLatitude         *float64   `json:"pvzLatitude"`
+	PvzLongitude        *float64   `json:"pvzLongitude"`
+	PvzWorkingHours     *string    `json:"pvzWorkingHours"`
+	PvzPhone            *string    `json:"pvzPhone"`
+	ReceptionId         string     `json:"receptionId"`
+	ReceptionDateTime   time.Time  `json:"receptionDateTime"`
+	ReceptionStatus     string     `json:"receptionStatus"`
+	ReceptionOpenedBy   *string    `json:"receptionOpenedBy"`
+	ReceptionClosedBy   *string    `json:"receptionClosedBy"`
+	ReceptionClosedAt   *time.Time `json:"receptionClosedAt"`
+	ProductId           *string    `json:"productId"`
+	ProductDateTime     *time.Time `json:"productDateTime"`
+	ProductType         *string    `json:"productType"`
+	ProductAcceptedBy   *string    `json:"productAcceptedBy"`
+}
+
+// flatJsonWriter writes an object per line with the same keys as the columns of the flat csv.
+type flatJsonWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newFlatJsonWriter(w io.Writer) *flatJsonWriter {
+	buf := bufio.NewWriter(w)
+	return &flatJsonWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (fw *flatJsonWriter) Write(row eModel.Row) error {
+	line := flatLine{
+		PvzId:               row.Pvz.Id,
+		PvzRegistrationDate: row.Pvz.RegistrationDate,
+		PvzCity:             row.Pvz.City,
+		PvzAddress:          row.Pvz.Address,
+		PvzLatitude:         row.Pvz.Latitude,
+		PvzLongitude:        row.Pvz.Longitude,
+		PvzWorkingHours:     row.Pvz.WorkingHours,
+		PvzPhone:            row.Pvz.Phone,
+		ReceptionId:         row.Reception.Id,
+		ReceptionDateTime:   row.Reception.DateTime,
+		ReceptionStatus:     row.Reception.Status,
+		ReceptionOpenedBy:   row.Reception.OpenedBy,
+		ReceptionClosedBy:   row.Reception.ClosedBy,
+		ReceptionClosedAt:   row.Reception.ClosedAt,
+	}
+	if p := row.Product; p != nil {
+		line.ProductId, line.ProductDateTime, line.ProductType, line.ProductAcceptedBy = &p.Id, &p.DateTime, &p.Type, p.AcceptedBy
+	}
+	return fw.enc.Encode(line)
+}
+
+func (fw *flatJsonWriter) Close() error {
+	return fw.buf.Flush()
+}
+
+// nestedJsonWriter writes a pvz per line shaped as in GET /pvz. Only the pvz being written is held in memory.
+type nestedJsonWriter struct {
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	current *pvz.ListResponse
+}
+
+func newNestedJsonWriter(w io.Writer) *nestedJsonWriter {
+	buf := bufio.NewWriter(w)
+	return &nestedJsonWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (nw *nestedJsonWriter) Write(row eModel.Row) error {
+	if nw.current != nil && nw.current.Pvz.Id != row.Pvz.Id {
+		if err := nw.flushPvz(); err != nil {
+			return err
+		}
+	}
+	if nw.current == nil {
+		nw.current = &pvz.ListResponse{Pvz: row.Pvz, Receptions: []pvz.ReceptionProducts{}}
+	}
+
+	receptions := nw.current.Receptions
+	if len(receptions) > 0 && receptions[len(receptions)-1].Reception.Id == row.Reception.Id {
+		last := &receptions[len(receptions)-1]
+		last.Products = append(last.Products, productsOf(row)...)
+		return nil
+	}
+	nw.current.Receptions = append(receptions, pvz.ReceptionProducts{Reception: row.Reception, Products: productsOf(row)})
+	return nil
+}
+
+func (nw *nestedJsonWriter) Close() error {
+	if err := nw.flushPvz(); err != nil {
+		return err
+	}
+	return nw.buf.Flush()
+}
+
+func (nw *nestedJsonWriter) flushPvz() error {
+	if nw.current == nil {
+		return nil
+	}
+	current := nw.current
+	nw.current = nil
+	return nw.enc.Encode(current)
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// xlsxMaxRows is the row limit of a spreadsheet in Excel.
+const xlsxMaxRows = 1048576
+
+var ErrTooManyRows = errors.New("export does not fit into a single xlsx sheet")
+
+// the static parts of a workbook with a single sheet
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="export" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxTable streams a workbook: the sheet is the last part of the zip and its rows are deflated as they are written.
+// Cells are inline strings, so no shared string table has to be built in memory.
+type xlsxTable struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+	err   error
+}
+
+func newXlsxTable(w io.Writer) *xlsxTable {
+	return &xlsxTable{zip: zip.NewWriter(w)}
+}
+
+func (xt *xlsxTable) WriteRecord(record []string) error {
+	if xt.err != nil {
+		return xt.err
+	}
+	if xt.sheet == nil {
+		if xt.err = xt.open(); xt.err != nil {
+			return xt.err
+		}
+	}
+	if xt.rows == xlsxMaxRows {
+		xt.err = ErrTooManyRows
+		return xt.err
+	}
+	xt.rows++
+
+	row := strconv.Itoa(xt.rows)
+	xt.sheet.WriteString(`<row r="` + row + `">`)
+	for i, cell := range record {
+		if cell == "" {
+			continue
+		}
+		xt.sheet.WriteString(`<c r="` + xlsxColumn(i) + row + `" t="inlineStr"><is><t>`)
+		xml.EscapeText(xt.sheet, []byte(cell))
+		xt.sheet.WriteString(`</t></is></c>`)
+	}
+	_, xt.err = xt.sheet.WriteString(`</row>`)
+	return xt.err
+}
+
+func (xt *xlsxTable) Close() error {
+	if xt.err != nil {
+		return xt.err
+	}
+	if xt.sheet == nil {
+		if err := xt.open(); err != nil {
+			return err
+		}
+	}
+	if _, err := xt.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := xt.sheet.Flush(); err != nil {
+		return err
+	}
+	return xt.zip.Close()
+}
+
+func (xt *xlsxTable) open() error {
+	for _, part := range xlsxParts {
+		f, err := xt.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := xt.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	xt.sheet = bufio.NewWriter(f)
+	_, err = xt.sheet.WriteString(xml.Header +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return err
+}
+
+// xlsxColumn turns a zero based column index into its letters: 0 is A, 26 is AA.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
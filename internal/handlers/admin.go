@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/logger"
+	"pvz/internal/models/admin"
+	"pvz/pkg/errors"
+)
+
+type AdminHandler struct{}
+
+func NewAdminHandler() *AdminHandler {
+	return &AdminHandler{}
+}
+
+func (ah *AdminHandler) GetLogLevel(c echo.Context) error {
+	return c.JSON(http.StatusOK, admin.LogLevelResponse{Level: logger.Level()})
+}
+
+func (ah *AdminHandler) SetLogLevel(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "admin", "method", "SetLogLevel")
+
+	var req admin.LogLevelRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	previous := logger.Level()
+	if err := logger.SetLevel(req.Level); err != nil {
+		log.Error("failed to set log level", "error", err)
+		return errors.NewWrongPropertyValue("Level", req.Level)
+	}
+	log.Warn("log level changed", "from", previous, "to", logger.Level())
+
+	return c.JSON(http.StatusOK, admin.LogLevelResponse{Level: logger.Level()})
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/bootstrap"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/models/audit"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+	"strconv"
+	"time"
+)
+
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+func NewAuditHandler(deps bootstrap.Deps) *AuditHandler {
+	return &AuditHandler{
+		auditService: deps.AuditService,
+	}
+}
+
+func (ah *AuditHandler) List(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "audit", "method", "List")
+
+	req := audit.ListRequest{}
+
+	if actorId := c.QueryParam("actorId"); actorId != "" {
+		req.ActorId = &actorId
+	}
+	if pvzId := c.QueryParam("pvzId"); pvzId != "" {
+		req.PvzId = &pvzId
+	}
+	if startDateStr := c.QueryParam("startDate"); startDateStr != "" {
+		startDate, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			return errors.NewBadParamValue("startDate", startDateStr)
+		}
+		req.StartDate = &startDate
+	}
+	if endDateStr := c.QueryParam("endDate"); endDateStr != "" {
+		endDate, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			return errors.NewBadParamValue("endDate", endDateStr)
+		}
+		req.EndDate = &endDate
+	}
+
+	page := c.QueryParam("page")
+	if page == "" {
+		page = "1"
+	}
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		return errors.NewBadParamValue("page", page)
+	}
+	req.Page = pageInt
+
+	limit := c.QueryParam("limit")
+	if limit == "" {
+		limit = "20"
+	}
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil {
+		return errors.NewBadParamValue("limit", limit)
+	}
+	req.Limit = limitInt
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	records, err := ah.auditService.List(c.Request().Context(), req)
+	if err != nil {
+		log.Error("auditService.List failed", "error", err)
+		return err
+	}
+	log.Info("received audit records", "count", len(records))
+
+	return c.JSON(http.StatusOK, records)
+}
+
+func auditMeta(c echo.Context) audit.Meta {
+	principal, _ := middleware.GetPrincipal(c)
+	return audit.Meta{
+		Actor:     principal,
+		RequestId: middleware.RequestIdFromContext(c.Request().Context()),
+		Ip:        middleware.ClientIp(c),
+	}
+}
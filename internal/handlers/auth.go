@@ -21,7 +21,7 @@ type AuthHandler struct {
 }
 
 func (ah *AuthHandler) Login(c echo.Context) error {
-	log := logger.Log.With("handler", "auth", "method", "Login")
+	log := logger.FromContext(c.Request().Context()).With("handler", "auth", "method", "Login")
 
 	var req auth.LoginRequest
 	if err := c.Bind(&req); err != nil {
@@ -35,7 +35,7 @@ func (ah *AuthHandler) Login(c echo.Context) error {
 	}
 
 	log.Info("calling authService.Login")
-	token, err := ah.authService.Login(req)
+	token, err := ah.authService.Login(c.Request().Context(), auditMeta(c), req)
 	if err != nil {
 		log.Error("authService.Login failed", "error", err)
 		return err
@@ -46,7 +46,7 @@ func (ah *AuthHandler) Login(c echo.Context) error {
 }
 
 func (ah *AuthHandler) Register(c echo.Context) error {
-	log := logger.Log.With("handler", "auth", "method", "Register")
+	log := logger.FromContext(c.Request().Context()).With("handler", "auth", "method", "Register")
 
 	var req auth.RegisterRequest
 	if err := c.Bind(&req); err != nil {
@@ -61,7 +61,7 @@ func (ah *AuthHandler) Register(c echo.Context) error {
 	}
 
 	log.Info("calling authService.Register")
-	user, err := ah.authService.Register(req)
+	user, err := ah.authService.Register(c.Request().Context(), auditMeta(c), req)
 	if err != nil {
 		log.Error("authService.Register failed", "error", err)
 		return err
@@ -71,8 +71,33 @@ func (ah *AuthHandler) Register(c echo.Context) error {
 	return c.JSON(http.StatusOK, user)
 }
 
+func (ah *AuthHandler) CreateUser(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "auth", "method", "CreateUser")
+
+	var req auth.RegisterRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling authService.CreateUser")
+	user, err := ah.authService.CreateUser(c.Request().Context(), auditMeta(c), req)
+	if err != nil {
+		log.Error("authService.CreateUser failed", "error", err)
+		return err
+	}
+
+	log.Info("user created")
+	return c.JSON(http.StatusCreated, user)
+}
+
 func (ah *AuthHandler) DummyLogin(c echo.Context) error {
-	log := logger.Log.With("handler", "auth", "method", "DummyLogin")
+	log := logger.FromContext(c.Request().Context()).With("handler", "auth", "method", "DummyLogin")
 
 	var req auth.DummyLoginRequest
 	if err := c.Bind(&req); err != nil {
@@ -86,7 +111,7 @@ func (ah *AuthHandler) DummyLogin(c echo.Context) error {
 	}
 
 	log.Info("calling authService.DummyLogin")
-	token, err := ah.authService.DummyLogin(req)
+	token, err := ah.authService.DummyLogin(c.Request().Context(), req)
 	if err != nil {
 		log.Error("authService.DummyLogin failed", "error", err)
 		return err
@@ -95,3 +120,28 @@ func (ah *AuthHandler) DummyLogin(c echo.Context) error {
 	log.Info("dummy login successful")
 	return c.JSON(http.StatusOK, token)
 }
+
+func (ah *AuthHandler) SetLanguage(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "auth", "method", "SetLanguage")
+
+	var req auth.SetLanguageRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling authService.SetLanguage")
+	token, err := ah.authService.SetLanguage(c.Request().Context(), auditMeta(c), req)
+	if err != nil {
+		log.Error("authService.SetLanguage failed", "error", err)
+		return err
+	}
+
+	log.Info("language set")
+	return c.JSON(http.StatusOK, token)
+}
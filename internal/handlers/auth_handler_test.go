@@ -19,6 +19,7 @@ import (
 	"pvz/internal/bootstrap"
 	"pvz/internal/handlers"
 	"pvz/internal/mocks"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/auth"
 )
 
@@ -45,7 +46,7 @@ func TestAuthHandlers(t *testing.T) {
 				"password": "avito12345",
 			},
 			setupMock: func(m *mocks.AuthService) {
-				m.On("Login", mock.MatchedBy(func(req auth.LoginRequest) bool {
+				m.On("Login", mock.Anything, mock.Anything, mock.MatchedBy(func(req auth.LoginRequest) bool {
 					return req.Email == "user@avito.ru" &&
 						req.Password == "avito12345"
 				})).Return("token-avito", nil)
@@ -62,10 +63,10 @@ func TestAuthHandlers(t *testing.T) {
 				"password": "avito12345",
 			},
 			setupMock: func(m *mocks.AuthService) {
-				m.On("Login", mock.Anything).Return("", errors.NewInvalidCredentials())
+				m.On("Login", mock.Anything, mock.Anything, mock.Anything).Return("", errors.NewInvalidCredentials())
 			},
 			expectedStatus: http.StatusUnauthorized,
-			wantResponse:   map[string]string{"message": "invalid credentials"},
+			wantResponse:   wantProblem{Code: "invalid_credentials", Detail: "invalid credentials"},
 		},
 		{
 			name:   "login invalid request",
@@ -76,7 +77,7 @@ func TestAuthHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.AuthService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property Email has bad value format invalid-email"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property email has bad value format invalid-email; property password is missing"},
 		},
 		{
 			name:   "success register",
@@ -88,7 +89,7 @@ func TestAuthHandlers(t *testing.T) {
 				"role":     "employee",
 			},
 			setupMock: func(m *mocks.AuthService) {
-				m.On("Register", mock.Anything).Return(auth.RegisterResponse{
+				m.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(auth.RegisterResponse{
 					Id:    "123",
 					Email: "new@avito.ru",
 					Role:  "employee",
@@ -111,10 +112,10 @@ func TestAuthHandlers(t *testing.T) {
 				"role":     "employee",
 			},
 			setupMock: func(m *mocks.AuthService) {
-				m.On("Register", mock.Anything).Return(auth.RegisterResponse{}, errors.NewObjectAlreadyExists("user", "email", "exists@avito.ru"))
+				m.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(auth.RegisterResponse{}, errors.NewObjectAlreadyExists("user", "email", "exists@avito.ru"))
 			},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "user with email exists@avito.ru already exists"},
+			wantResponse:   wantProblem{Code: "user_already_exists", Detail: "user with email exists@avito.ru already exists"},
 		},
 		{
 			name:   "register invalid role",
@@ -127,7 +128,52 @@ func TestAuthHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.AuthService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property Role has wrong value invalid"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property role has wrong value invalid"},
+		},
+		{
+			name:   "register moderator forbidden",
+			method: http.MethodPost,
+			path:   "/register",
+			body: map[string]interface{}{
+				"email":    "new@avito.ru",
+				"password": "avito12345",
+				"role":     "moderator",
+			},
+			setupMock: func(m *mocks.AuthService) {
+				m.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(auth.RegisterResponse{}, errors.NewAccessForbidden())
+			},
+			expectedStatus: http.StatusForbidden,
+			wantResponse:   wantProblem{Code: "access_forbidden", Detail: "access forbidden"},
+		},
+		{
+			name:   "moderator creates moderator",
+			method: http.MethodPost,
+			path:   "/users",
+			body: map[string]interface{}{
+				"email":    "mod@avito.ru",
+				"password": "avito12345",
+				"role":     "moderator",
+			},
+			setupMock: func(m *mocks.AuthService) {
+				m.On("CreateUser", mock.Anything, mock.Anything, mock.MatchedBy(func(req auth.RegisterRequest) bool {
+					return req.Email == "mod@avito.ru" && req.Role == "moderator"
+				})).Return(auth.RegisterResponse{Id: "user1", Email: "mod@avito.ru", Role: "moderator"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			wantResponse:   auth.RegisterResponse{Id: "user1", Email: "mod@avito.ru", Role: "moderator"},
+		},
+		{
+			name:   "dummy login disabled",
+			method: http.MethodPost,
+			path:   "/dummy-login",
+			body: map[string]interface{}{
+				"role": "employee",
+			},
+			setupMock: func(m *mocks.AuthService) {
+				m.On("DummyLogin", mock.Anything, mock.Anything).Return("", errors.NewFeatureDisabled("dummy login"))
+			},
+			expectedStatus: http.StatusForbidden,
+			wantResponse:   wantProblem{Code: "feature_disabled", Detail: "dummy login is disabled"},
 		},
 		{
 			name:   "success dummy login moderator",
@@ -137,7 +183,7 @@ func TestAuthHandlers(t *testing.T) {
 				"role": "moderator",
 			},
 			setupMock: func(m *mocks.AuthService) {
-				m.On("DummyLogin", mock.Anything).Return("dummy-token-moderator", nil)
+				m.On("DummyLogin", mock.Anything, mock.Anything).Return("dummy-token-moderator", nil)
 			},
 			expectedStatus: http.StatusOK,
 			wantResponse:   "dummy-token-moderator",
@@ -151,7 +197,44 @@ func TestAuthHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.AuthService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property Role has wrong value invalid"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property role has wrong value invalid"},
+		},
+		{
+			name:   "set language",
+			method: http.MethodPut,
+			path:   "/users/me/language",
+			body: map[string]interface{}{
+				"language": "ru",
+			},
+			setupMock: func(m *mocks.AuthService) {
+				m.On("SetLanguage", mock.Anything, mock.Anything, auth.SetLanguageRequest{Language: "ru"}).Return("token-ru", nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantResponse:   "token-ru",
+		},
+		{
+			name:   "set unsupported language",
+			method: http.MethodPut,
+			path:   "/users/me/language",
+			body: map[string]interface{}{
+				"language": "de",
+			},
+			setupMock:      func(m *mocks.AuthService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property language has wrong value de"},
+		},
+		{
+			name:   "login throttled",
+			method: http.MethodPost,
+			path:   "/login",
+			body: map[string]interface{}{
+				"email":    "user@avito.ru",
+				"password": "avito12345",
+			},
+			setupMock: func(m *mocks.AuthService) {
+				m.On("Login", mock.Anything, mock.Anything, mock.Anything).Return("", errors.NewTooManyLoginAttempts(30))
+			},
+			expectedStatus: http.StatusTooManyRequests,
 		},
 		{
 			name:   "login internal error",
@@ -162,10 +245,10 @@ func TestAuthHandlers(t *testing.T) {
 				"password": "avito12345",
 			},
 			setupMock: func(m *mocks.AuthService) {
-				m.On("Login", mock.Anything).Return("", errors.NewInternalError())
+				m.On("Login", mock.Anything, mock.Anything, mock.Anything).Return("", errors.NewInternalError())
 			},
 			expectedStatus: http.StatusInternalServerError,
-			wantResponse:   map[string]string{"message": "internal error"},
+			wantResponse:   wantProblem{Code: "internal_error", Detail: "internal error"},
 		},
 	}
 
@@ -194,8 +277,12 @@ func TestAuthHandlers(t *testing.T) {
 					return h.Login(c)
 				case "/register":
 					return h.Register(c)
+				case "/users":
+					return h.CreateUser(c)
 				case "/dummy-login":
 					return h.DummyLogin(c)
+				case "/users/me/language":
+					return h.SetLanguage(c)
 				default:
 					t.Fatalf("unsupported path %s", tt.path)
 					return nil
@@ -213,11 +300,8 @@ func TestAuthHandlers(t *testing.T) {
 				case string:
 					// Для строковых ответов удаляем кавычки
 					assert.JSONEq(t, `"`+want+`"`, rec.Body.String())
-				case map[string]string:
-					var response map[string]string
-					err := json.Unmarshal(rec.Body.Bytes(), &response)
-					require.NoError(t, err)
-					assert.Equal(t, want, response)
+				case wantProblem:
+					assertProblem(t, rec, want)
 				case auth.RegisterResponse:
 					var response auth.RegisterResponse
 					err := json.Unmarshal(rec.Body.Bytes(), &response)
@@ -232,3 +316,33 @@ func TestAuthHandlers(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandlers_LoginIgnoresSpoofedForwardedFor(t *testing.T) {
+	e := echo.New()
+	e.Validator = handlers.NewApiValidator()
+	logger.Init("debug")
+
+	mockAuth := mocks.NewAuthService(t)
+	var ips []string
+	mockAuth.On("Login", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ips = append(ips, args.Get(1).(audit.Meta).Ip)
+		}).
+		Return("", errors.NewInvalidCredentials())
+	h := handlers.NewAuthHandler(bootstrap.Deps{AuthService: mockAuth})
+
+	// a fresh forwarded address on every attempt must still count against the same ip
+	for _, spoofed := range []string{"203.0.113.1", "203.0.113.2", "203.0.113.3"} {
+		body, _ := json.Marshal(map[string]interface{}{"email": "user@avito.ru", "password": "avito12345"})
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderXForwardedFor, spoofed)
+		req.Header.Set(echo.HeaderXRealIP, spoofed)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, middleware.HandleError(h.Login)(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.1", "192.0.2.1"}, ips)
+}
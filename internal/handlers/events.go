@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/configs"
+	"pvz/internal/bootstrap"
+	"pvz/internal/events"
+	"pvz/internal/logger"
+	"time"
+)
+
+const mimeEventStream = "text/event-stream"
+
+type EventsHandler struct {
+	eventBus  events.Bus
+	keepAlive time.Duration
+}
+
+func NewEventsHandler(deps bootstrap.Deps) *EventsHandler {
+	return &EventsHandler{
+		eventBus:  deps.EventBus,
+		keepAlive: configs.DefaultEventsKeepAlive,
+	}
+}
+
+func (eh *EventsHandler) Stream(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "events", "method", "Stream")
+
+	pvzId := c.Param("pvzId")
+	log.Info("received request to stream pvz events", "pvzId", pvzId)
+
+	if err := apiValidator.ValidateParam("pvzId", pvzId, "required,uuid"); err != nil {
+		log.Error("parameter validation failed", "error", err)
+		return err
+	}
+
+	sub := eh.eventBus.Subscribe(pvzId)
+	defer eh.eventBus.Unsubscribe(sub)
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, mimeEventStream)
+	resp.Header().Set("Cache-Control", "no-cache")
+	resp.Header().Set("Connection", "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	keepAlive := time.NewTicker(eh.keepAlive)
+	defer keepAlive.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("client disconnected from event stream", "pvzId", pvzId)
+			return nil
+		case <-sub.Lagged():
+			log.Warn("client is too slow, closing event stream", "pvzId", pvzId)
+			return nil
+		case ev := <-sub.Events():
+			if err := writeEvent(resp, ev); err != nil {
+				log.Error("failed to write event", "error", err)
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(resp, ": keep-alive\n\n"); err != nil {
+				log.Error("failed to write keep-alive", "error", err)
+				return nil
+			}
+			resp.Flush()
+		}
+	}
+}
+
+func writeEvent(resp *echo.Response, ev events.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", ev.Id, ev.Type, data); err != nil {
+		return err
+	}
+	resp.Flush()
+	return nil
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/export"
+	"pvz/internal/logger"
+	eModel "pvz/internal/models/export"
+	"pvz/pkg/errors"
+	"time"
+)
+
+// Export streams the pvz history of a window. Once the first bytes are sent the status can no longer change,
+// so an error in the middle of the stream only cuts the file short and is logged.
+func (ph *PvzHandler) Export(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "Export")
+
+	req := eModel.Request{
+		Format: c.QueryParam("format"),
+		Layout: c.QueryParam("layout"),
+	}
+	if req.Format == "" {
+		req.Format = eModel.FormatCsv
+	}
+	if req.Layout == "" {
+		req.Layout = eModel.LayoutFlat
+	}
+
+	if startDateStr := c.QueryParam("startDate"); startDateStr != "" {
+		startDate, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			return errors.NewBadParamValue("startDate", startDateStr)
+		}
+		req.StartDate = &startDate
+	}
+	if endDateStr := c.QueryParam("endDate"); endDateStr != "" {
+		endDate, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			return errors.NewBadParamValue("endDate", endDateStr)
+		}
+		req.EndDate = &endDate
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	resp := c.Response()
+	w, err := export.NewWriter(req.Format, req.Layout, resp)
+	if err != nil {
+		log.Error("failed to create export writer", "error", err)
+		return errors.NewInternalError()
+	}
+
+	resp.Header().Set(echo.HeaderContentType, export.ContentType(req.Format))
+	resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="pvz-export.%s"`, req.Format))
+
+	if err := ph.pvzService.Export(c.Request().Context(), req, w.Write); err != nil {
+		return ph.exportFailed(c, err)
+	}
+	if err := w.Close(); err != nil {
+		return ph.exportFailed(c, err)
+	}
+
+	if !resp.Committed {
+		resp.WriteHeader(http.StatusOK)
+	}
+	log.Info("exported pvz history", "bytes", resp.Size)
+	return nil
+}
+
+func (ph *PvzHandler) exportFailed(c echo.Context, err error) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "Export")
+
+	resp := c.Response()
+	if resp.Committed {
+		log.Error("export stream interrupted", "bytes", resp.Size, "error", err)
+		return nil
+	}
+
+	log.Error("pvzService.Export failed", "error", err)
+	resp.Header().Del(echo.HeaderContentType)
+	resp.Header().Del(echo.HeaderContentDisposition)
+	if _, ok := err.(errors.Coded); ok {
+		return err
+	}
+	return errors.NewInternalError()
+}
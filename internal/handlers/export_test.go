@@ -0,0 +1,162 @@
+package handlers_test
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/bootstrap"
+	"pvz/internal/handlers"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/mocks"
+	"pvz/internal/models/export"
+	"pvz/internal/models/pvz"
+	"pvz/pkg/errors"
+)
+
+func TestPvzHandler_Export(t *testing.T) {
+	e := echo.New()
+	e.Validator = handlers.NewApiValidator()
+	logger.Init("debug")
+
+	date := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	row := export.Row{
+		Pvz:       pvz.Pvz{Id: "pvz1", RegistrationDate: date, City: "Москва"},
+		Reception: pvz.Reception{Id: "rec1", DateTime: date, PvzId: "pvz1", Status: "close"},
+		Product:   &pvz.Product{Id: "prod1", DateTime: date, Type: "обувь", ReceptionId: "rec1"},
+	}
+	// streams the given rows, then fails with err
+	stream := func(count int, err error) func(*mocks.PvzService) {
+		return func(m *mocks.PvzService) {
+			m.On("Export", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					fn := args.Get(2).(func(export.Row) error)
+					for i := 0; i < count; i++ {
+						if fn(row) != nil {
+							return
+						}
+					}
+				}).Return(err)
+		}
+	}
+	window := "startDate=2025-01-01T00:00:00Z&endDate=2025-02-01T00:00:00Z"
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.PvzService)
+		expectedStatus int
+		wantType       string
+		wantLines      int
+		wantProblem    *wantProblem
+	}{
+		{
+			name:  "flat csv by default",
+			query: window,
+			setupMock: func(m *mocks.PvzService) {
+				m.On("Export", mock.Anything, mock.MatchedBy(func(req export.Request) bool {
+					return req.Format == export.FormatCsv && req.Layout == export.LayoutFlat &&
+						req.StartDate.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+				}), mock.Anything).
+					Run(func(args mock.Arguments) {
+						_ = args.Get(2).(func(export.Row) error)(row)
+					}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantType:       "text/csv; charset=utf-8",
+			wantLines:      2,
+		},
+		{
+			name:           "nested ndjson",
+			query:          window + "&format=ndjson&layout=nested",
+			setupMock:      stream(3, nil),
+			expectedStatus: http.StatusOK,
+			wantType:       "application/x-ndjson",
+			wantLines:      1,
+		},
+		{
+			name:           "xlsx",
+			query:          window + "&format=xlsx",
+			setupMock:      stream(1, nil),
+			expectedStatus: http.StatusOK,
+			wantType:       "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		},
+		{
+			name:           "missing window",
+			query:          "format=csv",
+			setupMock:      func(m *mocks.PvzService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "validation_failed", Detail: "property startDate is missing; property endDate is missing"},
+		},
+		{
+			name:           "unknown format",
+			query:          window + "&format=pdf",
+			setupMock:      func(m *mocks.PvzService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "validation_failed", Detail: "property format has wrong value pdf"},
+		},
+		{
+			name:           "service error before streaming",
+			query:          window,
+			setupMock:      stream(0, errors.NewStartDateAfterEndDate()),
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "start_date_after_end_date", Detail: "start date after end date"},
+		},
+		{
+			name:           "failure while nothing is sent yet",
+			query:          window,
+			setupMock:      stream(1, io.ErrUnexpectedEOF),
+			expectedStatus: http.StatusInternalServerError,
+			wantProblem:    &wantProblem{Code: "internal_error", Detail: "internal error"},
+		},
+		{
+			name:           "failure in the middle of the stream keeps what was sent",
+			query:          window,
+			setupMock:      stream(200, errors.NewInternalError()),
+			expectedStatus: http.StatusOK,
+			wantType:       "text/csv; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPvz := mocks.NewPvzService(t)
+			tt.setupMock(mockPvz)
+			h := handlers.NewPvzHandler(bootstrap.Deps{PvzService: mockPvz})
+
+			req := httptest.NewRequest(http.MethodGet, "/pvz/export?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := middleware.HandleError(h.Export)(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.wantType != "" {
+				assert.Equal(t, tt.wantType, rec.Header().Get(echo.HeaderContentType))
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "attachment")
+			}
+			if tt.wantLines != 0 && tt.wantType == "text/csv; charset=utf-8" {
+				records, err := csv.NewReader(rec.Body).ReadAll()
+				require.NoError(t, err)
+				assert.Len(t, records, tt.wantLines)
+			} else if tt.wantLines != 0 {
+				assert.Len(t, strings.Split(strings.TrimSpace(rec.Body.String()), "\n"), tt.wantLines)
+			}
+			if tt.wantProblem != nil {
+				assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+				assertProblem(t, rec, *tt.wantProblem)
+			}
+		})
+	}
+}
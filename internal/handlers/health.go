@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/bootstrap"
+	"pvz/internal/models/health"
+	"pvz/internal/services"
+)
+
+type HealthHandler struct {
+	healthService services.HealthService
+}
+
+func NewHealthHandler(deps bootstrap.Deps) *HealthHandler {
+	return &HealthHandler{
+		healthService: deps.HealthService,
+	}
+}
+
+func (hh *HealthHandler) Live(c echo.Context) error {
+	report := hh.healthService.Live(c.Request().Context())
+	return c.JSON(statusFor(report), report)
+}
+
+func (hh *HealthHandler) Ready(c echo.Context) error {
+	report := hh.healthService.Ready(c.Request().Context())
+	return c.JSON(statusFor(report), report)
+}
+
+func (hh *HealthHandler) Details(c echo.Context) error {
+	report := hh.healthService.Details(c.Request().Context())
+	return c.JSON(statusFor(report.Report), report)
+}
+
+func statusFor(report health.Report) int {
+	if report.Status != health.StatusUp {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/configs"
+	"pvz/internal/i18n"
+	"pvz/internal/importer"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/models/pvz"
+	"pvz/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+// Import takes a csv or json file of pvzs as the request body. The format comes from the format param or, without it,
+// from Content-Type. Rows that fail are listed in the response; the rest are inserted unless dryRun is set.
+func (ph *PvzHandler) Import(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "Import")
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = pvz.ImportFormatJson
+		if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), "text/csv") {
+			format = pvz.ImportFormatCsv
+		}
+	}
+	if err := apiValidator.ValidateParam("format", format, "oneof=csv json"); err != nil {
+		log.Error("parameter validation failed", "error", err)
+		return err
+	}
+
+	dryRun := false
+	if dryRunStr := c.QueryParam("dryRun"); dryRunStr != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(dryRunStr); err != nil {
+			return errors.NewBadParamValue("dryRun", dryRunStr)
+		}
+	}
+	log.Info("received request to import pvzs", "format", format, "dryRun", dryRun)
+
+	rows, err := importer.Read(format, c.Request().Body, configs.DefaultImportMaxRows, apiValidator.ValidateRequest)
+	if err != nil {
+		log.Error("failed to read import file", "error", err)
+		return err
+	}
+
+	resp, err := ph.pvzService.Import(c.Request().Context(), auditMeta(c), pvz.ImportRequest{Rows: rows, DryRun: dryRun})
+	if err != nil {
+		log.Error("pvzService.Import failed", "error", err)
+		return err
+	}
+	log.Info("pvzs imported", "imported", resp.Imported, "failed", resp.Failed)
+
+	lang := middleware.Language(c)
+	trans := i18n.Translator(lang)
+	for i := range resp.Rows {
+		if len(resp.Rows[i].Errors) > 0 {
+			resp.Rows[i].Errors = errors.LocalizeFieldErrors(resp.Rows[i].Errors, trans)
+		}
+	}
+	c.Response().Header().Set(echo.HeaderVary, "Accept-Language")
+	c.Response().Header().Set("Content-Language", lang)
+
+	status := http.StatusOK
+	if !dryRun && resp.Imported > 0 {
+		status = http.StatusCreated
+	}
+	return c.JSON(status, resp)
+}
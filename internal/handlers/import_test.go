@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/configs"
+	"pvz/internal/bootstrap"
+	"pvz/internal/handlers"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/mocks"
+	"pvz/internal/models/pvz"
+	"pvz/pkg/errors"
+)
+
+func TestPvzHandler_Import(t *testing.T) {
+	e := echo.New()
+	e.Validator = handlers.NewApiValidator()
+	logger.Init("debug")
+
+	conflict := errors.NewFieldError(errors.NewObjectAlreadyExists("pvz", "id", "b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70"), "unique")
+
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		language       string
+		body           string
+		setupMock      func(*mocks.PvzService)
+		expectedStatus int
+		wantMessage    string
+		wantProblem    *wantProblem
+	}{
+		{
+			name:        "csv by content type",
+			contentType: "text/csv",
+			body:        "city,address\nМосква,Тверская 1\nСамара,\n",
+			setupMock: func(m *mocks.PvzService) {
+				m.On("Import", mock.Anything, mock.Anything, mock.MatchedBy(func(req pvz.ImportRequest) bool {
+					return !req.DryRun && len(req.Rows) == 2 && len(req.Rows[0].Errors) == 0 &&
+						len(req.Rows[1].Errors) == 1 && req.Rows[1].Errors[0].Code == "wrong_property_value"
+				})).Return(pvz.ImportResponse{Total: 2, Imported: 1, Failed: 1, Rows: []pvz.ImportResult{
+					{Row: 1, Pvz: &pvz.CreateResponse{Id: "pvz1", City: "Москва"}},
+					{Row: 2, Errors: []errors.FieldError{errors.NewFieldError(errors.NewWrongPropertyValue("city", "Самара"), "oneof")}},
+				}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:     "json dry run with localized row errors",
+			query:    "dryRun=true",
+			language: "ru",
+			body:     `[{"id": "b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70", "city": "Казань"}]`,
+			setupMock: func(m *mocks.PvzService) {
+				m.On("Import", mock.Anything, mock.Anything, mock.MatchedBy(func(req pvz.ImportRequest) bool {
+					return req.DryRun && len(req.Rows) == 1
+				})).Return(pvz.ImportResponse{DryRun: true, Total: 1, Failed: 1, Rows: []pvz.ImportResult{
+					{Row: 1, Errors: []errors.FieldError{conflict}},
+				}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantMessage:    "ПВЗ с id b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70 уже существует",
+		},
+		{
+			name:           "unknown format",
+			query:          "format=xml",
+			body:           "<pvzs/>",
+			setupMock:      func(m *mocks.PvzService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "bad_param_value", Detail: "bad param value xml"},
+		},
+		{
+			name:           "malformed file",
+			body:           `{"city": "Москва"}`,
+			setupMock:      func(m *mocks.PvzService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "malformed_body", Detail: "malformed body"},
+		},
+		{
+			name:           "too many rows",
+			body:           "[" + strings.Repeat("{},", configs.DefaultImportMaxRows) + "{}]",
+			setupMock:      func(m *mocks.PvzService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "property_too_big", Detail: "property rows is too big"},
+		},
+		{
+			name:           "bad dry run flag",
+			query:          "dryRun=maybe",
+			body:           `[]`,
+			setupMock:      func(m *mocks.PvzService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "bad_param_value", Detail: "bad param value maybe"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPvz := mocks.NewPvzService(t)
+			tt.setupMock(mockPvz)
+			h := handlers.NewPvzHandler(bootstrap.Deps{PvzService: mockPvz})
+
+			req := httptest.NewRequest(http.MethodPost, "/pvz/import?"+tt.query, strings.NewReader(tt.body))
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = echo.MIMEApplicationJSON
+			}
+			req.Header.Set(echo.HeaderContentType, contentType)
+			if tt.language != "" {
+				req.Header.Set("Accept-Language", tt.language)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := middleware.HandleError(h.Import)(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.wantMessage != "" {
+				var resp pvz.ImportResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.Rows, 1)
+				assert.Equal(t, tt.wantMessage, resp.Rows[0].Errors[0].Message)
+				assert.Equal(t, tt.language, rec.Header().Get("Content-Language"))
+			}
+			if tt.wantProblem != nil {
+				assertProblem(t, rec, *tt.wantProblem)
+			}
+		})
+	}
+}
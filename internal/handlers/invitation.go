@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/bootstrap"
+	"pvz/internal/logger"
+	"pvz/internal/models/invitation"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+)
+
+type InvitationHandler struct {
+	invitationService services.InvitationService
+}
+
+func NewInvitationHandler(deps bootstrap.Deps) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: deps.InvitationService,
+	}
+}
+
+func (ih *InvitationHandler) Create(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "invitation", "method", "Create")
+
+	var req invitation.CreateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling invitationService.Create")
+	resp, err := ih.invitationService.Create(c.Request().Context(), auditMeta(c), req)
+	if err != nil {
+		log.Error("invitationService.Create failed", "error", err)
+		return err
+	}
+
+	log.Info("invitation created")
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (ih *InvitationHandler) Accept(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "invitation", "method", "Accept")
+
+	token := c.Param("token")
+	if err := apiValidator.ValidateParam("token", token, "required"); err != nil {
+		log.Error("parameter validation failed", "error", err)
+		return err
+	}
+
+	var req invitation.AcceptRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling invitationService.Accept")
+	resp, err := ih.invitationService.Accept(c.Request().Context(), auditMeta(c), token, req)
+	if err != nil {
+		log.Error("invitationService.Accept failed", "error", err)
+		return err
+	}
+
+	log.Info("invitation accepted")
+	return c.JSON(http.StatusCreated, resp)
+}
@@ -0,0 +1,105 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/bootstrap"
+	"pvz/internal/handlers"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/mocks"
+	"pvz/internal/models/invitation"
+	"pvz/internal/models/user"
+	"pvz/pkg/errors"
+)
+
+func TestInvitationHandlers(t *testing.T) {
+	e := echo.New()
+	e.Validator = handlers.NewApiValidator()
+	logger.Init("debug")
+
+	tests := []struct {
+		name           string
+		accept         bool
+		body           interface{}
+		setupMock      func(*mocks.InvitationService)
+		expectedStatus int
+		wantMessage    string
+	}{
+		{
+			name: "create invitation",
+			body: map[string]interface{}{"email": "new@avito.ru", "role": "employee"},
+			setupMock: func(m *mocks.InvitationService) {
+				m.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(req invitation.CreateRequest) bool {
+					return req.Email == "new@avito.ru" && req.Role == "employee"
+				})).Return(invitation.CreateResponse{Id: "inv1", Email: "new@avito.ru", Role: "employee"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create invitation with bad pvz id",
+			body:           map[string]interface{}{"email": "new@avito.ru", "role": "employee", "pvzIds": []string{"nope"}},
+			setupMock:      func(m *mocks.InvitationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "accept invitation",
+			accept: true,
+			body:   map[string]interface{}{"password": "password123"},
+			setupMock: func(m *mocks.InvitationService) {
+				m.On("Accept", mock.Anything, mock.Anything, "tok", invitation.AcceptRequest{Password: "password123"}).
+					Return(user.Response{Id: "user1", Email: "new@avito.ru", Role: "employee"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "accept expired invitation",
+			accept: true,
+			body:   map[string]interface{}{"password": "password123"},
+			setupMock: func(m *mocks.InvitationService) {
+				m.On("Accept", mock.Anything, mock.Anything, "tok", mock.Anything).
+					Return(user.Response{}, errors.NewInvalidToken("invitation"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantMessage:    "invitation token is invalid or expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitations := mocks.NewInvitationService(t)
+			tt.setupMock(mockInvitations)
+			h := handlers.NewInvitationHandler(bootstrap.Deps{InvitationService: mockInvitations})
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/invitations", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := h.Create
+			if tt.accept {
+				c.SetParamNames("token")
+				c.SetParamValues("tok")
+				handler = h.Accept
+			}
+
+			err := middleware.HandleError(handler)(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.wantMessage != "" {
+				assertProblem(t, rec, wantProblem{Code: "invalid_token", Detail: tt.wantMessage})
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/bootstrap"
+	"pvz/internal/logger"
+	"pvz/internal/models/auth"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+)
+
+type PasswordHandler struct {
+	passwordService services.PasswordService
+}
+
+func NewPasswordHandler(deps bootstrap.Deps) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: deps.PasswordService,
+	}
+}
+
+func (ph *PasswordHandler) Change(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "password", "method", "Change")
+
+	var req auth.ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling passwordService.ChangePassword")
+	token, err := ph.passwordService.ChangePassword(c.Request().Context(), auditMeta(c), req)
+	if err != nil {
+		log.Error("passwordService.ChangePassword failed", "error", err)
+		return err
+	}
+
+	log.Info("password changed")
+	return c.JSON(http.StatusOK, token)
+}
+
+func (ph *PasswordHandler) RequestReset(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "password", "method", "RequestReset")
+
+	var req auth.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling passwordService.RequestReset")
+	if err := ph.passwordService.RequestReset(c.Request().Context(), auditMeta(c), req); err != nil {
+		log.Error("passwordService.RequestReset failed", "error", err)
+		return err
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+func (ph *PasswordHandler) ConfirmReset(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "password", "method", "ConfirmReset")
+
+	var req auth.ConfirmResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	log.Info("calling passwordService.ConfirmReset")
+	if err := ph.passwordService.ConfirmReset(c.Request().Context(), auditMeta(c), req); err != nil {
+		log.Error("passwordService.ConfirmReset failed", "error", err)
+		return err
+	}
+
+	log.Info("password reset confirmed")
+	return c.NoContent(http.StatusNoContent)
+}
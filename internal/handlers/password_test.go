@@ -0,0 +1,121 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/bootstrap"
+	"pvz/internal/handlers"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/mocks"
+	"pvz/internal/models/auth"
+	"pvz/pkg/errors"
+)
+
+func TestPasswordHandlers(t *testing.T) {
+	e := echo.New()
+	e.Validator = handlers.NewApiValidator()
+	logger.Init("debug")
+
+	tests := []struct {
+		name           string
+		method         string
+		body           interface{}
+		setupMock      func(*mocks.PasswordService)
+		expectedStatus int
+		wantMessage    string
+	}{
+		{
+			name:   "change password",
+			method: "change",
+			body:   map[string]interface{}{"oldPassword": "oldpassword", "newPassword": "newpassword"},
+			setupMock: func(m *mocks.PasswordService) {
+				m.On("ChangePassword", mock.Anything, mock.Anything,
+					auth.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "newpassword"}).Return("new-token", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "change password with wrong old password",
+			method: "change",
+			body:   map[string]interface{}{"oldPassword": "wrong", "newPassword": "newpassword"},
+			setupMock: func(m *mocks.PasswordService) {
+				m.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything).Return("", errors.NewInvalidCredentials())
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "change password too short",
+			method:         "change",
+			body:           map[string]interface{}{"oldPassword": "oldpassword", "newPassword": "short"},
+			setupMock:      func(m *mocks.PasswordService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "request reset",
+			method: "reset",
+			body:   map[string]interface{}{"email": "user@avito.ru"},
+			setupMock: func(m *mocks.PasswordService) {
+				m.On("RequestReset", mock.Anything, mock.Anything, auth.ResetPasswordRequest{Email: "user@avito.ru"}).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:   "confirm reset",
+			method: "confirm",
+			body:   map[string]interface{}{"token": "tok", "newPassword": "newpassword"},
+			setupMock: func(m *mocks.PasswordService) {
+				m.On("ConfirmReset", mock.Anything, mock.Anything,
+					auth.ConfirmResetPasswordRequest{Token: "tok", NewPassword: "newpassword"}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "confirm reset with expired token",
+			method: "confirm",
+			body:   map[string]interface{}{"token": "tok", "newPassword": "newpassword"},
+			setupMock: func(m *mocks.PasswordService) {
+				m.On("ConfirmReset", mock.Anything, mock.Anything, mock.Anything).Return(errors.NewInvalidToken("password reset"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantMessage:    "password reset token is invalid or expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPasswords := mocks.NewPasswordService(t)
+			tt.setupMock(mockPasswords)
+			h := handlers.NewPasswordHandler(bootstrap.Deps{PasswordService: mockPasswords})
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/password", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := map[string]echo.HandlerFunc{
+				"change":  h.Change,
+				"reset":   h.RequestReset,
+				"confirm": h.ConfirmReset,
+			}[tt.method]
+
+			err := middleware.HandleError(handler)(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.wantMessage != "" {
+				assertProblem(t, rec, wantProblem{Code: "invalid_token", Detail: tt.wantMessage})
+			}
+		})
+	}
+}
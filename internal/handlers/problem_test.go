@@ -0,0 +1,30 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/pkg/errors"
+)
+
+// wantProblem is the part of a problem+json error body the handler tests check.
+type wantProblem struct {
+	Code   string
+	Detail string
+}
+
+func assertProblem(t *testing.T, rec *httptest.ResponseRecorder, want wantProblem) {
+	t.Helper()
+	assert.Equal(t, errors.ProblemContentType, rec.Header().Get(echo.HeaderContentType))
+
+	var problem errors.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, rec.Code, problem.Status)
+	assert.Equal(t, want.Code, problem.Code)
+	assert.Equal(t, want.Detail, problem.Detail)
+}
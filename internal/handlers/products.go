@@ -21,7 +21,7 @@ func NewProductHandler(deps bootstrap.Deps) *ProductHandler {
 }
 
 func (ph *ProductHandler) AddInReception(c echo.Context) error {
-	log := logger.Log.With("handler", "product", "method", "AddInReception")
+	log := logger.FromContext(c.Request().Context()).With("handler", "product", "method", "AddInReception")
 
 	var req product.AddInReceptionRequest
 	if err := c.Bind(&req); err != nil {
@@ -35,7 +35,7 @@ func (ph *ProductHandler) AddInReception(c echo.Context) error {
 	}
 
 	log.Info("calling productService.AddInReception", "pvzId", req.PvzId)
-	createdProduct, err := ph.productService.AddInReception(req)
+	createdProduct, err := ph.productService.AddInReception(c.Request().Context(), auditMeta(c), req)
 	if err != nil {
 		log.Error("productService.AddInReception failed", "error", err)
 		return err
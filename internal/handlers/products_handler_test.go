@@ -49,7 +49,7 @@ func TestProductHandlers(t *testing.T) {
 				"pvzId": "550e8400-e29b-41d4-a716-446655440000",
 			},
 			setupMock: func(m *mocks.ProductService) {
-				m.On("AddInReception", mock.MatchedBy(func(req product.AddInReceptionRequest) bool {
+				m.On("AddInReception", mock.Anything, mock.Anything, mock.MatchedBy(func(req product.AddInReceptionRequest) bool {
 					return req.Type == "электроника" &&
 						req.PvzId == "550e8400-e29b-41d4-a716-446655440000"
 				})).Return(product.AddInReceptionResponse{
@@ -77,7 +77,7 @@ func TestProductHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.ProductService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property Type has wrong value мебель"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property type has wrong value мебель"},
 		},
 		{
 			name:   "missing pvzId",
@@ -88,7 +88,7 @@ func TestProductHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.ProductService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property PvzId is missing"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property pvzId is missing"},
 		},
 		{
 			name:   "pvz not found",
@@ -99,13 +99,13 @@ func TestProductHandlers(t *testing.T) {
 				"pvzId": "550e8400-e29b-41d4-a716-446655440000",
 			},
 			setupMock: func(m *mocks.ProductService) {
-				m.On("AddInReception", mock.Anything).Return(
+				m.On("AddInReception", mock.Anything, mock.Anything, mock.Anything).Return(
 					product.AddInReceptionResponse{},
 					errors.NewObjectNotFound("pvz"),
 				)
 			},
 			expectedStatus: http.StatusNotFound,
-			wantResponse:   map[string]string{"message": "pvz not found"},
+			wantResponse:   wantProblem{Code: "pvz_not_found", Detail: "pvz not found"},
 		},
 		{
 			name:   "reception not in progress",
@@ -116,13 +116,13 @@ func TestProductHandlers(t *testing.T) {
 				"pvzId": "550e8400-e29b-41d4-a716-446655440000",
 			},
 			setupMock: func(m *mocks.ProductService) {
-				m.On("AddInReception", mock.Anything).Return(
+				m.On("AddInReception", mock.Anything, mock.Anything, mock.Anything).Return(
 					product.AddInReceptionResponse{},
 					errors.NewReceptionIsNotInProgress("456"),
 				)
 			},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "reception with id 456 is not closed"},
+			wantResponse:   wantProblem{Code: "reception_not_in_progress", Detail: "reception with id 456 is not closed"},
 		},
 		{
 			name:   "internal error",
@@ -133,13 +133,13 @@ func TestProductHandlers(t *testing.T) {
 				"pvzId": "550e8400-e29b-41d4-a716-446655440000",
 			},
 			setupMock: func(m *mocks.ProductService) {
-				m.On("AddInReception", mock.Anything).Return(
+				m.On("AddInReception", mock.Anything, mock.Anything, mock.Anything).Return(
 					product.AddInReceptionResponse{},
 					errors.NewInternalError(),
 				)
 			},
 			expectedStatus: http.StatusInternalServerError,
-			wantResponse:   map[string]string{"message": "internal error"},
+			wantResponse:   wantProblem{Code: "internal_error", Detail: "internal error"},
 		},
 	}
 
@@ -179,11 +179,8 @@ func TestProductHandlers(t *testing.T) {
 					err := json.Unmarshal(rec.Body.Bytes(), &response)
 					require.NoError(t, err)
 					assert.Equal(t, want, response)
-				case map[string]string:
-					var response map[string]string
-					err := json.Unmarshal(rec.Body.Bytes(), &response)
-					require.NoError(t, err)
-					assert.Equal(t, want, response)
+				case wantProblem:
+					assertProblem(t, rec, want)
 				default:
 					t.Fatal("unsupported response type")
 				}
@@ -23,7 +23,7 @@ func NewPvzHandler(deps bootstrap.Deps) *PvzHandler {
 }
 
 func (ph *PvzHandler) Create(c echo.Context) error {
-	log := logger.Log.With("handler", "pvz", "method", "Create")
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "Create")
 	log.Info("received request to create pvz")
 
 	var req pvz.CreateRequest
@@ -37,7 +37,7 @@ func (ph *PvzHandler) Create(c echo.Context) error {
 		return err
 	}
 
-	createdPvz, err := ph.pvzService.Create(req)
+	createdPvz, err := ph.pvzService.Create(c.Request().Context(), auditMeta(c), req)
 	if err != nil {
 		log.Error("pvzService.Create failed", "error", err)
 		return err
@@ -48,17 +48,17 @@ func (ph *PvzHandler) Create(c echo.Context) error {
 }
 
 func (ph *PvzHandler) DeleteLastProduct(c echo.Context) error {
-	log := logger.Log.With("handler", "pvz", "method", "DeleteLastProduct")
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "DeleteLastProduct")
 
 	pvzId := c.Param("pvzId")
 	log.Info("received request to delete last product", "pvzId", pvzId)
 
-	if err := apiValidator.ValidateParam(pvzId, "required,uuid"); err != nil {
+	if err := apiValidator.ValidateParam("pvzId", pvzId, "required,uuid"); err != nil {
 		log.Error("parameter validation failed", "error", err)
 		return err
 	}
 
-	updatedPvz, err := ph.pvzService.DeleteLastProduct(pvzId)
+	updatedPvz, err := ph.pvzService.DeleteLastProduct(c.Request().Context(), auditMeta(c), pvzId)
 	if err != nil {
 		log.Error("pvzService.DeleteLastProduct failed", "error", err)
 		return err
@@ -69,17 +69,17 @@ func (ph *PvzHandler) DeleteLastProduct(c echo.Context) error {
 }
 
 func (ph *PvzHandler) CloseLastReception(c echo.Context) error {
-	log := logger.Log.With("handler", "pvz", "method", "CloseLastReception")
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "CloseLastReception")
 
 	pvzId := c.Param("pvzId")
 	log.Info("received request to close last reception", "pvzId", pvzId)
 
-	if err := apiValidator.ValidateParam(pvzId, "required,uuid"); err != nil {
+	if err := apiValidator.ValidateParam("pvzId", pvzId, "required,uuid"); err != nil {
 		log.Error("parameter validation failed", "error", err)
 		return err
 	}
 
-	updatedPvz, err := ph.pvzService.CLoseLastReception(pvzId)
+	updatedPvz, err := ph.pvzService.CLoseLastReception(c.Request().Context(), auditMeta(c), pvzId)
 	if err != nil {
 		log.Error("pvzService.CLoseLastReception failed", "error", err)
 		return err
@@ -90,19 +90,19 @@ func (ph *PvzHandler) CloseLastReception(c echo.Context) error {
 }
 
 func (ph *PvzHandler) ListWithFilterDate(c echo.Context) error {
-	log := logger.Log.With("handler", "pvz", "method", "ListWithFilterDate")
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "ListWithFilterDate")
 
 	startDateStr := c.QueryParam("startDate")
 
 	startDate, err := time.Parse(time.RFC3339, startDateStr)
 	if err != nil {
-		return errors.NewBadParamValue(startDateStr)
+		return errors.NewBadParamValue("startDate", startDateStr)
 	}
 	
 	endDateStr := c.QueryParam("endDate")
 	endDate, err := time.Parse(time.RFC3339, endDateStr)
 	if err != nil {
-		return errors.NewBadParamValue(endDateStr)
+		return errors.NewBadParamValue("endDate", endDateStr)
 	}
 	page := c.QueryParam("page")
 	if page == "" {
@@ -110,7 +110,7 @@ func (ph *PvzHandler) ListWithFilterDate(c echo.Context) error {
 	}
 	pageInt, err := strconv.Atoi(page)
 	if err != nil {
-		return errors.NewBadParamValue(page)
+		return errors.NewBadParamValue("page", page)
 	}
 
 	limit := c.QueryParam("limit")
@@ -119,7 +119,7 @@ func (ph *PvzHandler) ListWithFilterDate(c echo.Context) error {
 	}
 	limitInt, err := strconv.Atoi(limit)
 	if err != nil {
-		return errors.NewBadParamValue(limit)
+		return errors.NewBadParamValue("limit", limit)
 	}
 
 	req := pvz.ListRequest{
@@ -128,7 +128,7 @@ func (ph *PvzHandler) ListWithFilterDate(c echo.Context) error {
 		Page:      pageInt,
 		Limit:     limitInt,
 	}
-	list, err := ph.pvzService.ListWithFilterDate(req)
+	list, err := ph.pvzService.ListWithFilterDate(c.Request().Context(), req)
 	if err != nil {
 		log.Error("pvzService.ListWithFilterDate failed", "error", err)
 		return err
@@ -137,3 +137,88 @@ func (ph *PvzHandler) ListWithFilterDate(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, list)
 }
+
+func (ph *PvzHandler) Update(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "Update")
+
+	pvzId := c.Param("pvzId")
+	log.Info("received request to update pvz", "pvzId", pvzId)
+
+	if err := apiValidator.ValidateParam("pvzId", pvzId, "required,uuid"); err != nil {
+		log.Error("parameter validation failed", "error", err)
+		return err
+	}
+
+	var req pvz.UpdateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Error("failed to bind request body", "error", err)
+		return errors.NewMalformedBody()
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	updatedPvz, err := ph.pvzService.Update(c.Request().Context(), auditMeta(c), pvzId, req)
+	if err != nil {
+		log.Error("pvzService.Update failed", "error", err)
+		return err
+	}
+	log.Info("pvz successfully updated", "pvzId", updatedPvz.Id)
+
+	return c.JSON(http.StatusOK, updatedPvz)
+}
+
+func (ph *PvzHandler) Nearby(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "pvz", "method", "Nearby")
+
+	req := pvz.NearbyRequest{RadiusMeters: 5000, Limit: 20}
+
+	var err error
+	if req.Latitude, err = floatParam(c, "lat"); err != nil {
+		return err
+	}
+	if req.Longitude, err = floatParam(c, "lon"); err != nil {
+		return err
+	}
+	radius, err := floatParam(c, "radius")
+	if err != nil {
+		return err
+	}
+	if radius != nil {
+		req.RadiusMeters = *radius
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return errors.NewBadParamValue("limit", limit)
+		}
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	found, err := ph.pvzService.Nearby(c.Request().Context(), req)
+	if err != nil {
+		log.Error("pvzService.Nearby failed", "error", err)
+		return err
+	}
+	log.Info("received nearby pvzs", "count", len(found))
+
+	return c.JSON(http.StatusOK, found)
+}
+
+// floatParam returns nil for a param that is not given.
+func floatParam(c echo.Context, name string) (*float64, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, errors.NewBadParamValue(name, value)
+	}
+	return &parsed, nil
+}
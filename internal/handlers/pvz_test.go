@@ -23,6 +23,10 @@ import (
 	"pvz/internal/mocks"
 )
 
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
 func TestPvzHandlers(t *testing.T) {
 	e := echo.New()
 	apiValidator := handlers.NewApiValidator()
@@ -51,7 +55,7 @@ func TestPvzHandlers(t *testing.T) {
 				"city": "Москва",
 			},
 			setupMock: func(m *mocks.PvzService) {
-				m.On("Create", mock.Anything).Return(pvz.CreateResponse{
+				m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(pvz.CreateResponse{
 					Id:               testUUID,
 					RegistrationDate: validTime,
 					City:             "Москва",
@@ -73,13 +77,13 @@ func TestPvzHandlers(t *testing.T) {
 				"city": "Санкт-Петербург",
 			},
 			setupMock: func(m *mocks.PvzService) {
-				m.On("Create", mock.Anything).Return(
+				m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(
 					pvz.CreateResponse{},
 					errors.NewObjectAlreadyExists("pvz", "id", testUUID),
 				)
 			},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "pvz with id " + testUUID + " already exists"},
+			wantResponse:   wantProblem{Code: "pvz_already_exists", Detail: "pvz with id " + testUUID + " already exists"},
 		},
 		{
 			name:   "create invalid city",
@@ -90,7 +94,7 @@ func TestPvzHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.PvzService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property City has wrong value Новосибирск"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property city has wrong value Новосибирск"},
 		},
 		{
 			name:   "success delete last product",
@@ -98,7 +102,7 @@ func TestPvzHandlers(t *testing.T) {
 			path:   "/pvz/:pvzId/products/last",
 			params: map[string]string{"pvzId": testUUID},
 			setupMock: func(m *mocks.PvzService) {
-				m.On("DeleteLastProduct", testUUID).Return(pvz.DeleteLastProductResponse{
+				m.On("DeleteLastProduct", mock.Anything, mock.Anything, testUUID).Return(pvz.DeleteLastProductResponse{
 					Id: "product-123",
 				}, nil)
 			},
@@ -111,13 +115,13 @@ func TestPvzHandlers(t *testing.T) {
 			path:   "/pvz/:pvzId/products/last",
 			params: map[string]string{"pvzId": testUUID},
 			setupMock: func(m *mocks.PvzService) {
-				m.On("DeleteLastProduct", testUUID).Return(
+				m.On("DeleteLastProduct", mock.Anything, mock.Anything, testUUID).Return(
 					pvz.DeleteLastProductResponse{},
 					errors.NewObjectNotFound("pvz"),
 				)
 			},
 			expectedStatus: http.StatusNotFound,
-			wantResponse:   map[string]string{"message": "pvz not found"},
+			wantResponse:   wantProblem{Code: "pvz_not_found", Detail: "pvz not found"},
 		},
 		{
 			name:   "delete last product invalid uuid",
@@ -127,7 +131,7 @@ func TestPvzHandlers(t *testing.T) {
 			setupMock: func(m *mocks.PvzService) {
 			},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "bad param value invalid"},
+			wantResponse:   wantProblem{Code: "bad_param_value", Detail: "bad param value invalid"},
 		},
 		{
 			name:   "success close last reception",
@@ -135,7 +139,7 @@ func TestPvzHandlers(t *testing.T) {
 			path:   "/pvz/:pvzId/receptions/close",
 			params: map[string]string{"pvzId": testUUID},
 			setupMock: func(m *mocks.PvzService) {
-				m.On("CLoseLastReception", testUUID).Return(pvz.CloseLastProductResponse{
+				m.On("CLoseLastReception", mock.Anything, mock.Anything, testUUID).Return(pvz.CloseLastProductResponse{
 					Id:       "rec-123",
 					DateTime: validTime,
 					PvzId:    testUUID,
@@ -156,13 +160,13 @@ func TestPvzHandlers(t *testing.T) {
 			path:   "/pvz/:pvzId/receptions/close",
 			params: map[string]string{"pvzId": testUUID},
 			setupMock: func(m *mocks.PvzService) {
-				m.On("CLoseLastReception", testUUID).Return(
+				m.On("CLoseLastReception", mock.Anything, mock.Anything, testUUID).Return(
 					pvz.CloseLastProductResponse{},
 					errors.NewNoInProgressReception(),
 				)
 			},
 			expectedStatus: http.StatusNotFound,
-			wantResponse:   map[string]string{"message": "no in-progress reception"},
+			wantResponse:   wantProblem{Code: "no_in_progress_reception", Detail: "no in-progress reception"},
 		},
 		{
 			name:   "success list with filter",
@@ -177,7 +181,7 @@ func TestPvzHandlers(t *testing.T) {
 			setupMock: func(m *mocks.PvzService) {
 				start, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 				end, _ := time.Parse(time.RFC3339, "2024-01-31T23:59:59Z")
-				m.On("ListWithFilterDate", pvz.ListRequest{
+				m.On("ListWithFilterDate", mock.Anything, pvz.ListRequest{
 					StartDate: &start,
 					EndDate:   &end,
 					Page:      2,
@@ -249,7 +253,7 @@ func TestPvzHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.PvzService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "bad param value invalid"},
+			wantResponse:   wantProblem{Code: "bad_param_value", Detail: "bad param value invalid"},
 		},
 		{
 			name:   "list start after end date",
@@ -262,7 +266,7 @@ func TestPvzHandlers(t *testing.T) {
 			setupMock: func(m *mocks.PvzService) {
 				start, _ := time.Parse(time.RFC3339, "2024-02-01T00:00:00Z")
 				end, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
-				m.On("ListWithFilterDate", pvz.ListRequest{
+				m.On("ListWithFilterDate", mock.Anything, pvz.ListRequest{
 					StartDate: &start,
 					EndDate:   &end,
 					Page:      1,
@@ -270,7 +274,94 @@ func TestPvzHandlers(t *testing.T) {
 				}).Return(nil, errors.NewStartDateAfterEndDate())
 			},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "start date after end date"},
+			wantResponse:   wantProblem{Code: "start_date_after_end_date", Detail: "start date after end date"},
+		},
+		{
+			name:   "update pvz details",
+			method: http.MethodPut,
+			path:   "/pvz/:pvzId",
+			params: map[string]string{"pvzId": testUUID},
+			body: map[string]interface{}{
+				"city":         "Казань",
+				"address":      "ул. Баумана, 10",
+				"latitude":     55.7887,
+				"longitude":    49.1221,
+				"workingHours": "Пн-Вс 09:00-21:00",
+				"phone":        "+78432000000",
+			},
+			setupMock: func(m *mocks.PvzService) {
+				m.On("Update", mock.Anything, mock.Anything, testUUID, mock.MatchedBy(func(req pvz.UpdateRequest) bool {
+					return req.City == "Казань" && *req.Latitude == 55.7887 && *req.Phone == "+78432000000"
+				})).Return(pvz.Pvz{Id: testUUID, RegistrationDate: validTime, City: "Казань", Latitude: floatPtr(55.7887), Longitude: floatPtr(49.1221)}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantResponse:   pvz.Pvz{Id: testUUID, RegistrationDate: validTime, City: "Казань", Latitude: floatPtr(55.7887), Longitude: floatPtr(49.1221)},
+		},
+		{
+			name:   "update with latitude only and bad phone",
+			method: http.MethodPut,
+			path:   "/pvz/:pvzId",
+			params: map[string]string{"pvzId": testUUID},
+			body: map[string]interface{}{
+				"city":     "Казань",
+				"latitude": 55.7887,
+				"phone":    "8-843-200",
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantResponse: wantProblem{
+				Code:   "validation_failed",
+				Detail: "property longitude is missing; property phone has bad value format 8-843-200",
+			},
+		},
+		{
+			name:   "update unknown pvz",
+			method: http.MethodPut,
+			path:   "/pvz/:pvzId",
+			params: map[string]string{"pvzId": testUUID},
+			body:   map[string]interface{}{"city": "Москва"},
+			setupMock: func(m *mocks.PvzService) {
+				m.On("Update", mock.Anything, mock.Anything, testUUID, mock.Anything).
+					Return(pvz.Pvz{}, errors.NewObjectNotFound("pvz"))
+			},
+			expectedStatus: http.StatusNotFound,
+			wantResponse:   wantProblem{Code: "pvz_not_found", Detail: "pvz not found"},
+		},
+		{
+			name:        "nearby with default radius",
+			method:      http.MethodGet,
+			path:        "/pvz/nearby",
+			queryParams: map[string]string{"lat": "55.7558", "lon": "37.6173"},
+			setupMock: func(m *mocks.PvzService) {
+				m.On("Nearby", mock.Anything, pvz.NearbyRequest{
+					Latitude: floatPtr(55.7558), Longitude: floatPtr(37.6173), RadiusMeters: 5000, Limit: 20,
+				}).Return([]pvz.NearbyResponse{{Pvz: pvz.Pvz{Id: testUUID, RegistrationDate: validTime, City: "Москва"}, DistanceMeters: 412.5}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantResponse:   []pvz.NearbyResponse{{Pvz: pvz.Pvz{Id: testUUID, RegistrationDate: validTime, City: "Москва"}, DistanceMeters: 412.5}},
+		},
+		{
+			name:           "nearby without longitude",
+			method:         http.MethodGet,
+			path:           "/pvz/nearby",
+			queryParams:    map[string]string{"lat": "55.7558", "radius": "1000"},
+			expectedStatus: http.StatusBadRequest,
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property lon is missing"},
+		},
+		{
+			name:           "nearby with radius that is not a number",
+			method:         http.MethodGet,
+			path:           "/pvz/nearby",
+			queryParams:    map[string]string{"lat": "55.7558", "lon": "37.6173", "radius": "far"},
+			expectedStatus: http.StatusBadRequest,
+			wantResponse:   wantProblem{Code: "bad_param_value", Detail: "bad param value far"},
+		},
+		{
+			name:           "nearby with radius too big",
+			method:         http.MethodGet,
+			path:           "/pvz/nearby",
+			queryParams:    map[string]string{"lat": "55.7558", "lon": "37.6173", "radius": "500000"},
+			expectedStatus: http.StatusBadRequest,
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property radius is too big"},
 		},
 	}
 
@@ -316,6 +407,10 @@ func TestPvzHandlers(t *testing.T) {
 					return h.CloseLastReception(c)
 				case http.MethodGet + ":/pvz":
 					return h.ListWithFilterDate(c)
+				case http.MethodPut + ":/pvz/:pvzId":
+					return h.Update(c)
+				case http.MethodGet + ":/pvz/nearby":
+					return h.Nearby(c)
 				default:
 					t.Fatalf("unknown route: %s %s", tt.method, tt.path)
 					return nil
@@ -346,10 +441,16 @@ func TestPvzHandlers(t *testing.T) {
 					var response []pvz.ListResponse
 					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
 					assert.Equal(t, want, response)
-				case map[string]string:
-					var response map[string]string
+				case pvz.Pvz:
+					var response pvz.Pvz
+					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+					assert.Equal(t, want, response)
+				case []pvz.NearbyResponse:
+					var response []pvz.NearbyResponse
 					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
 					assert.Equal(t, want, response)
+				case wantProblem:
+					assertProblem(t, rec, want)
 				default:
 					t.Fatal("unsupported response type")
 				}
@@ -21,7 +21,7 @@ func NewReceptionHandler(deps bootstrap.Deps) *ReceptionHandler {
 }
 
 func (rh *ReceptionHandler) Create(c echo.Context) error {
-	log := logger.Log.With("handler", "reception", "method", "Create")
+	log := logger.FromContext(c.Request().Context()).With("handler", "reception", "method", "Create")
 
 	var req reception.CreateRequest
 	if err := c.Bind(&req); err != nil {
@@ -34,7 +34,7 @@ func (rh *ReceptionHandler) Create(c echo.Context) error {
 		return err
 	}
 
-	newReception, err := rh.receptionService.Create(req)
+	newReception, err := rh.receptionService.Create(c.Request().Context(), auditMeta(c), req)
 	if err != nil {
 		log.Error("receptionService.Create failed", "error", err)
 		return err
@@ -43,3 +43,24 @@ func (rh *ReceptionHandler) Create(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, newReception)
 }
+
+func (rh *ReceptionHandler) GetCurrent(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "reception", "method", "GetCurrent")
+
+	pvzId := c.Param("pvzId")
+	log.Info("received request to get current reception", "pvzId", pvzId)
+
+	if err := apiValidator.ValidateParam("pvzId", pvzId, "required,uuid"); err != nil {
+		log.Error("parameter validation failed", "error", err)
+		return err
+	}
+
+	current, err := rh.receptionService.GetCurrent(c.Request().Context(), pvzId)
+	if err != nil {
+		log.Error("receptionService.GetCurrent failed", "error", err)
+		return err
+	}
+	log.Info("current reception found", "receptionId", current.Id, "pvzId", pvzId)
+
+	return c.JSON(http.StatusOK, current)
+}
@@ -49,7 +49,7 @@ func TestReceptionHandlers(t *testing.T) {
 				"pvzId": testUUID,
 			},
 			setupMock: func(m *mocks.ReceptionService) {
-				m.On("Create", mock.MatchedBy(func(req reception.CreateRequest) bool {
+				m.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(req reception.CreateRequest) bool {
 					return req.PvzId == testUUID
 				})).Return(reception.CreateResponse{
 					Id:       "rec-123",
@@ -75,7 +75,7 @@ func TestReceptionHandlers(t *testing.T) {
 			},
 			setupMock:      func(m *mocks.ReceptionService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property PvzId has bad value format invalid-uuid"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property pvzId has bad value format invalid-uuid"},
 		},
 		{
 			name:           "missing pvzId",
@@ -84,7 +84,7 @@ func TestReceptionHandlers(t *testing.T) {
 			body:           map[string]interface{}{},
 			setupMock:      func(m *mocks.ReceptionService) {},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "property PvzId is missing"},
+			wantResponse:   wantProblem{Code: "validation_failed", Detail: "property pvzId is missing"},
 		},
 		{
 			name:   "reception already exists",
@@ -94,13 +94,13 @@ func TestReceptionHandlers(t *testing.T) {
 				"pvzId": testUUID,
 			},
 			setupMock: func(m *mocks.ReceptionService) {
-				m.On("Create", mock.Anything).Return(
+				m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(
 					reception.CreateResponse{},
 					errors.NewReceptionIsNotClosed(testUUID),
 				)
 			},
 			expectedStatus: http.StatusBadRequest,
-			wantResponse:   map[string]string{"message": "reception in pvz with id " + testUUID + " is not closed"},
+			wantResponse:   wantProblem{Code: "reception_not_closed", Detail: "reception in pvz with id " + testUUID + " is not closed"},
 		},
 		{
 			name:   "pvz not found",
@@ -110,13 +110,13 @@ func TestReceptionHandlers(t *testing.T) {
 				"pvzId": testUUID,
 			},
 			setupMock: func(m *mocks.ReceptionService) {
-				m.On("Create", mock.Anything).Return(
+				m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(
 					reception.CreateResponse{},
 					errors.NewObjectNotFound("pvz"),
 				)
 			},
 			expectedStatus: http.StatusNotFound,
-			wantResponse:   map[string]string{"message": "pvz not found"},
+			wantResponse:   wantProblem{Code: "pvz_not_found", Detail: "pvz not found"},
 		},
 		{
 			name:   "internal error",
@@ -126,13 +126,13 @@ func TestReceptionHandlers(t *testing.T) {
 				"pvzId": testUUID,
 			},
 			setupMock: func(m *mocks.ReceptionService) {
-				m.On("Create", mock.Anything).Return(
+				m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(
 					reception.CreateResponse{},
 					errors.NewInternalError(),
 				)
 			},
 			expectedStatus: http.StatusInternalServerError,
-			wantResponse:   map[string]string{"message": "internal error"},
+			wantResponse:   wantProblem{Code: "internal_error", Detail: "internal error"},
 		},
 	}
 
@@ -172,11 +172,8 @@ func TestReceptionHandlers(t *testing.T) {
 					err := json.Unmarshal(rec.Body.Bytes(), &response)
 					require.NoError(t, err)
 					assert.Equal(t, want, response)
-				case map[string]string:
-					var response map[string]string
-					err := json.Unmarshal(rec.Body.Bytes(), &response)
-					require.NoError(t, err)
-					assert.Equal(t, want, response)
+				case wantProblem:
+					assertProblem(t, rec, want)
 				default:
 					t.Fatal("unsupported response type")
 				}
@@ -186,3 +183,76 @@ func TestReceptionHandlers(t *testing.T) {
 		})
 	}
 }
+
+func TestReceptionHandlers_GetCurrent(t *testing.T) {
+	e := echo.New()
+	logger.Init("debug")
+
+	testUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name           string
+		pvzId          string
+		setupMock      func(*mocks.ReceptionService)
+		expectedStatus int
+		wantResponse   interface{}
+	}{
+		{
+			name:  "success",
+			pvzId: testUUID,
+			setupMock: func(m *mocks.ReceptionService) {
+				m.On("GetCurrent", mock.Anything, testUUID).Return(reception.CurrentResponse{
+					Id:            "rec-123",
+					PvzId:         testUUID,
+					Status:        reception.InProgressStatus,
+					Products:      []reception.Product{{Id: "p1", Type: "обувь"}},
+					ProductCounts: map[string]int{"обувь": 1},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid pvzId",
+			pvzId:          "invalid-uuid",
+			setupMock:      func(m *mocks.ReceptionService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantResponse:   wantProblem{Code: "bad_param_value", Detail: "bad param value invalid-uuid"},
+		},
+		{
+			name:  "no in-progress reception",
+			pvzId: testUUID,
+			setupMock: func(m *mocks.ReceptionService) {
+				m.On("GetCurrent", mock.Anything, testUUID).Return(reception.CurrentResponse{}, errors.NewNoInProgressReception())
+			},
+			expectedStatus: http.StatusNotFound,
+			wantResponse:   wantProblem{Code: "no_in_progress_reception", Detail: "no in-progress reception"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReception := mocks.NewReceptionService(t)
+			tt.setupMock(mockReception)
+
+			h := handlers.NewReceptionHandler(bootstrap.Deps{
+				ReceptionService: mockReception,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/pvz/"+tt.pvzId+"/receptions/current", nil)
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetPath("/pvz/:pvzId/receptions/current")
+			c.SetParamNames("pvzId")
+			c.SetParamValues(tt.pvzId)
+
+			err := middleware.HandleError(h.GetCurrent)(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if want, ok := tt.wantResponse.(wantProblem); ok {
+				assertProblem(t, rec, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/labstack/echo"
+	"net/http"
+	"pvz/internal/bootstrap"
+	"pvz/internal/logger"
+	"pvz/internal/models/stats"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const csvContentType = "text/csv; charset=utf-8"
+
+type StatsHandler struct {
+	statsService services.StatsService
+}
+
+func NewStatsHandler(deps bootstrap.Deps) *StatsHandler {
+	return &StatsHandler{
+		statsService: deps.StatsService,
+	}
+}
+
+func (sh *StatsHandler) Get(c echo.Context) error {
+	log := logger.FromContext(c.Request().Context()).With("handler", "stats", "method", "Get")
+
+	req := stats.Request{}
+
+	if startDateStr := c.QueryParam("startDate"); startDateStr != "" {
+		startDate, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			return errors.NewBadParamValue("startDate", startDateStr)
+		}
+		req.StartDate = &startDate
+	}
+	if endDateStr := c.QueryParam("endDate"); endDateStr != "" {
+		endDate, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			return errors.NewBadParamValue("endDate", endDateStr)
+		}
+		req.EndDate = &endDate
+	}
+	if groupBy := c.QueryParam("groupBy"); groupBy != "" {
+		for _, group := range strings.Split(groupBy, ",") {
+			req.GroupBy = append(req.GroupBy, strings.TrimSpace(group))
+		}
+	}
+
+	req.Format = c.QueryParam("format")
+	if req.Format == "" && strings.Contains(c.Request().Header.Get(echo.HeaderAccept), "text/csv") {
+		req.Format = stats.FormatCsv
+	}
+
+	if err := apiValidator.ValidateRequest(req); err != nil {
+		log.Error("request validation failed", "error", err)
+		return err
+	}
+
+	resp, err := sh.statsService.Get(c.Request().Context(), req)
+	if err != nil {
+		log.Error("statsService.Get failed", "error", err)
+		return err
+	}
+	log.Info("received stats", "rows", len(resp.Rows))
+
+	if req.Format == stats.FormatCsv {
+		body, err := statsCsv(resp)
+		if err != nil {
+			log.Error("failed to render stats csv", "error", err)
+			return errors.NewInternalError()
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="stats.csv"`)
+		return c.Blob(http.StatusOK, csvContentType, body)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// statsCsv writes a column per grouped dimension, in the order they were asked for, followed by the figures.
+func statsCsv(resp stats.Response) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := make([]string, 0, len(resp.GroupBy)+4)
+	for _, group := range resp.GroupBy {
+		header = append(header, statsColumn(group))
+	}
+	header = append(header, "products", "receptions", "openReceptions", "avgReceptionDurationSeconds")
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, row := range resp.Rows {
+		record := make([]string, 0, len(header))
+		for _, group := range resp.GroupBy {
+			record = append(record, statsDimension(row, group))
+		}
+		avg := ""
+		if row.AvgReceptionDurationSeconds != nil {
+			avg = strconv.FormatFloat(*row.AvgReceptionDurationSeconds, 'f', 0, 64)
+		}
+		record = append(record, strconv.Itoa(row.Products), strconv.Itoa(row.Receptions), strconv.Itoa(row.OpenReceptions), avg)
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func statsColumn(group string) string {
+	if group == stats.GroupPvz {
+		return "pvzId"
+	}
+	return group
+}
+
+func statsDimension(row stats.Row, group string) string {
+	var value *string
+	switch group {
+	case stats.GroupPvz:
+		value = row.PvzId
+	case stats.GroupCity:
+		value = row.City
+	case stats.GroupType:
+		value = row.Type
+	case stats.GroupDay:
+		value = row.Day
+	}
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/bootstrap"
+	"pvz/internal/handlers"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/mocks"
+	"pvz/internal/models/stats"
+)
+
+func TestStatsHandler(t *testing.T) {
+	e := echo.New()
+	e.Validator = handlers.NewApiValidator()
+	logger.Init("debug")
+
+	city := "Москва"
+	productType := "обувь"
+	avg := 5400.0
+	resp := stats.Response{
+		GroupBy: []string{stats.GroupCity, stats.GroupType},
+		Rows: []stats.Row{
+			{City: &city, Type: &productType, Products: 12, Receptions: 3, OpenReceptions: 1, AvgReceptionDurationSeconds: &avg},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		accept         string
+		setupMock      func(*mocks.StatsService)
+		expectedStatus int
+		wantType       string
+		wantBody       string
+		wantProblem    *wantProblem
+	}{
+		{
+			name:  "json",
+			query: "groupBy=city,type&startDate=2025-01-01T00:00:00Z&endDate=2025-02-01T00:00:00Z",
+			setupMock: func(m *mocks.StatsService) {
+				m.On("Get", mock.Anything, mock.MatchedBy(func(req stats.Request) bool {
+					return len(req.GroupBy) == 2 && req.GroupBy[1] == stats.GroupType &&
+						req.StartDate != nil && req.EndDate != nil
+				})).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantType:       echo.MIMEApplicationJSONCharsetUTF8,
+		},
+		{
+			name:  "csv by format param",
+			query: "groupBy=city,type&format=csv",
+			setupMock: func(m *mocks.StatsService) {
+				m.On("Get", mock.Anything, mock.Anything).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantType:       "text/csv; charset=utf-8",
+			wantBody:       "city,type,products,receptions,openReceptions,avgReceptionDurationSeconds\nМосква,обувь,12,3,1,5400\n",
+		},
+		{
+			name:   "csv by accept header",
+			query:  "groupBy=city,type",
+			accept: "text/csv",
+			setupMock: func(m *mocks.StatsService) {
+				m.On("Get", mock.Anything, mock.Anything).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantType:       "text/csv; charset=utf-8",
+		},
+		{
+			name:           "unknown grouping",
+			query:          "groupBy=city,region",
+			setupMock:      func(m *mocks.StatsService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "validation_failed", Detail: "property groupBy[1] has wrong value region"},
+		},
+		{
+			name:           "bad start date",
+			query:          "startDate=yesterday",
+			setupMock:      func(m *mocks.StatsService) {},
+			expectedStatus: http.StatusBadRequest,
+			wantProblem:    &wantProblem{Code: "bad_param_value", Detail: "bad param value yesterday"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStats := mocks.NewStatsService(t)
+			tt.setupMock(mockStats)
+			h := handlers.NewStatsHandler(bootstrap.Deps{StatsService: mockStats})
+
+			req := httptest.NewRequest(http.MethodGet, "/stats?"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := middleware.HandleError(h.Get)(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.wantType != "" {
+				assert.Equal(t, tt.wantType, rec.Header().Get(echo.HeaderContentType))
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+			if tt.wantType == echo.MIMEApplicationJSONCharsetUTF8 {
+				var got stats.Response
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, resp, got)
+			}
+			if tt.wantProblem != nil {
+				assertProblem(t, rec, *tt.wantProblem)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/go-playground/validator/v10"
 	"pvz/pkg/errors"
+	"reflect"
 	"regexp"
+	"strings"
+	"time"
 )
 
 var (
@@ -13,6 +17,10 @@ var (
 	emailPattern = regexp.MustCompile(
 		`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`,
 	)
+	// E.164: a plus, the country code and at most 15 digits in total
+	phonePattern = regexp.MustCompile(
+		`^\+[1-9][0-9]{6,14}$`,
+	)
 )
 
 var apiValidator = NewApiValidator()
@@ -31,6 +39,13 @@ func NewApiValidator() *ApiValidator {
 	if err := v.RegisterValidation("email", validateEmail); err != nil {
 		panic(err)
 	}
+	if err := v.RegisterValidation("notfuture", validateNotFuture); err != nil {
+		panic(err)
+	}
+	if err := v.RegisterValidation("phone", validatePhone); err != nil {
+		panic(err)
+	}
+	v.RegisterTagNameFunc(jsonFieldName)
 
 	return apiVal
 }
@@ -43,32 +58,40 @@ func (av *ApiValidator) ValidateRequest(req interface{}) error {
 	reqErrors := av.validate.Struct(req)
 	if reqErrors != nil {
 		if validationErrors, ok := reqErrors.(validator.ValidationErrors); ok {
-			reqError := validationErrors[0]
-			reqName := reqError.Field()
-			reqTag := reqError.Tag()
-			reqValue := reqError.Value()
-
-			switch reqTag {
-			case "required":
-				return errors.NewPropertyMissing(reqName)
-			case "min":
-				return errors.NewPropertyTooSmall(reqName)
-			case "max":
-				return errors.NewPropertyTooBig(reqName)
-			case "oneof":
-				return errors.NewWrongPropertyValue(reqName, reqValue.(string))
-			case "uuid", "email":
-				return errors.NewBadPropertyValue(reqName, reqValue.(string))
-			default:
-				return errors.NewInternalError()
+			fieldErrors := make([]errors.FieldError, 0, len(validationErrors))
+			for _, reqError := range validationErrors {
+				fieldErrors = append(fieldErrors, errors.NewFieldError(propertyError(reqError), reqError.Tag()))
 			}
+			return errors.NewValidationFailed(fieldErrors)
 		}
 		return reqErrors
 	}
 	return nil
 }
 
-func (av *ApiValidator) ValidateParam(param interface{}, tag string) error {
+func propertyError(reqError validator.FieldError) errors.Coded {
+	reqName := reqError.Field()
+	reqValue := fmt.Sprint(reqError.Value())
+
+	switch reqError.Tag() {
+	case "required", "required_with":
+		return errors.NewPropertyMissing(reqName)
+	case "min", "gt":
+		return errors.NewPropertyTooSmall(reqName)
+	case "max":
+		return errors.NewPropertyTooBig(reqName)
+	case "oneof":
+		return errors.NewWrongPropertyValue(reqName, reqValue)
+	case "uuid", "email", "phone":
+		return errors.NewBadPropertyValue(reqName, reqValue)
+	case "notfuture":
+		return errors.NewPropertyInFuture(reqName)
+	default:
+		return errors.NewPropertyInvalid(reqName)
+	}
+}
+
+func (av *ApiValidator) ValidateParam(name string, param interface{}, tag string) error {
 	parErrors := av.validate.Var(param, tag)
 	if parErrors != nil {
 		if validationErrors, ok := parErrors.(validator.ValidationErrors); ok {
@@ -78,11 +101,9 @@ func (av *ApiValidator) ValidateParam(param interface{}, tag string) error {
 
 			switch parTag {
 			case "required":
-				return errors.NewParamMissing()
-			case "uuid":
-				return errors.NewBadParamValue(parValue.(string))
+				return errors.NewParamMissing(name)
 			default:
-				return errors.NewInternalError()
+				return errors.NewBadParamValue(name, fmt.Sprint(parValue))
 			}
 		}
 		return parErrors
@@ -98,6 +119,22 @@ func validateUuid(fl validator.FieldLevel) bool {
 	return uuidV4Pattern.MatchString(tag)
 }
 
+func validatePhone(fl validator.FieldLevel) bool {
+	phone, ok := fl.Field().Interface().(string)
+	if !ok {
+		return false
+	}
+	return phonePattern.MatchString(phone)
+}
+
+func validateNotFuture(fl validator.FieldLevel) bool {
+	date, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	return !date.After(time.Now())
+}
+
 func validateEmail(fl validator.FieldLevel) bool {
 	tag, ok := fl.Field().Interface().(string)
 	if !ok {
@@ -105,3 +142,15 @@ func validateEmail(fl validator.FieldLevel) bool {
 	}
 	return emailPattern.MatchString(tag)
 }
+
+// jsonFieldName reports fields the way clients send them, e.g. pvzId instead of PvzId.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
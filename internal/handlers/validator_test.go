@@ -6,6 +6,7 @@ import (
 	"pvz/pkg/errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 type TestStruct struct {
@@ -14,6 +15,37 @@ type TestStruct struct {
 	Username string `json:"username" validate:"required,min=3,max=50"`
 }
 
+type PageStruct struct {
+	Page int      `json:"page" validate:"min=1"`
+	Ids  []string `json:"ids" validate:"omitempty,dive,uuid"`
+}
+
+type DateStruct struct {
+	RegistrationDate *time.Time `json:"registrationDate" validate:"omitempty,notfuture"`
+}
+
+type LocationStruct struct {
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	Phone     *string  `json:"phone" validate:"omitempty,phone"`
+}
+
+type UnknownTagStruct struct {
+	Code string `json:"code" validate:"numeric"`
+}
+
+func invalid(err errors.Coded, tag string) error {
+	return errors.NewValidationFailed([]errors.FieldError{errors.NewFieldError(err, tag)})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
 func TestApiValidator_ValidateRequest(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -36,7 +68,7 @@ func TestApiValidator_ValidateRequest(t *testing.T) {
 				UUID:     "f284df64-34de-4c29-b04c-075b1e660850",
 				Username: "validuser",
 			},
-			expectedError: errors.NewBadPropertyValue("Email", "invalid-email"),
+			expectedError: invalid(errors.NewBadPropertyValue("email", "invalid-email"), "email"),
 		},
 		{
 			name: "invalid UUID format",
@@ -45,7 +77,7 @@ func TestApiValidator_ValidateRequest(t *testing.T) {
 				UUID:     "invalid-uuid",
 				Username: "validuser",
 			},
-			expectedError: errors.NewBadPropertyValue("UUID", "invalid-uuid"),
+			expectedError: invalid(errors.NewBadPropertyValue("uuid", "invalid-uuid"), "uuid"),
 		},
 		{
 			name: "missing required email",
@@ -54,7 +86,7 @@ func TestApiValidator_ValidateRequest(t *testing.T) {
 				UUID:     "f284df64-34de-4c29-b04c-075b1e660850",
 				Username: "validuser",
 			},
-			expectedError: errors.NewPropertyMissing("Email"),
+			expectedError: invalid(errors.NewPropertyMissing("email"), "required"),
 		},
 		{
 			name: "username too short",
@@ -63,7 +95,7 @@ func TestApiValidator_ValidateRequest(t *testing.T) {
 				UUID:     "f284df64-34de-4c29-b04c-075b1e660850",
 				Username: "us",
 			},
-			expectedError: errors.NewPropertyTooSmall("Username"),
+			expectedError: invalid(errors.NewPropertyTooSmall("username"), "min"),
 		},
 		{
 			name: "username too long",
@@ -72,7 +104,68 @@ func TestApiValidator_ValidateRequest(t *testing.T) {
 				UUID:     "f284df64-34de-4c29-b04c-075b1e660850",
 				Username: strings.Repeat("a", 200),
 			},
-			expectedError: errors.NewPropertyTooBig("Username"),
+			expectedError: invalid(errors.NewPropertyTooBig("username"), "max"),
+		},
+		{
+			name: "every invalid property reported",
+			input: &TestStruct{
+				Email:    "",
+				UUID:     "invalid-uuid",
+				Username: "us",
+			},
+			expectedError: errors.NewValidationFailed([]errors.FieldError{
+				errors.NewFieldError(errors.NewPropertyMissing("email"), "required"),
+				errors.NewFieldError(errors.NewBadPropertyValue("uuid", "invalid-uuid"), "uuid"),
+				errors.NewFieldError(errors.NewPropertyTooSmall("username"), "min"),
+			}),
+		},
+		{
+			name:  "non-string property",
+			input: &PageStruct{Page: 0, Ids: []string{"invalid-uuid"}},
+			expectedError: errors.NewValidationFailed([]errors.FieldError{
+				errors.NewFieldError(errors.NewPropertyTooSmall("page"), "min"),
+				errors.NewFieldError(errors.NewBadPropertyValue("ids[0]", "invalid-uuid"), "uuid"),
+			}),
+		},
+		{
+			name:          "date in the past",
+			input:         &DateStruct{RegistrationDate: timePtr(time.Now().Add(-time.Hour))},
+			expectedError: nil,
+		},
+		{
+			name:          "no date",
+			input:         &DateStruct{},
+			expectedError: nil,
+		},
+		{
+			name:          "date in the future",
+			input:         &DateStruct{RegistrationDate: timePtr(time.Now().Add(time.Hour))},
+			expectedError: invalid(errors.NewPropertyInFuture("registrationDate"), "notfuture"),
+		},
+		{
+			name:          "coordinates and phone",
+			input:         &LocationStruct{Latitude: floatPtr(55.75), Longitude: floatPtr(37.61), Phone: stringPtr("+74951234567")},
+			expectedError: nil,
+		},
+		{
+			name:          "latitude without longitude",
+			input:         &LocationStruct{Latitude: floatPtr(55.75)},
+			expectedError: invalid(errors.NewPropertyMissing("longitude"), "required_with"),
+		},
+		{
+			name:          "latitude out of range",
+			input:         &LocationStruct{Latitude: floatPtr(91), Longitude: floatPtr(0)},
+			expectedError: invalid(errors.NewPropertyTooBig("latitude"), "max"),
+		},
+		{
+			name:          "phone without country code",
+			input:         &LocationStruct{Phone: stringPtr("84951234567")},
+			expectedError: invalid(errors.NewBadPropertyValue("phone", "84951234567"), "phone"),
+		},
+		{
+			name:          "unknown tag is a bad request",
+			input:         &UnknownTagStruct{Code: "abc"},
+			expectedError: invalid(errors.NewPropertyInvalid("code"), "numeric"),
 		},
 	}
 
@@ -107,7 +200,7 @@ func TestApiValidator_ValidateParam(t *testing.T) {
 			name:          "invalid uuid",
 			param:         "invalid-uuid",
 			tag:           "uuid",
-			expectedError: errors.NewBadParamValue("invalid-uuid"),
+			expectedError: errors.NewBadParamValue("param", "invalid-uuid"),
 		},
 		{
 			name:          "valid email",
@@ -119,7 +212,7 @@ func TestApiValidator_ValidateParam(t *testing.T) {
 			name:          "missing required parameter",
 			param:         "",
 			tag:           "required",
-			expectedError: errors.NewParamMissing(),
+			expectedError: errors.NewParamMissing("param"),
 		},
 	}
 
@@ -127,7 +220,7 @@ func TestApiValidator_ValidateParam(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := apiValidator.ValidateParam(tt.param, tt.tag)
+			err := apiValidator.ValidateParam("param", tt.param, tt.tag)
 			if tt.expectedError != nil {
 				assert.Equal(t, tt.expectedError, err)
 			} else {
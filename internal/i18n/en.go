@@ -0,0 +1,38 @@
+package i18n
+
+// english repeats the messages pkg/errors builds its errors with.
+var english = map[string]string{
+	"malformed_body":               "malformed body",
+	"internal_error":               "internal error",
+	"pvz_not_found":                "pvz not found",
+	"pvzs_not_found":               "pvzs not found",
+	"user_not_found":               "user not found",
+	"invalid_credentials":          "invalid credentials",
+	"start_date_after_end_date":    "start date after end date",
+	"reception_not_closed":         "reception in pvz with id {0} is not closed",
+	"reception_not_in_progress":    "reception with id {0} is not closed",
+	"no_in_progress_reception":     "no in-progress reception",
+	"pvz_has_no_reception":         "pvz has not reception",
+	"reception_has_no_product":     "reception has not product",
+	"pvz_already_exists":           "pvz with {0} {1} already exists",
+	"user_already_exists":          "user with {0} {1} already exists",
+	"property_missing":             "property {0} is missing",
+	"param_missing":                "param is missing",
+	"property_too_small":           "property {0} is too small",
+	"property_too_big":             "property {0} is too big",
+	"wrong_property_value":         "property {0} has wrong value {1}",
+	"bad_property_value":           "property {0} has bad value format {1}",
+	"property_invalid":             "property {0} is invalid",
+	"property_in_future":           "property {0} is in the future",
+	"bad_param_value":              "bad param value {0}",
+	"access_forbidden":             "access forbidden",
+	"dummy_login_disabled":         "dummy login is disabled",
+	"registration_disabled":        "registration is disabled",
+	"invitation_token_invalid":     "invitation token is invalid or expired",
+	"password_reset_token_invalid": "password reset token is invalid or expired",
+	"session_expired":              "session expired, please log in again",
+	"too_many_login_attempts":      "too many failed login attempts, retry in {0} seconds",
+	"rate_limited":                 "rate limit exceeded, retry in {0} seconds",
+	"idempotency_key_reused":       "idempotency key was already used with a different request",
+	"idempotency_key_in_progress":  "request with this idempotency key is still in progress",
+}
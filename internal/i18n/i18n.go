@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+)
+
+const (
+	English = "en"
+	Russian = "ru"
+)
+
+var universal = newUniversal()
+
+func newUniversal() *ut.UniversalTranslator {
+	catalogues := map[locales.Translator]map[string]string{
+		en.New(): english,
+		ru.New(): russian,
+	}
+	supported := make([]locales.Translator, 0, len(catalogues))
+	for locale := range catalogues {
+		supported = append(supported, locale)
+	}
+	uni := ut.New(en.New(), supported...)
+
+	for locale, messages := range catalogues {
+		trans, _ := uni.GetTranslator(locale.Locale())
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return uni
+}
+
+// Supported reports whether lang has a message catalogue.
+func Supported(lang string) bool {
+	_, found := universal.GetTranslator(lang)
+	return found
+}
+
+// Translator returns the catalogue for lang, or the English one if lang is not supported.
+func Translator(lang string) ut.Translator {
+	trans, _ := universal.GetTranslator(lang)
+	return trans
+}
+
+// FromAcceptLanguage picks the most preferred supported language of an Accept-Language header.
+// Regional variants match their language, so ru-RU selects ru. It returns "" if nothing matches.
+func FromAcceptLanguage(header string) string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+	var candidates []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		lang, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		if quality <= 0 || !Supported(lang) {
+			continue
+		}
+		candidates = append(candidates, weighted{lang: strings.ToLower(lang), quality: quality})
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
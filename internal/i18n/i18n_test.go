@@ -0,0 +1,94 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/i18n"
+	"pvz/pkg/errors"
+)
+
+func TestCatalogues(t *testing.T) {
+	tests := []errors.Coded{
+		errors.NewMalformedBody(),
+		errors.NewInternalError(),
+		errors.NewObjectNotFound("pvz"),
+		errors.NewObjectNotFound("pvzs"),
+		errors.NewObjectNotFound("user"),
+		errors.NewInvalidCredentials(),
+		errors.NewStartDateAfterEndDate(),
+		errors.NewReceptionIsNotClosed("pvz1"),
+		errors.NewReceptionIsNotInProgress("rec1"),
+		errors.NewNoInProgressReception(),
+		errors.NewObjectHasNotSubObjects("pvz", "reception"),
+		errors.NewObjectHasNotSubObjects("reception", "product"),
+		errors.NewObjectAlreadyExists("pvz", "id", "pvz1"),
+		errors.NewObjectAlreadyExists("user", "email", "user@avito.ru"),
+		errors.NewPropertyMissing("pvzId"),
+		errors.NewParamMissing("pvzId"),
+		errors.NewPropertyTooSmall("page"),
+		errors.NewPropertyTooBig("limit"),
+		errors.NewWrongPropertyValue("city", "Новосибирск"),
+		errors.NewBadPropertyValue("email", "mail"),
+		errors.NewPropertyInvalid("code"),
+		errors.NewBadParamValue("pvzId", "nope"),
+		errors.NewAccessForbidden(),
+		errors.NewFeatureDisabled("dummy login"),
+		errors.NewFeatureDisabled("registration"),
+		errors.NewInvalidToken("invitation"),
+		errors.NewInvalidToken("password reset"),
+		errors.NewSessionExpired(),
+		errors.NewTooManyLoginAttempts(30),
+		errors.NewRateLimited(2),
+		errors.NewIdempotencyKeyReused(),
+		errors.NewIdempotencyKeyInProgress(),
+	}
+
+	english := i18n.Translator(i18n.English)
+	russian := i18n.Translator(i18n.Russian)
+	for _, err := range tests {
+		t.Run(err.MessageKey(), func(t *testing.T) {
+			_, enErr := english.T(err.MessageKey(), err.MessageParams()...)
+			_, ruErr := russian.T(err.MessageKey(), err.MessageParams()...)
+			assert.NoError(t, enErr, "missing in the english catalogue")
+			assert.NoError(t, ruErr, "missing in the russian catalogue")
+
+			assert.Equal(t, err.Error(), errors.Localize(err, english))
+			assert.NotEqual(t, err.Error(), errors.Localize(err, russian))
+		})
+	}
+}
+
+func TestLocalizeValidationFailed(t *testing.T) {
+	err := errors.NewValidationFailed([]errors.FieldError{
+		errors.NewFieldError(errors.NewPropertyMissing("pvzId"), "required"),
+		errors.NewFieldError(errors.NewWrongPropertyValue("type", "мебель"), "oneof"),
+	})
+
+	assert.Equal(t, "не заполнено свойство pvzId; свойство type не может иметь значение мебель",
+		errors.Localize(err, i18n.Translator(i18n.Russian)))
+	assert.Equal(t, err.Error(), errors.Localize(err, i18n.Translator(i18n.English)))
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "ru", want: i18n.Russian},
+		{header: "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7", want: i18n.Russian},
+		{header: "de-DE,en;q=0.5,ru;q=0.8", want: i18n.Russian},
+		{header: "EN-gb", want: i18n.English},
+		{header: "ru;q=0,en", want: i18n.English},
+		{header: "de, fr;q=0.8", want: ""},
+		{header: "*", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.FromAcceptLanguage(tt.header))
+		})
+	}
+}
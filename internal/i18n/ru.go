@@ -0,0 +1,37 @@
+package i18n
+
+var russian = map[string]string{
+	"malformed_body":               "некорректное тело запроса",
+	"internal_error":               "внутренняя ошибка",
+	"pvz_not_found":                "ПВЗ не найден",
+	"pvzs_not_found":               "ПВЗ не найдены",
+	"user_not_found":               "пользователь не найден",
+	"invalid_credentials":          "неверный email или пароль",
+	"start_date_after_end_date":    "дата начала позже даты окончания",
+	"reception_not_closed":         "приёмка в ПВЗ с id {0} не закрыта",
+	"reception_not_in_progress":    "приёмка с id {0} уже закрыта",
+	"no_in_progress_reception":     "нет открытой приёмки",
+	"pvz_has_no_reception":         "у ПВЗ нет приёмок",
+	"reception_has_no_product":     "в приёмке нет товаров",
+	"pvz_already_exists":           "ПВЗ с {0} {1} уже существует",
+	"user_already_exists":          "пользователь с {0} {1} уже существует",
+	"property_missing":             "не заполнено свойство {0}",
+	"param_missing":                "не передан параметр",
+	"property_too_small":           "значение свойства {0} слишком мало",
+	"property_too_big":             "значение свойства {0} слишком велико",
+	"wrong_property_value":         "свойство {0} не может иметь значение {1}",
+	"bad_property_value":           "свойство {0}: неверный формат значения {1}",
+	"property_invalid":             "некорректное значение свойства {0}",
+	"property_in_future":           "значение свойства {0} ещё не наступило",
+	"bad_param_value":              "неверное значение параметра {0}",
+	"access_forbidden":             "доступ запрещён",
+	"dummy_login_disabled":         "вход по тестовому токену отключён",
+	"registration_disabled":        "регистрация отключена",
+	"invitation_token_invalid":     "приглашение недействительно или истекло",
+	"password_reset_token_invalid": "ссылка для сброса пароля недействительна или истекла",
+	"session_expired":              "сессия истекла, войдите заново",
+	"too_many_login_attempts":      "слишком много неудачных попыток входа, повторите через {0} с",
+	"rate_limited":                 "превышен лимит запросов, повторите через {0} с",
+	"idempotency_key_reused":       "ключ идемпотентности уже использован с другим запросом",
+	"idempotency_key_in_progress":  "запрос с этим ключом идемпотентности ещё выполняется",
+}
@@ -0,0 +1,194 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	stdErrors "errors"
+	"io"
+	"pvz/internal/models/pvz"
+	"pvz/pkg/errors"
+	"strings"
+	"time"
+)
+
+// record holds the properties of a pvz as they are written in the file, before dates are parsed.
+type record struct {
+	Id               *string      `json:"id"`
+	RegistrationDate *string      `json:"registrationDate"`
+	City             *string      `json:"city"`
+	Address          *string      `json:"address"`
+	Latitude         *json.Number `json:"latitude"`
+	Longitude        *json.Number `json:"longitude"`
+	WorkingHours     *string      `json:"workingHours"`
+	Phone            *string      `json:"phone"`
+}
+
+var csvColumns = map[string]func(*record, *string){
+	"id":               func(r *record, v *string) { r.Id = v },
+	"registrationdate": func(r *record, v *string) { r.RegistrationDate = v },
+	"city":             func(r *record, v *string) { r.City = v },
+	"address":          func(r *record, v *string) { r.Address = v },
+	"latitude":         func(r *record, v *string) { r.Latitude = (*json.Number)(v) },
+	"longitude":        func(r *record, v *string) { r.Longitude = (*json.Number)(v) },
+	"workinghours":     func(r *record, v *string) { r.WorkingHours = v },
+	"phone":            func(r *record, v *string) { r.Phone = v },
+}
+
+// Read parses pvzs from a csv file with a header or a json array and checks every row with validate, which is
+// expected to report a failed check as errors.ValidationFailed. A file that cannot be read at all is an error,
+// a row that cannot is reported in its Errors. A file of more than maxRows rows is rejected as a whole.
+func Read(format string, r io.Reader, maxRows int, validate func(interface{}) error) ([]pvz.ImportRow, error) {
+	src := &sourceReader{r: r}
+	var rows []pvz.ImportRow
+	var err error
+	switch format {
+	case pvz.ImportFormatCsv:
+		rows, err = readCsv(src, maxRows)
+	case pvz.ImportFormatJson:
+		rows, err = readJson(src, maxRows)
+	default:
+		return nil, errors.NewWrongPropertyValue("format", format)
+	}
+	if src.err != nil {
+		// the file was cut short by its reader, e.g. by a body limit, which is not a syntax error of the file
+		return nil, src.err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if len(rows[i].Errors) > 0 {
+			continue
+		}
+		if err := validate(rows[i].Pvz); err != nil {
+			var validationFailed errors.ValidationFailed
+			if !stdErrors.As(err, &validationFailed) {
+				return nil, err
+			}
+			rows[i].Errors = validationFailed.Errors
+		}
+	}
+	return rows, nil
+}
+
+// sourceReader keeps the error of the underlying reader, which the decoders report as a malformed file.
+type sourceReader struct {
+	r   io.Reader
+	err error
+}
+
+func (s *sourceReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if err != nil && err != io.EOF {
+		s.err = err
+	}
+	return n, err
+}
+
+func readCsv(r io.Reader, maxRows int) ([]pvz.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []pvz.ImportRow{}, nil
+	}
+	if err != nil {
+		return nil, errors.NewMalformedBody()
+	}
+
+	setters := make([]func(*record, *string), 0, len(header))
+	for _, column := range header {
+		setter, ok := csvColumns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))]
+		if !ok {
+			return nil, errors.NewPropertyInvalid(column)
+		}
+		setters = append(setters, setter)
+	}
+
+	rows := []pvz.ImportRow{}
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, errors.NewMalformedBody()
+		}
+
+		if len(rows) == maxRows {
+			return nil, errors.NewPropertyTooBig("rows")
+		}
+
+		var rec record
+		for i, cell := range cells {
+			if cell = strings.TrimSpace(cell); cell != "" {
+				setters[i](&rec, &cell)
+			}
+		}
+		rows = append(rows, newRow(len(rows)+1, rec))
+	}
+}
+
+func readJson(r io.Reader, maxRows int) ([]pvz.ImportRow, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.NewMalformedBody()
+	}
+	if len(raw) > maxRows {
+		return nil, errors.NewPropertyTooBig("rows")
+	}
+
+	rows := make([]pvz.ImportRow, 0, len(raw))
+	for i, item := range raw {
+		var rec record
+		if err := json.Unmarshal(item, &rec); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !stdErrors.As(err, &typeErr) {
+				return nil, errors.NewMalformedBody()
+			}
+			rows = append(rows, pvz.ImportRow{
+				Row:    i + 1,
+				Errors: []errors.FieldError{errors.NewFieldError(errors.NewPropertyInvalid(typeErr.Field), "type")},
+			})
+			continue
+		}
+		rows = append(rows, newRow(i+1, rec))
+	}
+	return rows, nil
+}
+
+func newRow(number int, rec record) pvz.ImportRow {
+	row := pvz.ImportRow{
+		Row: number,
+		Pvz: pvz.CreateRequest{Id: rec.Id, Address: rec.Address, WorkingHours: rec.WorkingHours, Phone: rec.Phone},
+	}
+	if rec.City != nil {
+		row.Pvz.City = *rec.City
+	}
+	if rec.RegistrationDate != nil {
+		date, err := time.Parse(time.RFC3339, *rec.RegistrationDate)
+		if err != nil {
+			row.Errors = append(row.Errors,
+				errors.NewFieldError(errors.NewBadPropertyValue("registrationDate", *rec.RegistrationDate), "datetime"))
+		} else {
+			row.Pvz.RegistrationDate = &date
+		}
+	}
+	row.Pvz.Latitude = parseCoordinate(&row, "latitude", rec.Latitude)
+	row.Pvz.Longitude = parseCoordinate(&row, "longitude", rec.Longitude)
+	return row
+}
+
+func parseCoordinate(row *pvz.ImportRow, property string, value *json.Number) *float64 {
+	if value == nil {
+		return nil
+	}
+	coordinate, err := value.Float64()
+	if err != nil {
+		row.Errors = append(row.Errors, errors.NewFieldError(errors.NewBadPropertyValue(property, value.String()), "number"))
+		return nil
+	}
+	return &coordinate
+}
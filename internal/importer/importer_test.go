@@ -0,0 +1,144 @@
+package importer_test
+
+import (
+	stdErrors "errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/handlers"
+	"pvz/internal/importer"
+	"pvz/internal/models/pvz"
+	"pvz/pkg/errors"
+)
+
+var validate = handlers.NewApiValidator().ValidateRequest
+
+func codes(fieldErrors []errors.FieldError) []string {
+	result := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		result = append(result, fieldError.Field+":"+fieldError.Code)
+	}
+	return result
+}
+
+func TestReadCsv(t *testing.T) {
+	file := "\ufeffCity,id,registrationDate,address\n" +
+		"Москва,,,\"Тверская, 1\"\n" +
+		"Казань,b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70,2024-03-01T10:00:00Z,\n" +
+		"Самара,not-a-uuid,вчера,\n"
+
+	rows, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader(file), 10, validate)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "Москва", rows[0].Pvz.City)
+	require.NotNil(t, rows[0].Pvz.Address)
+	assert.Equal(t, "Тверская, 1", *rows[0].Pvz.Address)
+	assert.Nil(t, rows[0].Pvz.Id)
+	assert.Nil(t, rows[0].Pvz.RegistrationDate)
+	assert.Empty(t, rows[0].Errors)
+
+	require.NotNil(t, rows[1].Pvz.Id)
+	require.NotNil(t, rows[1].Pvz.RegistrationDate)
+	assert.Equal(t, 2024, rows[1].Pvz.RegistrationDate.Year())
+	assert.Nil(t, rows[1].Pvz.Address)
+	assert.Empty(t, rows[1].Errors)
+
+	// a date that cannot be read is reported on its own, without running the other checks
+	assert.Equal(t, []string{"registrationDate:bad_property_value"}, codes(rows[2].Errors))
+}
+
+func TestReadCsvValidatesRows(t *testing.T) {
+	file := "id,city\nnot-a-uuid,Самара\n"
+
+	rows, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader(file), 10, validate)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []string{"id:bad_property_value", "city:wrong_property_value"}, codes(rows[0].Errors))
+}
+
+func TestReadCsvUnknownColumn(t *testing.T) {
+	_, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader("city,region\nМосква,ЦФО\n"), 10, validate)
+	assert.IsType(t, errors.PropertyInvalid{}, err)
+}
+
+func TestReadJson(t *testing.T) {
+	file := `[
+		{"city": "Санкт-Петербург", "address": "Невский, 28"},
+		{"city": 42},
+		{"registrationDate": "2024-03-01T10:00:00Z"}
+	]`
+
+	rows, err := importer.Read(pvz.ImportFormatJson, strings.NewReader(file), 10, validate)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Empty(t, rows[0].Errors)
+	assert.Equal(t, "Санкт-Петербург", rows[0].Pvz.City)
+	assert.Equal(t, []string{"city:property_invalid"}, codes(rows[1].Errors))
+	assert.Equal(t, 3, rows[2].Row)
+	assert.Equal(t, []string{"city:property_missing"}, codes(rows[2].Errors))
+}
+
+func TestReadMalformed(t *testing.T) {
+	_, err := importer.Read(pvz.ImportFormatJson, strings.NewReader(`{"city": "Москва"}`), 10, validate)
+	assert.IsType(t, errors.MalformedBody{}, err)
+
+	_, err = importer.Read(pvz.ImportFormatCsv, strings.NewReader("city\nМосква,лишнее\n"), 10, validate)
+	assert.IsType(t, errors.MalformedBody{}, err)
+}
+
+func TestReadEmpty(t *testing.T) {
+	rows, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader(""), 10, validate)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestReadCsvLocation(t *testing.T) {
+	file := "city,latitude,longitude,workingHours,phone\n" +
+		"Москва,55.7558,37.6173,09:00-21:00,+74951234567\n" +
+		"Казань,север,49.1221,,\n" +
+		"Москва,55.7558,,,\n"
+
+	rows, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader(file), 10, validate)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Empty(t, rows[0].Errors)
+	require.NotNil(t, rows[0].Pvz.Latitude)
+	assert.Equal(t, 55.7558, *rows[0].Pvz.Latitude)
+	assert.Equal(t, 37.6173, *rows[0].Pvz.Longitude)
+	assert.Equal(t, "09:00-21:00", *rows[0].Pvz.WorkingHours)
+	assert.Equal(t, "+74951234567", *rows[0].Pvz.Phone)
+
+	assert.Equal(t, []string{"latitude:bad_property_value"}, codes(rows[1].Errors))
+	assert.Equal(t, []string{"longitude:property_missing"}, codes(rows[2].Errors))
+}
+
+func TestReadTooManyRows(t *testing.T) {
+	_, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader("city\nМосква\nКазань\nМосква\n"), 2, validate)
+	assert.IsType(t, errors.PropertyTooBig{}, err)
+
+	_, err = importer.Read(pvz.ImportFormatJson, strings.NewReader(`[{"city": "Москва"}, {"city": "Казань"}, {}]`), 2, validate)
+	assert.IsType(t, errors.PropertyTooBig{}, err)
+
+	rows, err := importer.Read(pvz.ImportFormatCsv, strings.NewReader("city\nМосква\nКазань\n"), 2, validate)
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
+
+func TestReadSourceError(t *testing.T) {
+	cut := stdErrors.New("body too large")
+	prefixes := map[string]string{pvz.ImportFormatCsv: "city\nМоск", pvz.ImportFormatJson: `[{"city": "Моск`}
+	for format, prefix := range prefixes {
+		r := io.MultiReader(strings.NewReader(prefix), iotest.ErrReader(cut))
+		_, err := importer.Read(format, r, 10, validate)
+		assert.ErrorIs(t, err, cut, format)
+	}
+}
@@ -1,17 +1,92 @@
 package logger
 
 import (
+	"fmt"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"io"
 	"log/slog"
 	"os"
+	"pvz/configs"
 	"strings"
+	"time"
 )
 
-var Log *slog.Logger
+const (
+	FormatText = "text"
+	FormatJson = "json"
+)
+
+var Log = slog.Default()
+
+var (
+	level      = new(slog.LevelVar)
+	fileWriter *lumberjack.Logger
+	stopRotate chan struct{}
+)
+
+// Init configures a stdout-only text logger, which is what tests and tools need.
+func Init(lvl string) {
+	if err := Setup(configs.LogConfig{Level: lvl, Format: FormatText}); err != nil {
+		panic("failed to initialize logger: " + err.Error())
+	}
+}
+
+func Setup(cfg configs.LogConfig) error {
+	return SetupTo(cfg, os.Stdout)
+}
+
+// SetupTo is Setup with console output sent to w instead of stdout.
+func SetupTo(cfg configs.LogConfig, w io.Writer) error {
+	Close()
 
-func Init(level string) {
+	if err := SetLevel(cfg.Level); err != nil {
+		level.Set(slog.LevelInfo)
+	}
+
+	consoleHandler, err := newHandler(cfg.Format, w)
+	if err != nil {
+		return err
+	}
+	if !cfg.FileEnabled {
+		Log = slog.New(consoleHandler)
+		return nil
+	}
+
+	fileWriter = &lumberjack.Logger{
+		Filename:   cfg.File,
+		MaxSize:    cfg.FileMaxSizeMb,
+		MaxAge:     cfg.FileMaxAgeDays,
+		MaxBackups: cfg.FileMaxBackups,
+		Compress:   cfg.FileCompress,
+	}
+	fileHandler, err := newHandler(cfg.Format, fileWriter)
+	if err != nil {
+		return err
+	}
+	if cfg.FileRotateInterval > 0 {
+		stopRotate = make(chan struct{})
+		go rotateEvery(fileWriter, cfg.FileRotateInterval, stopRotate)
+	}
+
+	Log = slog.New(NewMultiHandler(consoleHandler, fileHandler))
+	return nil
+}
+
+func Close() {
+	if stopRotate != nil {
+		close(stopRotate)
+		stopRotate = nil
+	}
+	if fileWriter != nil {
+		_ = fileWriter.Close()
+		fileWriter = nil
+	}
+}
+
+func SetLevel(lvl string) error {
 	var slogLevel slog.Level
 
-	switch strings.ToLower(level) {
+	switch strings.ToLower(lvl) {
 	case "debug":
 		slogLevel = slog.LevelDebug
 	case "info":
@@ -21,27 +96,39 @@ func Init(level string) {
 	case "error":
 		slogLevel = slog.LevelError
 	default:
-		slogLevel = slog.LevelInfo
+		return fmt.Errorf("unknown log level %q", lvl)
 	}
 
-	filePath := os.Getenv("LOG_FILE")
-	if filePath == "" {
-		filePath = "default.log"
-	}
+	level.Set(slogLevel)
+	return nil
+}
 
-	logFile, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		panic("failed to open log file: " + err.Error())
-	}
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
 
-	stdoutHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slogLevel,
-	})
-	fileHandler := slog.NewTextHandler(logFile, &slog.HandlerOptions{
-		Level: slogLevel,
-	})
+func newHandler(format string, w io.Writer) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return slog.NewTextHandler(w, opts), nil
+	case FormatJson:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
 
-	multiHandler := NewMultiHandler(stdoutHandler, fileHandler)
+func rotateEvery(w *lumberjack.Logger, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	Log = slog.New(multiHandler)
+	for {
+		select {
+		case <-ticker.C:
+			_ = w.Rotate()
+		case <-stop:
+			return
+		}
+	}
 }
@@ -0,0 +1,52 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvz/configs"
+	"pvz/internal/logger"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("json file sink", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		require.NoError(t, logger.Setup(configs.LogConfig{
+			Level:         "info",
+			Format:        logger.FormatJson,
+			FileEnabled:   true,
+			File:          path,
+			FileMaxSizeMb: 1,
+		}))
+		defer logger.Close()
+
+		logger.Log.Debug("hidden")
+		logger.Log.Info("visible", "pvzId", "pvz1")
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(data, &line))
+		require.Equal(t, "visible", line["msg"])
+		require.Equal(t, "pvz1", line["pvzId"])
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		require.Error(t, logger.Setup(configs.LogConfig{Level: "info", Format: "xml"}))
+	})
+}
+
+func TestSetLevel(t *testing.T) {
+	logger.Init("info")
+
+	require.NoError(t, logger.SetLevel("debug"))
+	require.Equal(t, "debug", logger.Level())
+
+	require.Error(t, logger.SetLevel("verbose"))
+	require.Equal(t, "debug", logger.Level())
+}
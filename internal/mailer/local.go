@@ -0,0 +1,73 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"pvz/internal/logger"
+	"strings"
+	"time"
+)
+
+// LogMailer writes messages to the log instead of delivering them, for local development. The secret
+// is redacted from the body: logs are kept and read far more widely than mailboxes.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (lm *LogMailer) Send(ctx context.Context, msg Message) error {
+	body := msg.Body
+	if msg.Secret != "" {
+		body = strings.ReplaceAll(body, msg.Secret, "[redacted]")
+	}
+	logger.FromContext(ctx).Info("mail not delivered, log mailer in use",
+		"to", msg.To, "subject", msg.Subject, "body", body)
+	return nil
+}
+
+// FileMailer stores every message as an .eml file in dir.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (fm *FileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s_%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), sanitize(msg.To))
+	path := filepath.Join(fm.dir, name)
+	if err := os.WriteFile(path, compose(fm.from, msg), 0o640); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("mail written to file", "to", msg.To, "path", path)
+	return nil
+}
+
+func sanitize(addr string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '@' || r == '.' || r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, addr)
+}
+
+func compose(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"pvz/configs"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	// Secret is the one-time token the body carries. Mailers that do not deliver the message redact it.
+	Secret string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func New(cfg configs.MailerConfig) (Mailer, error) {
+	switch cfg.Kind {
+	case configs.MailerLog:
+		return NewLogMailer(), nil
+	case configs.MailerFile:
+		return NewFileMailer(cfg.FileDir, cfg.From)
+	case configs.MailerSmtp:
+		return NewSmtpMailer(cfg)
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", cfg.Kind)
+	}
+}
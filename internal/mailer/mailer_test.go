@@ -0,0 +1,61 @@
+package mailer_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/mailer"
+)
+
+func TestFileMailer(t *testing.T) {
+	logger.Init("debug")
+	dir := t.TempDir()
+
+	m, err := mailer.New(configs.MailerConfig{Kind: configs.MailerFile, FileDir: dir, From: "pvz@localhost"})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), mailer.Message{To: "user@avito.ru", Subject: "Hello", Body: "line1\nline2"})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*_user@avito.ru.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), "To: user@avito.ru\r\n")
+	require.Contains(t, string(data), "Subject: Hello\r\n")
+	require.Contains(t, string(data), "\r\n\r\nline1\r\nline2")
+}
+
+func TestLogMailerRedactsSecret(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logger.WithContext(context.Background(), slog.New(slog.NewTextHandler(&buf, nil)))
+
+	m := mailer.NewLogMailer()
+	err := m.Send(ctx, mailer.Message{To: "user@avito.ru", Subject: "Hello", Body: "POST /invitations/tok123/accept", Secret: "tok123"})
+	require.NoError(t, err)
+
+	require.NotContains(t, buf.String(), "tok123")
+	require.Contains(t, buf.String(), "/invitations/[redacted]/accept")
+}
+
+func TestNew(t *testing.T) {
+	_, err := mailer.New(configs.MailerConfig{Kind: "pigeon"})
+	require.Error(t, err)
+
+	_, err = mailer.New(configs.MailerConfig{Kind: configs.MailerSmtp})
+	require.Error(t, err)
+
+	m, err := mailer.New(configs.MailerConfig{Kind: configs.MailerLog})
+	require.NoError(t, err)
+	require.NoError(t, m.Send(context.Background(), mailer.Message{To: "user@avito.ru"}))
+}
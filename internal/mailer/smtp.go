@@ -0,0 +1,75 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"pvz/configs"
+	"strconv"
+)
+
+type SmtpMailer struct {
+	cfg configs.MailerConfig
+}
+
+func NewSmtpMailer(cfg configs.MailerConfig) (*SmtpMailer, error) {
+	if cfg.SmtpHost == "" {
+		return nil, errors.New("SMTP_HOST is required for the smtp mailer")
+	}
+	return &SmtpMailer{cfg: cfg}, nil
+}
+
+func (sm *SmtpMailer) Send(ctx context.Context, msg Message) error {
+	ctx, cancel := context.WithTimeout(ctx, sm.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(sm.cfg.SmtpHost, strconv.Itoa(sm.cfg.SmtpPort))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, sm.cfg.SmtpHost)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: sm.cfg.SmtpHost}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if sm.cfg.SmtpUser != "" {
+		auth := smtp.PlainAuth("", sm.cfg.SmtpUser, sm.cfg.SmtpPassword, sm.cfg.SmtpHost)
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+
+	if err = client.Mail(sm.cfg.From); err != nil {
+		return err
+	}
+	if err = client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(compose(sm.cfg.From, msg)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
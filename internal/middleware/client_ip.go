@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+	"net"
+	"net/netip"
+	"pvz/configs"
+	"strings"
+)
+
+// ClientIp is the address of the caller. Forwarding headers are written by whoever sends the request, so
+// they are only read when the connection comes from one of TRUSTED_PROXIES. X-Forwarded-For is then walked
+// from the right, skipping trusted proxies: only the hops they appended can be believed.
+func ClientIp(c echo.Context) string {
+	req := c.Request()
+	remote := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+
+	addr, err := netip.ParseAddr(remote)
+	if err != nil || !trustedProxy(addr) {
+		return remote
+	}
+
+	if forwarded := req.Header.Values(echo.HeaderXForwardedFor); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			if !trustedProxy(hop) {
+				return hop.Unmap().String()
+			}
+			addr = hop
+		}
+		return addr.Unmap().String()
+	}
+
+	if realIp, err := netip.ParseAddr(strings.TrimSpace(req.Header.Get(echo.HeaderXRealIP))); err == nil {
+		return realIp.Unmap().String()
+	}
+	return remote
+}
+
+func trustedProxy(addr netip.Addr) bool {
+	return configs.AppConfiguration != nil && configs.AppConfiguration.TrustedProxies.Contains(addr)
+}
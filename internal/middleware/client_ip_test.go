@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/configs"
+	"pvz/internal/middleware"
+)
+
+func TestClientIp(t *testing.T) {
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	var proxies configs.Proxies
+	require.NoError(t, proxies.UnmarshalText([]byte("10.0.0.0/8, 192.0.2.10")))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIp     string
+		want       string
+	}{
+		{
+			name:       "direct caller",
+			remoteAddr: "198.51.100.7:5123",
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "headers from an untrusted caller are ignored",
+			remoteAddr: "198.51.100.7:5123",
+			forwarded:  []string{"203.0.113.1"},
+			realIp:     "203.0.113.2",
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "trusted proxy forwards the client",
+			remoteAddr: "10.1.2.3:443",
+			forwarded:  []string{"203.0.113.1"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "hops prepended by the client are skipped",
+			remoteAddr: "10.1.2.3:443",
+			forwarded:  []string{"1.1.1.1, 203.0.113.1", "192.0.2.10"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "garbled hop stops the walk",
+			remoteAddr: "10.1.2.3:443",
+			forwarded:  []string{"203.0.113.1, nonsense, 10.0.0.5"},
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "real ip from a trusted proxy",
+			remoteAddr: "192.0.2.10:443",
+			realIp:     "203.0.113.2",
+			want:       "203.0.113.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs.AppConfiguration.TrustedProxies = proxies
+			defer func() { configs.AppConfiguration.TrustedProxies = nil }()
+
+			req := httptest.NewRequest(http.MethodPost, "/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, forwarded := range tt.forwarded {
+				req.Header.Add(echo.HeaderXForwardedFor, forwarded)
+			}
+			if tt.realIp != "" {
+				req.Header.Set(echo.HeaderXRealIP, tt.realIp)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			assert.Equal(t, tt.want, middleware.ClientIp(c))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/labstack/echo"
+	"io"
+	"net/http"
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/models/idempotency"
+	"pvz/pkg/errors"
+	"time"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+type IdempotencyStore interface {
+	Begin(ctx context.Context, caller, key, requestHash string) (*idempotency.Response, error)
+	Renew(ctx context.Context, caller, key string) error
+	Complete(ctx context.Context, caller, key string, resp idempotency.Response) error
+	Release(ctx context.Context, caller, key string) error
+}
+
+type recordingWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// Idempotent makes POST requests carrying an Idempotency-Key header safe to retry: the first
+// request with a key runs and its response is stored, repeats get that response replayed. Keys are
+// scoped to the caller and bound to the request body, and a failed request releases its key. It must
+// run after Authenticate and inside HandleError.
+func Idempotent(store IdempotencyStore) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if c.Request().Method != http.MethodPost || key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return errors.NewPropertyTooBig(HeaderIdempotencyKey)
+			}
+
+			log := logger.FromContext(c.Request().Context()).With("idempotencyKey", key)
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				log.Error("failed to read request body", "err", err)
+				if httpErr, ok := err.(*echo.HTTPError); ok {
+					// a body limit in front of this middleware
+					return httpErr
+				}
+				return errors.NewMalformedBody()
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			caller := callerKey(c)
+			stored, err := store.Begin(c.Request().Context(), caller, key, requestHash(c.Request(), body))
+			if err != nil {
+				return err
+			}
+			if stored != nil {
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				return c.Blob(stored.Status, stored.ContentType, stored.Body)
+			}
+
+			recorder := &recordingWriter{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			// the outcome must be stored even when the request deadline has already passed
+			storeCtx := context.WithoutCancel(c.Request().Context())
+			stopRenew := renewWhileRunning(c.Request().Context(), store, caller, key)
+			err = next(c)
+			stopRenew()
+			if err != nil {
+				if releaseErr := store.Release(storeCtx, caller, key); releaseErr != nil {
+					log.Error("failed to release idempotency key", "err", releaseErr)
+				}
+				return err
+			}
+
+			resp := idempotency.Response{
+				Status:      c.Response().Status,
+				ContentType: c.Response().Header().Get(echo.HeaderContentType),
+				Body:        recorder.body.Bytes(),
+			}
+			if err = store.Complete(storeCtx, caller, key, resp); err != nil {
+				log.Error("failed to store idempotent response", "err", err)
+			}
+			return nil
+		}
+	}
+}
+
+// renewWhileRunning keeps the key claimed by a request that may run longer than the renew interval,
+// such as an import without a deadline, so a retry cannot take the key over as stale meanwhile.
+func renewWhileRunning(ctx context.Context, store IdempotencyStore, caller, key string) (stop func()) {
+	every := configs.DefaultIdempotencyRenewInterval
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < every {
+		return func() {}
+	}
+
+	renewCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				if err := store.Renew(renewCtx, caller, key); err != nil {
+					logger.FromContext(ctx).Warn("failed to renew idempotency key", "idempotencyKey", key, "err", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func requestHash(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
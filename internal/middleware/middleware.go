@@ -2,15 +2,20 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
+	stdErrors "errors"
 	"fmt"
 	"github.com/labstack/echo"
+	"io"
 	"net/http"
 	"pvz/configs"
+	"pvz/internal/i18n"
 	"pvz/internal/logger"
 	"pvz/internal/models/auth"
 	"pvz/internal/tokens"
 	"pvz/pkg/errors"
 	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -24,44 +29,125 @@ func SetApiTimeout(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
-func AllowRoles(trueRoles ...auth.Role) func(handlerFunc echo.HandlerFunc) echo.HandlerFunc {
+// BodyLimit rejects a request whose body is longer than limit bytes with 413, up front when Content-Length
+// says so and otherwise once the handler reads past the limit.
+func BodyLimit(limit int64) func(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if !HasAccess(c.Request(), trueRoles) {
-				logger.Log.Error("access denied")
-				return errors.NewAccessForbidden()
+			req := c.Request()
+			if req.ContentLength > limit {
+				return echo.ErrStatusRequestEntityTooLarge
 			}
+			req.Body = &limitedBody{ReadCloser: http.MaxBytesReader(c.Response(), req.Body, limit)}
 			return next(c)
 		}
 	}
 }
 
-func HasAccess(r *http.Request, roles []auth.Role) bool {
-	header := r.Header.Get("Authorization")
-	parts := strings.Split(header, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return false
+// limitedBody reports a body cut by http.MaxBytesReader as echo.ErrStatusRequestEntityTooLarge, which
+// HandleError renders as 413.
+type limitedBody struct {
+	io.ReadCloser
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var tooLarge *http.MaxBytesError
+	if stdErrors.As(err, &tooLarge) {
+		return n, echo.ErrStatusRequestEntityTooLarge
 	}
-	jwt := parts[1]
-	claims, err := tokens.ParseJwt(jwt)
-	if err != nil {
-		return false
+	return n, err
+}
+
+const principalKey = "principal"
+
+type principalCtxKey struct{}
+
+// Authenticate parses the bearer token once and stores the caller's principal in both
+// the echo context and the request context. Requests without a valid token pass through
+// unauthenticated and are rejected later by AllowRoles where a role is required.
+func Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		header := c.Request().Header.Get(echo.HeaderAuthorization)
+		if header == "" {
+			return next(c)
+		}
+
+		log := logger.FromContext(c.Request().Context())
+
+		parts := strings.Split(header, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			log.Warn("malformed authorization header")
+			return next(c)
+		}
+		claims, err := tokens.ParseJwt(parts[1])
+		if err != nil {
+			log.Warn("failed to parse jwt", "err", err)
+			return next(c)
+		}
+
+		principal := claims.Principal()
+		c.Set(principalKey, principal)
+		ctx := context.WithValue(c.Request().Context(), principalCtxKey{}, principal)
+		ctx = logger.WithContext(ctx, log.With("user_id", principal.UserId, "role", principal.Role))
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+func GetPrincipal(c echo.Context) (auth.Principal, bool) {
+	principal, ok := c.Get(principalKey).(auth.Principal)
+	return principal, ok
+}
+
+func PrincipalFromContext(ctx context.Context) (auth.Principal, bool) {
+	principal, ok := ctx.Value(principalCtxKey{}).(auth.Principal)
+	return principal, ok
+}
+
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, principal auth.Principal) error
+}
+
+// CheckSession rejects authenticated requests whose token was revoked by a password change
+// or reset. It must run after Authenticate; anonymous requests are left alone.
+func CheckSession(validator SessionValidator) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := GetPrincipal(c)
+			if !ok {
+				return next(c)
+			}
+			if err := validator.ValidateSession(c.Request().Context(), principal); err != nil {
+				return err
+			}
+			return next(c)
+		}
 	}
+}
 
-	ok := slices.Contains(roles, claims.Role)
-	if !ok {
-		return false
+func AllowRoles(trueRoles ...auth.Role) func(handlerFunc echo.HandlerFunc) echo.HandlerFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := GetPrincipal(c)
+			if !ok || !slices.Contains(trueRoles, principal.Role) {
+				logger.FromContext(c.Request().Context()).Error("access denied")
+				return errors.NewAccessForbidden()
+			}
+			return next(c)
+		}
 	}
-	return true
 }
 
+// HandleError renders errors as application/problem+json, or in the legacy {"message": ...} shape
+// when LEGACY_ERRORS is set. Errors from outside pkg/errors are reported as internal errors.
 func HandleError(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		err := next(c)
 		if err == nil {
 			return nil
 		}
-		var status int
+		var status, retryAfter int
 		switch e := err.(type) {
 		case *echo.HTTPError:
 			return echo.NewHTTPError(e.Code, e.Message)
@@ -69,19 +155,94 @@ func HandleError(next echo.HandlerFunc) echo.HandlerFunc {
 			status = http.StatusNotFound
 		case errors.PropertyMissing, errors.PropertyTooSmall, errors.PropertyTooBig, errors.ObjectAlreadyExists,
 			errors.WrongPropertyValue, errors.MalformedBody, errors.ReceptionIsNotClosed, errors.ReceptionIsNotInProgress,
-			errors.BadPropertyValue, errors.BadParamValue, errors.ParamMissing, errors.StartDateAfterEndDate:
+			errors.BadPropertyValue, errors.BadParamValue, errors.ParamMissing, errors.StartDateAfterEndDate,
+			errors.InvalidToken, errors.PropertyInvalid, errors.PropertyInFuture, errors.ValidationFailed:
 			status = http.StatusBadRequest
-		case errors.AccessForbidden:
+		case errors.IdempotencyKeyInProgress:
+			status = http.StatusConflict
+		case errors.IdempotencyKeyReused:
+			status = http.StatusUnprocessableEntity
+		case errors.AccessForbidden, errors.FeatureDisabled:
 			status = http.StatusForbidden
-		case errors.InvalidCredentials:
+		case errors.InvalidCredentials, errors.SessionExpired:
 			status = http.StatusUnauthorized
+		case errors.TooManyLoginAttempts:
+			retryAfter = e.RetryAfter
+			status = http.StatusTooManyRequests
+		case errors.RateLimited:
+			retryAfter = e.RetryAfter
+			status = http.StatusTooManyRequests
 		case errors.InternalError:
 			status = http.StatusInternalServerError
 		default:
 			status = http.StatusInternalServerError
 		}
+		if retryAfter > 0 {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
+
+		logger.FromContext(c.Request().Context()).Error(fmt.Sprintf("request ended with error=%s, status code=%v", err, status))
+		requestId := RequestIdFromContext(c.Request().Context())
+		lang := Language(c)
+		trans := i18n.Translator(lang)
+		c.Response().Header().Set(echo.HeaderVary, "Accept-Language")
+		c.Response().Header().Set("Content-Language", lang)
+		if legacyErrors() {
+			return c.JSON(status, legacyBody(err, requestId, trans))
+		}
 
-		logger.Log.Error(fmt.Sprintf("request ended with error=%s, status code=%v", err, status))
-		return c.JSON(status, err)
+		coded, ok := err.(errors.Coded)
+		if !ok {
+			coded = errors.NewInternalError()
+		}
+		problem := errors.NewProblem(coded, status, trans)
+		problem.Instance = c.Request().URL.Path
+		problem.RetryAfter = retryAfter
+		problem.RequestId = requestId
+		body, marshalErr := json.Marshal(problem)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		return c.Blob(status, errors.ProblemContentType, body)
+	}
+}
+
+// Language is the language to answer in: the one the user saved, then Accept-Language, then DEFAULT_LANGUAGE.
+// HandleError runs before Authenticate, but the principal is already on the context once next returns.
+func Language(c echo.Context) string {
+	if principal, ok := GetPrincipal(c); ok && i18n.Supported(principal.Language) {
+		return principal.Language
+	}
+	if lang := i18n.FromAcceptLanguage(c.Request().Header.Get("Accept-Language")); lang != "" {
+		return lang
+	}
+	if configs.AppConfiguration != nil {
+		return configs.AppConfiguration.DefaultLanguage
+	}
+	return i18n.English
+}
+
+func legacyErrors() bool {
+	return configs.AppConfiguration != nil && configs.AppConfiguration.LegacyErrors
+}
+
+func legacyBody(err error, requestId string, trans errors.Translator) any {
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		return err
+	}
+	body := map[string]any{}
+	if unmarshalErr := json.Unmarshal(data, &body); unmarshalErr != nil {
+		return err
+	}
+	if coded, ok := err.(errors.Coded); ok {
+		body["message"] = errors.Localize(coded, trans)
+	}
+	if validationFailed, ok := err.(errors.ValidationFailed); ok {
+		body["errors"] = errors.LocalizeFieldErrors(validationFailed.Errors, trans)
+	}
+	if requestId != "" {
+		body["requestId"] = requestId
 	}
+	return body
 }
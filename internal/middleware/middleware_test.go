@@ -0,0 +1,612 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/internal/models/auth"
+	"pvz/internal/models/idempotency"
+	"pvz/internal/ratelimit"
+	"pvz/internal/tokens"
+	"pvz/pkg/errors"
+)
+
+func LoadTestEnv() {
+	os.Setenv("DB_HOST", "test_host")
+	os.Setenv("DB_NAME", "test_db")
+	os.Setenv("DB_USER", "test_user")
+	os.Setenv("DB_PASSWORD", "test_password")
+	os.Setenv("JWT_SECRET", "test_secret")
+}
+
+func TestAuthenticateAndAllowRoles(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	employeeToken, err := tokens.GenerateJwt("550e8400-e29b-41d4-a716-446655440000", auth.Employee, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		roles         []auth.Role
+		wantErr       error
+		wantPrincipal bool
+	}{
+		{
+			name:          "allowed role",
+			authorization: "Bearer " + employeeToken,
+			roles:         []auth.Role{auth.Employee},
+			wantPrincipal: true,
+		},
+		{
+			name:          "wrong role",
+			authorization: "Bearer " + employeeToken,
+			roles:         []auth.Role{auth.Moderator},
+			wantErr:       errors.NewAccessForbidden(),
+		},
+		{
+			name:          "missing token",
+			authorization: "",
+			roles:         []auth.Role{auth.Employee},
+			wantErr:       errors.NewAccessForbidden(),
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer not-a-jwt",
+			roles:         []auth.Role{auth.Employee},
+			wantErr:       errors.NewAccessForbidden(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var got auth.Principal
+			var fromCtx bool
+			handler := middleware.Authenticate(middleware.AllowRoles(tt.roles...)(func(c echo.Context) error {
+				got, _ = middleware.GetPrincipal(c)
+				_, fromCtx = middleware.PrincipalFromContext(c.Request().Context())
+				return nil
+			}))
+
+			err := handler(c)
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantPrincipal {
+				assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", got.UserId)
+				assert.Equal(t, auth.Employee, got.Role)
+				assert.NotEmpty(t, got.TokenId)
+				assert.True(t, fromCtx)
+			}
+		})
+	}
+}
+
+type sessionValidatorFunc func(principal auth.Principal) error
+
+func (f sessionValidatorFunc) ValidateSession(ctx context.Context, principal auth.Principal) error {
+	return f(principal)
+}
+
+func TestCheckSession(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	employeeToken, err := tokens.GenerateJwt("550e8400-e29b-41d4-a716-446655440000", auth.Employee, "")
+	require.NoError(t, err)
+
+	revoked := sessionValidatorFunc(func(principal auth.Principal) error {
+		return errors.NewSessionExpired()
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		validator     middleware.SessionValidator
+		wantErr       error
+		wantCalled    bool
+	}{
+		{
+			name:          "valid session",
+			authorization: "Bearer " + employeeToken,
+			validator: sessionValidatorFunc(func(principal auth.Principal) error {
+				if principal.IssuedAt.IsZero() {
+					return errors.NewInternalError()
+				}
+				return nil
+			}),
+			wantCalled: true,
+		},
+		{
+			name:          "revoked session",
+			authorization: "Bearer " + employeeToken,
+			validator:     revoked,
+			wantErr:       errors.NewSessionExpired(),
+		},
+		{
+			name:       "anonymous request is not checked",
+			validator:  revoked,
+			wantCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			called := false
+			handler := middleware.Authenticate(middleware.CheckSession(tt.validator)(func(c echo.Context) error {
+				called = true
+				return nil
+			}))
+
+			assert.Equal(t, tt.wantErr, handler(c))
+			assert.Equal(t, tt.wantCalled, called)
+		})
+	}
+}
+
+func TestHandleErrorTooManyLoginAttempts(t *testing.T) {
+	logger.Init("debug")
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/login", nil), rec)
+
+	err := middleware.HandleError(func(c echo.Context) error {
+		return errors.NewTooManyLoginAttempts(42)
+	})(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "42", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{
+		"type": "urn:pvz:error:too_many_login_attempts",
+		"title": "Too many login attempts",
+		"status": 429,
+		"detail": "too many failed login attempts, retry in 42 seconds",
+		"instance": "/login",
+		"code": "too_many_login_attempts",
+		"retryAfter": 42
+	}`, rec.Body.String())
+}
+
+func TestHandleErrorProblem(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	tests := []struct {
+		name       string
+		err        error
+		legacy     bool
+		wantStatus int
+		wantType   string
+		wantBody   string
+	}{
+		{
+			name:       "problem with field",
+			err:        errors.NewPropertyMissing("PvzId"),
+			wantStatus: http.StatusBadRequest,
+			wantType:   errors.ProblemContentType,
+			wantBody: `{
+				"type": "urn:pvz:error:property_missing",
+				"title": "Property missing",
+				"status": 400,
+				"detail": "property PvzId is missing",
+				"instance": "/pvz",
+				"code": "property_missing",
+				"field": "PvzId"
+			}`,
+		},
+		{
+			name: "validation errors listed",
+			err: errors.NewValidationFailed([]errors.FieldError{
+				errors.NewFieldError(errors.NewPropertyMissing("pvzId"), "required"),
+				errors.NewFieldError(errors.NewWrongPropertyValue("type", "мебель"), "oneof"),
+			}),
+			wantStatus: http.StatusBadRequest,
+			wantType:   errors.ProblemContentType,
+			wantBody: `{
+				"type": "urn:pvz:error:validation_failed",
+				"title": "Validation failed",
+				"status": 400,
+				"detail": "property pvzId is missing; property type has wrong value мебель",
+				"instance": "/pvz",
+				"code": "validation_failed",
+				"errors": [
+					{"field": "pvzId", "tag": "required", "code": "property_missing", "message": "property pvzId is missing"},
+					{"field": "type", "tag": "oneof", "code": "wrong_property_value", "message": "property type has wrong value мебель"}
+				]
+			}`,
+		},
+		{
+			name:       "unknown error does not leak",
+			err:        fmt.Errorf("pq: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   errors.ProblemContentType,
+			wantBody: `{
+				"type": "urn:pvz:error:internal_error",
+				"title": "Internal error",
+				"status": 500,
+				"detail": "internal error",
+				"instance": "/pvz",
+				"code": "internal_error"
+			}`,
+		},
+		{
+			name:       "legacy shape",
+			err:        errors.NewObjectNotFound("pvz"),
+			legacy:     true,
+			wantStatus: http.StatusNotFound,
+			wantType:   echo.MIMEApplicationJSONCharsetUTF8,
+			wantBody:   `{"message": "pvz not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs.AppConfiguration.LegacyErrors = tt.legacy
+			defer func() { configs.AppConfiguration.LegacyErrors = false }()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/pvz", nil), rec)
+
+			err := middleware.HandleError(func(c echo.Context) error {
+				return tt.err
+			})(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantType, rec.Header().Get(echo.HeaderContentType))
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandleErrorLanguage(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	russianToken, err := tokens.GenerateJwt("550e8400-e29b-41d4-a716-446655440000", auth.Employee, "ru")
+	require.NoError(t, err)
+	englishToken, err := tokens.GenerateJwt("550e8400-e29b-41d4-a716-446655440000", auth.Employee, "en")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		token           string
+		defaultLanguage string
+		legacy          bool
+		wantLanguage    string
+		wantBody        string
+	}{
+		{
+			name:         "english by default",
+			wantLanguage: "en",
+			wantBody:     `"detail": "property pvzId is missing"`,
+		},
+		{
+			name:           "accept-language",
+			acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8",
+			wantLanguage:   "ru",
+			wantBody:       `"detail": "не заполнено свойство pvzId"`,
+		},
+		{
+			name:           "user preference wins over accept-language",
+			acceptLanguage: "ru",
+			token:          englishToken,
+			wantLanguage:   "en",
+			wantBody:       `"detail": "property pvzId is missing"`,
+		},
+		{
+			name:         "user preference",
+			token:        russianToken,
+			wantLanguage: "ru",
+			wantBody:     `"detail": "не заполнено свойство pvzId"`,
+		},
+		{
+			name:            "configured default",
+			acceptLanguage:  "de",
+			defaultLanguage: "ru",
+			wantLanguage:    "ru",
+			wantBody:        `"detail": "не заполнено свойство pvzId"`,
+		},
+		{
+			name:           "legacy shape",
+			acceptLanguage: "ru",
+			legacy:         true,
+			wantLanguage:   "ru",
+			wantBody:       `"message": "не заполнено свойство pvzId"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs.AppConfiguration.LegacyErrors = tt.legacy
+			if tt.defaultLanguage != "" {
+				configs.AppConfiguration.DefaultLanguage = tt.defaultLanguage
+			}
+			defer func() {
+				configs.AppConfiguration.LegacyErrors = false
+				configs.AppConfiguration.DefaultLanguage = "en"
+			}()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/receptions", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if tt.token != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := middleware.HandleError(middleware.Authenticate(func(c echo.Context) error {
+				return errors.NewPropertyMissing("pvzId")
+			}))(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tt.wantLanguage, rec.Header().Get("Content-Language"))
+			assert.Contains(t, strings.ReplaceAll(rec.Body.String(), `":"`, `": "`), tt.wantBody)
+		})
+	}
+}
+
+type limiterFunc func(key string) (ratelimit.Decision, error)
+
+func (f limiterFunc) Allow(ctx context.Context, key string, budget configs.Budget) (ratelimit.Decision, error) {
+	return f(key)
+}
+
+func TestRateLimit(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	employeeToken, err := tokens.GenerateJwt("550e8400-e29b-41d4-a716-446655440000", auth.Employee, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		forwardedFor  string
+		decision      ratelimit.Decision
+		limiterErr    error
+		wantKey       string
+		wantStatus    int
+		wantRetry     string
+	}{
+		{
+			name:          "user is keyed by id",
+			authorization: "Bearer " + employeeToken,
+			decision:      ratelimit.Decision{Allowed: true},
+			wantKey:       "product:user:550e8400-e29b-41d4-a716-446655440000",
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:       "anonymous caller is keyed by ip",
+			decision:   ratelimit.Decision{Allowed: true},
+			wantKey:    "product:ip:192.0.2.1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "spoofed forwarded header does not get a fresh bucket",
+			forwardedFor: "203.0.113.7",
+			decision:     ratelimit.Decision{Allowed: true},
+			wantKey:      "product:ip:192.0.2.1",
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:       "exhausted bucket",
+			decision:   ratelimit.Decision{RetryAfter: 1500 * time.Millisecond},
+			wantKey:    "product:ip:192.0.2.1",
+			wantStatus: http.StatusTooManyRequests,
+			wantRetry:  "2",
+		},
+		{
+			name:       "limiter failure lets the request through",
+			limiterErr: errors.NewInternalError(),
+			wantKey:    "product:ip:192.0.2.1",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/product", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			if tt.forwardedFor != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var gotKey string
+			limiter := limiterFunc(func(key string) (ratelimit.Decision, error) {
+				gotKey = key
+				return tt.decision, tt.limiterErr
+			})
+			handler := middleware.HandleError(middleware.Authenticate(
+				middleware.RateLimit(limiter, "product", configs.Budget{Rate: 1, Burst: 1})(func(c echo.Context) error {
+					return c.NoContent(http.StatusOK)
+				})))
+
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantKey, gotKey)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantRetry, rec.Header().Get("Retry-After"))
+		})
+	}
+}
+
+type memoryIdempotencyStore struct {
+	hashes    map[string]string
+	responses map[string]idempotency.Response
+	released  []string
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{hashes: map[string]string{}, responses: map[string]idempotency.Response{}}
+}
+
+func (s *memoryIdempotencyStore) Begin(ctx context.Context, caller, key, requestHash string) (*idempotency.Response, error) {
+	id := caller + "/" + key
+	hash, ok := s.hashes[id]
+	if !ok {
+		s.hashes[id] = requestHash
+		return nil, nil
+	}
+	if hash != requestHash {
+		return nil, errors.NewIdempotencyKeyReused()
+	}
+	resp, ok := s.responses[id]
+	if !ok {
+		return nil, errors.NewIdempotencyKeyInProgress()
+	}
+	return &resp, nil
+}
+
+func (s *memoryIdempotencyStore) Renew(ctx context.Context, caller, key string) error {
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, caller, key string, resp idempotency.Response) error {
+	s.responses[caller+"/"+key] = resp
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, caller, key string) error {
+	delete(s.hashes, caller+"/"+key)
+	s.released = append(s.released, key)
+	return nil
+}
+
+func TestIdempotent(t *testing.T) {
+	logger.Init("debug")
+
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	handler := middleware.HandleError(middleware.Idempotent(store)(func(c echo.Context) error {
+		calls++
+		var body map[string]string
+		if err := c.Bind(&body); err != nil {
+			return errors.NewMalformedBody()
+		}
+		if body["type"] == "broken" {
+			return errors.NewInternalError()
+		}
+		return c.JSON(http.StatusCreated, map[string]any{"call": calls, "type": body["type"]})
+	}))
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/product", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(middleware.HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+		return rec
+	}
+
+	first := send("key1", `{"type":"обувь"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	repeat := send("key1", `{"type":"обувь"}`)
+	assert.Equal(t, http.StatusCreated, repeat.Code)
+	assert.Equal(t, first.Body.String(), repeat.Body.String())
+	assert.Equal(t, "true", repeat.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, repeat.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, 1, calls, "repeat is not executed")
+
+	reused := send("key1", `{"type":"одежда"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+
+	failed := send("key2", `{"type":"broken"}`)
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, []string{"key2"}, store.released, "failed request gives its key up")
+
+	send("", `{"type":"обувь"}`)
+	send("", `{"type":"обувь"}`)
+	assert.Equal(t, 4, calls, "requests without a key are not deduplicated")
+}
+
+func TestBodyLimit(t *testing.T) {
+	logger.Init("debug")
+
+	store := newMemoryIdempotencyStore()
+	e := echo.New()
+	e.POST("/import", func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, string(body))
+	}, middleware.HandleError, middleware.BodyLimit(8), middleware.Idempotent(store))
+
+	tests := []struct {
+		name     string
+		body     string
+		chunked  bool
+		key      string
+		wantCode int
+	}{
+		{name: "within the limit", body: "12345678", wantCode: http.StatusOK},
+		{name: "content length past the limit", body: "123456789", wantCode: http.StatusRequestEntityTooLarge},
+		{name: "chunked body past the limit", body: "123456789", chunked: true, wantCode: http.StatusRequestEntityTooLarge},
+		{name: "idempotent body past the limit", body: "123456789", chunked: true, key: "key1",
+			wantCode: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			if tt.key != "" {
+				req.Header.Set(middleware.HeaderIdempotencyKey, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+	"math"
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/ratelimit"
+	"pvz/pkg/errors"
+)
+
+// RateLimit spends one token of the caller's bucket in the given scope per request. Callers are
+// told apart by the user id from their token and fall back to the client IP when anonymous, so it
+// must run after Authenticate. When the limiter itself fails the request is let through.
+func RateLimit(limiter ratelimit.Limiter, scope string, budget configs.Budget) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := scope + ":" + callerKey(c)
+
+			log := logger.FromContext(c.Request().Context())
+			decision, err := limiter.Allow(c.Request().Context(), key, budget)
+			if err != nil {
+				log.Error("rate limiter failed, letting request through", "scope", scope, "err", err)
+				return next(c)
+			}
+			if !decision.Allowed {
+				retryAfter := max(int(math.Ceil(decision.RetryAfter.Seconds())), 1)
+				log.Warn("rate limit exceeded", "scope", scope, "key", key, "retryAfter", retryAfter)
+				return errors.NewRateLimited(retryAfter)
+			}
+			return next(c)
+		}
+	}
+}
+
+// callerKey tells clients apart by the user id from their token, or by ClientIp when anonymous.
+func callerKey(c echo.Context) string {
+	if principal, ok := GetPrincipal(c); ok && principal.UserId != "" {
+		return "user:" + principal.UserId
+	}
+	return "ip:" + ClientIp(c)
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel/trace"
+	"pvz/internal/logger"
+	"regexp"
+)
+
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,100}$`)
+
+type requestIdCtxKey struct{}
+
+// RequestId assigns every request an id, reusing a well-formed incoming X-Request-ID,
+// echoes it in the response and stores a logger carrying it in the request context.
+func RequestId(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		requestId := req.Header.Get(echo.HeaderXRequestID)
+		if !requestIdPattern.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, requestId)
+
+		log := logger.Log.With("request_id", requestId, "method", req.Method, "route", c.Path())
+		if spanCtx := trace.SpanContextFromContext(req.Context()); spanCtx.IsValid() {
+			log = log.With("trace_id", spanCtx.TraceID().String())
+		}
+
+		ctx := context.WithValue(req.Context(), requestIdCtxKey{}, requestId)
+		ctx = logger.WithContext(ctx, log)
+		c.SetRequest(req.WithContext(ctx))
+		return next(c)
+	}
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdCtxKey{}).(string)
+	return requestId
+}
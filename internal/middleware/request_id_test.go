@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/logger"
+	"pvz/internal/middleware"
+	"pvz/pkg/errors"
+)
+
+func TestRequestId(t *testing.T) {
+	logger.Init("debug")
+
+	tests := []struct {
+		name     string
+		incoming string
+		reused   bool
+	}{
+		{name: "incoming id is reused", incoming: "terminal-42.req-1", reused: true},
+		{name: "missing id is generated", incoming: ""},
+		{name: "malformed id is replaced", incoming: "bad id\nwith newline"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(middleware.RequestId)
+			e.GET("/pvz", middleware.HandleError(func(c echo.Context) error {
+				assert.Equal(t, c.Response().Header().Get(echo.HeaderXRequestID),
+					middleware.RequestIdFromContext(c.Request().Context()))
+				return errors.NewObjectNotFound("pvz")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
+			if tt.incoming != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			requestId := rec.Header().Get(echo.HeaderXRequestID)
+			require.NotEmpty(t, requestId)
+			if tt.reused {
+				assert.Equal(t, tt.incoming, requestId)
+			} else {
+				assert.NotEqual(t, tt.incoming, requestId)
+			}
+
+			var problem errors.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, "pvz not found", problem.Detail)
+			assert.Equal(t, requestId, problem.RequestId)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"pvz/internal/tracing"
+)
+
+// Trace starts a server span per request, continuing the W3C trace context from the
+// incoming headers. It must run after routing so that the route template is known.
+func Trace(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		route := c.Path()
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.HTTPRoute(route),
+		}
+		for i, name := range c.ParamNames() {
+			attrs = append(attrs, attribute.String(fmt.Sprintf("http.param.%s", name), c.ParamValues()[i]))
+		}
+
+		ctx, span := tracing.StartServer(ctx, req.Method+" "+route, attrs...)
+		defer span.End()
+
+		c.SetRequest(req.WithContext(ctx))
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(c.Response().Header()))
+
+		err := next(c)
+		if err != nil {
+			span.RecordError(err)
+			c.Error(err)
+		}
+
+		status := c.Response().Status
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("status code %d", status))
+		}
+		return nil
+	}
+}
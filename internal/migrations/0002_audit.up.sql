@@ -0,0 +1,26 @@
+CREATE TABLE audit_log (
+                           id BIGSERIAL PRIMARY KEY,
+                           createdAt TIMESTAMP NOT NULL DEFAULT now(),
+                           actorId UUID,
+                           actorRole VARCHAR(20),
+                           action VARCHAR(50) NOT NULL,
+                           pvzId UUID,
+                           targetIds JSONB NOT NULL DEFAULT '{}',
+                           requestId VARCHAR(100),
+                           ip VARCHAR(64),
+                           before JSONB,
+                           after JSONB
+);
+
+CREATE INDEX audit_log_actor_idx ON audit_log (actorId, createdAt);
+CREATE INDEX audit_log_pvz_idx ON audit_log (pvzId, createdAt);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_modify
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,7 @@
+ALTER TABLE products
+    DROP COLUMN acceptedBy;
+
+ALTER TABLE receptions
+    DROP COLUMN closedAt,
+    DROP COLUMN closedBy,
+    DROP COLUMN openedBy;
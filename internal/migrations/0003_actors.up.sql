@@ -0,0 +1,7 @@
+ALTER TABLE receptions
+    ADD COLUMN openedBy UUID,
+    ADD COLUMN closedBy UUID,
+    ADD COLUMN closedAt TIMESTAMP;
+
+ALTER TABLE products
+    ADD COLUMN acceptedBy UUID;
@@ -0,0 +1,5 @@
+CREATE TABLE user_pvzs (
+                           userId UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                           pvzId UUID NOT NULL REFERENCES pvzs(id) ON DELETE CASCADE,
+                           PRIMARY KEY (userId, pvzId)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE invitations (
+                             id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                             email VARCHAR(50) NOT NULL,
+                             role VARCHAR(20) NOT NULL,
+                             pvzIds UUID[] NOT NULL DEFAULT '{}',
+                             tokenHash VARCHAR(64) UNIQUE NOT NULL,
+                             createdBy UUID,
+                             createdAt TIMESTAMP NOT NULL DEFAULT now(),
+                             expiresAt TIMESTAMP NOT NULL,
+                             acceptedAt TIMESTAMP,
+                             acceptedBy UUID REFERENCES users(id) ON DELETE SET NULL
+);
@@ -0,0 +1,4 @@
+DROP TABLE password_resets;
+
+ALTER TABLE users
+    DROP COLUMN sessionsValidAfter;
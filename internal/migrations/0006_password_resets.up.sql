@@ -0,0 +1,11 @@
+ALTER TABLE users
+    ADD COLUMN sessionsValidAfter TIMESTAMP;
+
+CREATE TABLE password_resets (
+                                 id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                 userId UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                 tokenHash VARCHAR(64) UNIQUE NOT NULL,
+                                 createdAt TIMESTAMP NOT NULL DEFAULT now(),
+                                 expiresAt TIMESTAMP NOT NULL,
+                                 usedAt TIMESTAMP
+);
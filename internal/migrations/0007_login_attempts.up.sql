@@ -0,0 +1,8 @@
+CREATE TABLE login_attempts (
+                                kind VARCHAR(16) NOT NULL,
+                                subject VARCHAR(255) NOT NULL,
+                                failures INT NOT NULL DEFAULT 0,
+                                lastFailureAt TIMESTAMP NOT NULL,
+                                blockedUntil TIMESTAMP,
+                                PRIMARY KEY (kind, subject)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE rate_limits (
+                             key VARCHAR(255) PRIMARY KEY,
+                             tokens DOUBLE PRECISION NOT NULL,
+                             allowed BOOLEAN NOT NULL,
+                             updatedAt TIMESTAMP NOT NULL
+);
+
+CREATE INDEX rate_limits_updated_at_idx ON rate_limits (updatedAt);
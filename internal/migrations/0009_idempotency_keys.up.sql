@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+                                  caller VARCHAR(255) NOT NULL,
+                                  key VARCHAR(255) NOT NULL,
+                                  requestHash VARCHAR(64) NOT NULL,
+                                  status INT,
+                                  contentType VARCHAR(255),
+                                  responseBody BYTEA,
+                                  createdAt TIMESTAMP NOT NULL,
+                                  expiresAt TIMESTAMP NOT NULL,
+                                  PRIMARY KEY (caller, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expiresAt);
@@ -0,0 +1,2 @@
+ALTER TABLE pvzs
+    DROP COLUMN address;
@@ -0,0 +1,2 @@
+ALTER TABLE pvzs
+    ADD COLUMN address VARCHAR(255);
@@ -0,0 +1,8 @@
+DROP INDEX pvzs_location_idx;
+
+ALTER TABLE pvzs
+    DROP CONSTRAINT pvzs_location_check,
+    DROP COLUMN latitude,
+    DROP COLUMN longitude,
+    DROP COLUMN workingHours,
+    DROP COLUMN phone;
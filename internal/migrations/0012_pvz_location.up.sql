@@ -0,0 +1,8 @@
+ALTER TABLE pvzs
+    ADD COLUMN latitude     DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude    DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN workingHours VARCHAR(100),
+    ADD COLUMN phone        VARCHAR(20),
+    ADD CONSTRAINT pvzs_location_check CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+CREATE INDEX pvzs_location_idx ON pvzs (latitude, longitude) WHERE latitude IS NOT NULL;
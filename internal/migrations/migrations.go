@@ -0,0 +1,154 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"io/fs"
+	"pvz/internal/logger"
+	"sort"
+	"time"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion is the schema version the service code expects to run against.
+var LatestVersion = mustLatestVersion()
+
+type Status struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []uint
+}
+
+// Migrator applies the embedded migrations over a dedicated connection. Up, Down and Force
+// hold a postgres advisory lock for their whole run, so concurrent replicas wait for each other.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+func New(ctx context.Context, db *sql.DB, lockTimeout time.Duration) (*Migrator, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	src, err := iofs.New(FS, ".")
+	if err != nil {
+		_ = driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		_ = src.Close()
+		_ = driver.Close()
+		return nil, err
+	}
+	m.LockTimeout = lockTimeout
+	m.Log = migrateLogger{}
+
+	return &Migrator{m: m}, nil
+}
+
+func (mg *Migrator) Up() error {
+	return ignoreNoChange(mg.m.Up())
+}
+
+// Down rolls back the given number of migrations, or all of them when steps is not positive.
+func (mg *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return ignoreNoChange(mg.m.Down())
+	}
+	return ignoreNoChange(mg.m.Steps(-steps))
+}
+
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+func (mg *Migrator) Status() (Status, error) {
+	status := Status{Latest: LatestVersion}
+
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, err
+	}
+	status.Version, status.Dirty = version, dirty
+
+	versions, err := embeddedVersions()
+	if err != nil {
+		return Status{}, err
+	}
+	for _, v := range versions {
+		if v > version {
+			status.Pending = append(status.Pending, v)
+		}
+	}
+	return status, nil
+}
+
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+func embeddedVersions() ([]uint, error) {
+	files, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]uint, 0, len(files))
+	for _, file := range files {
+		m, err := source.DefaultParse(file)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration %s: %w", file, err)
+		}
+		versions = append(versions, m.Version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions, nil
+}
+
+func mustLatestVersion() uint {
+	versions, err := embeddedVersions()
+	if err != nil {
+		panic(err)
+	}
+	if len(versions) == 0 {
+		return 0
+	}
+	return versions[len(versions)-1]
+}
+
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...interface{}) {
+	logger.Log.Info(fmt.Sprintf(format, v...), "scope", "migrate")
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
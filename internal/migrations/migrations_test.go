@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	versions, err := embeddedVersions()
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+
+	for i, v := range versions {
+		require.Equal(t, uint(i+1), v, "migration versions must be contiguous")
+	}
+	require.Equal(t, versions[len(versions)-1], LatestVersion)
+
+	ups, err := fs.Glob(FS, "*.up.sql")
+	require.NoError(t, err)
+	downs, err := fs.Glob(FS, "*.down.sql")
+	require.NoError(t, err)
+	require.Len(t, downs, len(ups), "every up migration needs a down migration")
+	for i := range ups {
+		require.Equal(t, strings.SplitN(ups[i], "_", 2)[0], strings.SplitN(downs[i], "_", 2)[0])
+	}
+}
@@ -0,0 +1,150 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	audit "pvz/internal/models/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "pvz/internal/models"
+
+	repositories "pvz/internal/repositories"
+)
+
+// AuditRepository is an autogenerated mock type for the AuditRepository type
+type AuditRepository struct {
+	mock.Mock
+}
+
+type AuditRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepository) EXPECT() *AuditRepository_Expecter {
+	return &AuditRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, q, entry
+func (_m *AuditRepository) Create(ctx context.Context, q repositories.Querier, entry models.AuditEntry) error {
+	ret := _m.Called(ctx, q, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.AuditEntry) error); ok {
+		r0 = rf(ctx, q, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AuditRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - entry models.AuditEntry
+func (_e *AuditRepository_Expecter) Create(ctx interface{}, q interface{}, entry interface{}) *AuditRepository_Create_Call {
+	return &AuditRepository_Create_Call{Call: _e.mock.On("Create", ctx, q, entry)}
+}
+
+func (_c *AuditRepository_Create_Call) Run(run func(ctx context.Context, q repositories.Querier, entry models.AuditEntry)) *AuditRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *AuditRepository_Create_Call) Return(_a0 error) *AuditRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepository_Create_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.AuditEntry) error) *AuditRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, q, req, offset
+func (_m *AuditRepository) List(ctx context.Context, q repositories.Querier, req audit.ListRequest, offset int) ([]models.AuditEntry, error) {
+	ret := _m.Called(ctx, q, req, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, audit.ListRequest, int) ([]models.AuditEntry, error)); ok {
+		return rf(ctx, q, req, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, audit.ListRequest, int) []models.AuditEntry); ok {
+		r0 = rf(ctx, q, req, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, audit.ListRequest, int) error); ok {
+		r1 = rf(ctx, q, req, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AuditRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - req audit.ListRequest
+//   - offset int
+func (_e *AuditRepository_Expecter) List(ctx interface{}, q interface{}, req interface{}, offset interface{}) *AuditRepository_List_Call {
+	return &AuditRepository_List_Call{Call: _e.mock.On("List", ctx, q, req, offset)}
+}
+
+func (_c *AuditRepository_List_Call) Run(run func(ctx context.Context, q repositories.Querier, req audit.ListRequest, offset int)) *AuditRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(audit.ListRequest), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *AuditRepository_List_Call) Return(_a0 []models.AuditEntry, _a1 error) *AuditRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepository_List_Call) RunAndReturn(run func(context.Context, repositories.Querier, audit.ListRequest, int) ([]models.AuditEntry, error)) *AuditRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepository creates a new instance of AuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepository {
+	mock := &AuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
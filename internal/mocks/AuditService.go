@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	audit "pvz/internal/models/audit"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditService is an autogenerated mock type for the AuditService type
+type AuditService struct {
+	mock.Mock
+}
+
+type AuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditService) EXPECT() *AuditService_Expecter {
+	return &AuditService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, req
+func (_m *AuditService) List(ctx context.Context, req audit.ListRequest) ([]audit.Record, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []audit.Record
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.ListRequest) ([]audit.Record, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.ListRequest) []audit.Record); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit.Record)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.ListRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AuditService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req audit.ListRequest
+func (_e *AuditService_Expecter) List(ctx interface{}, req interface{}) *AuditService_List_Call {
+	return &AuditService_List_Call{Call: _e.mock.On("List", ctx, req)}
+}
+
+func (_c *AuditService_List_Call) Run(run func(ctx context.Context, req audit.ListRequest)) *AuditService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.ListRequest))
+	})
+	return _c
+}
+
+func (_c *AuditService_List_Call) Return(_a0 []audit.Record, _a1 error) *AuditService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditService_List_Call) RunAndReturn(run func(context.Context, audit.ListRequest) ([]audit.Record, error)) *AuditService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditService creates a new instance of AuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditService {
+	mock := &AuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,10 +1,13 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	audit "pvz/internal/models/audit"
 	auth "pvz/internal/models/auth"
 
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,9 +24,67 @@ func (_m *AuthService) EXPECT() *AuthService_Expecter {
 	return &AuthService_Expecter{mock: &_m.Mock}
 }
 
-// DummyLogin provides a mock function with given fields: req
-func (_m *AuthService) DummyLogin(req auth.DummyLoginRequest) (string, error) {
-	ret := _m.Called(req)
+// CreateUser provides a mock function with given fields: ctx, meta, req
+func (_m *AuthService) CreateUser(ctx context.Context, meta audit.Meta, req auth.RegisterRequest) (auth.RegisterResponse, error) {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 auth.RegisterResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.RegisterRequest) (auth.RegisterResponse, error)); ok {
+		return rf(ctx, meta, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.RegisterRequest) auth.RegisterResponse); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Get(0).(auth.RegisterResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, auth.RegisterRequest) error); ok {
+		r1 = rf(ctx, meta, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type AuthService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req auth.RegisterRequest
+func (_e *AuthService_Expecter) CreateUser(ctx interface{}, meta interface{}, req interface{}) *AuthService_CreateUser_Call {
+	return &AuthService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, meta, req)}
+}
+
+func (_c *AuthService_CreateUser_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.RegisterRequest)) *AuthService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.RegisterRequest))
+	})
+	return _c
+}
+
+func (_c *AuthService_CreateUser_Call) Return(_a0 auth.RegisterResponse, _a1 error) *AuthService_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthService_CreateUser_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.RegisterRequest) (auth.RegisterResponse, error)) *AuthService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DummyLogin provides a mock function with given fields: ctx, req
+func (_m *AuthService) DummyLogin(ctx context.Context, req auth.DummyLoginRequest) (string, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DummyLogin")
@@ -31,17 +92,17 @@ func (_m *AuthService) DummyLogin(req auth.DummyLoginRequest) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(auth.DummyLoginRequest) (string, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, auth.DummyLoginRequest) (string, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(auth.DummyLoginRequest) string); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, auth.DummyLoginRequest) string); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(auth.DummyLoginRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, auth.DummyLoginRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -55,14 +116,15 @@ type AuthService_DummyLogin_Call struct {
 }
 
 // DummyLogin is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req auth.DummyLoginRequest
-func (_e *AuthService_Expecter) DummyLogin(req interface{}) *AuthService_DummyLogin_Call {
-	return &AuthService_DummyLogin_Call{Call: _e.mock.On("DummyLogin", req)}
+func (_e *AuthService_Expecter) DummyLogin(ctx interface{}, req interface{}) *AuthService_DummyLogin_Call {
+	return &AuthService_DummyLogin_Call{Call: _e.mock.On("DummyLogin", ctx, req)}
 }
 
-func (_c *AuthService_DummyLogin_Call) Run(run func(req auth.DummyLoginRequest)) *AuthService_DummyLogin_Call {
+func (_c *AuthService_DummyLogin_Call) Run(run func(ctx context.Context, req auth.DummyLoginRequest)) *AuthService_DummyLogin_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(auth.DummyLoginRequest))
+		run(args[0].(context.Context), args[1].(auth.DummyLoginRequest))
 	})
 	return _c
 }
@@ -72,14 +134,14 @@ func (_c *AuthService_DummyLogin_Call) Return(_a0 string, _a1 error) *AuthServic
 	return _c
 }
 
-func (_c *AuthService_DummyLogin_Call) RunAndReturn(run func(auth.DummyLoginRequest) (string, error)) *AuthService_DummyLogin_Call {
+func (_c *AuthService_DummyLogin_Call) RunAndReturn(run func(context.Context, auth.DummyLoginRequest) (string, error)) *AuthService_DummyLogin_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Login provides a mock function with given fields: req
-func (_m *AuthService) Login(req auth.LoginRequest) (string, error) {
-	ret := _m.Called(req)
+// Login provides a mock function with given fields: ctx, meta, req
+func (_m *AuthService) Login(ctx context.Context, meta audit.Meta, req auth.LoginRequest) (string, error) {
+	ret := _m.Called(ctx, meta, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -87,17 +149,17 @@ func (_m *AuthService) Login(req auth.LoginRequest) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(auth.LoginRequest) (string, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.LoginRequest) (string, error)); ok {
+		return rf(ctx, meta, req)
 	}
-	if rf, ok := ret.Get(0).(func(auth.LoginRequest) string); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.LoginRequest) string); ok {
+		r0 = rf(ctx, meta, req)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(auth.LoginRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, auth.LoginRequest) error); ok {
+		r1 = rf(ctx, meta, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,14 +173,16 @@ type AuthService_Login_Call struct {
 }
 
 // Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - req auth.LoginRequest
-func (_e *AuthService_Expecter) Login(req interface{}) *AuthService_Login_Call {
-	return &AuthService_Login_Call{Call: _e.mock.On("Login", req)}
+func (_e *AuthService_Expecter) Login(ctx interface{}, meta interface{}, req interface{}) *AuthService_Login_Call {
+	return &AuthService_Login_Call{Call: _e.mock.On("Login", ctx, meta, req)}
 }
 
-func (_c *AuthService_Login_Call) Run(run func(req auth.LoginRequest)) *AuthService_Login_Call {
+func (_c *AuthService_Login_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.LoginRequest)) *AuthService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(auth.LoginRequest))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.LoginRequest))
 	})
 	return _c
 }
@@ -128,14 +192,14 @@ func (_c *AuthService_Login_Call) Return(_a0 string, _a1 error) *AuthService_Log
 	return _c
 }
 
-func (_c *AuthService_Login_Call) RunAndReturn(run func(auth.LoginRequest) (string, error)) *AuthService_Login_Call {
+func (_c *AuthService_Login_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.LoginRequest) (string, error)) *AuthService_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function with given fields: req
-func (_m *AuthService) Register(req auth.RegisterRequest) (auth.RegisterResponse, error) {
-	ret := _m.Called(req)
+// Register provides a mock function with given fields: ctx, meta, req
+func (_m *AuthService) Register(ctx context.Context, meta audit.Meta, req auth.RegisterRequest) (auth.RegisterResponse, error) {
+	ret := _m.Called(ctx, meta, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
@@ -143,17 +207,17 @@ func (_m *AuthService) Register(req auth.RegisterRequest) (auth.RegisterResponse
 
 	var r0 auth.RegisterResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(auth.RegisterRequest) (auth.RegisterResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.RegisterRequest) (auth.RegisterResponse, error)); ok {
+		return rf(ctx, meta, req)
 	}
-	if rf, ok := ret.Get(0).(func(auth.RegisterRequest) auth.RegisterResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.RegisterRequest) auth.RegisterResponse); ok {
+		r0 = rf(ctx, meta, req)
 	} else {
 		r0 = ret.Get(0).(auth.RegisterResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(auth.RegisterRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, auth.RegisterRequest) error); ok {
+		r1 = rf(ctx, meta, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,14 +231,16 @@ type AuthService_Register_Call struct {
 }
 
 // Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - req auth.RegisterRequest
-func (_e *AuthService_Expecter) Register(req interface{}) *AuthService_Register_Call {
-	return &AuthService_Register_Call{Call: _e.mock.On("Register", req)}
+func (_e *AuthService_Expecter) Register(ctx interface{}, meta interface{}, req interface{}) *AuthService_Register_Call {
+	return &AuthService_Register_Call{Call: _e.mock.On("Register", ctx, meta, req)}
 }
 
-func (_c *AuthService_Register_Call) Run(run func(req auth.RegisterRequest)) *AuthService_Register_Call {
+func (_c *AuthService_Register_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.RegisterRequest)) *AuthService_Register_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(auth.RegisterRequest))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.RegisterRequest))
 	})
 	return _c
 }
@@ -184,7 +250,65 @@ func (_c *AuthService_Register_Call) Return(_a0 auth.RegisterResponse, _a1 error
 	return _c
 }
 
-func (_c *AuthService_Register_Call) RunAndReturn(run func(auth.RegisterRequest) (auth.RegisterResponse, error)) *AuthService_Register_Call {
+func (_c *AuthService_Register_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.RegisterRequest) (auth.RegisterResponse, error)) *AuthService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLanguage provides a mock function with given fields: ctx, meta, req
+func (_m *AuthService) SetLanguage(ctx context.Context, meta audit.Meta, req auth.SetLanguageRequest) (string, error) {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.SetLanguageRequest) (string, error)); ok {
+		return rf(ctx, meta, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.SetLanguageRequest) string); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, auth.SetLanguageRequest) error); ok {
+		r1 = rf(ctx, meta, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthService_SetLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLanguage'
+type AuthService_SetLanguage_Call struct {
+	*mock.Call
+}
+
+// SetLanguage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req auth.SetLanguageRequest
+func (_e *AuthService_Expecter) SetLanguage(ctx interface{}, meta interface{}, req interface{}) *AuthService_SetLanguage_Call {
+	return &AuthService_SetLanguage_Call{Call: _e.mock.On("SetLanguage", ctx, meta, req)}
+}
+
+func (_c *AuthService_SetLanguage_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.SetLanguageRequest)) *AuthService_SetLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.SetLanguageRequest))
+	})
+	return _c
+}
+
+func (_c *AuthService_SetLanguage_Call) Return(_a0 string, _a1 error) *AuthService_SetLanguage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthService_SetLanguage_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.SetLanguageRequest) (string, error)) *AuthService_SetLanguage_Call {
 	_c.Call.Return(run)
 	return _c
 }
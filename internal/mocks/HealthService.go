@@ -0,0 +1,207 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	health "pvz/internal/models/health"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthService is an autogenerated mock type for the HealthService type
+type HealthService struct {
+	mock.Mock
+}
+
+type HealthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthService) EXPECT() *HealthService_Expecter {
+	return &HealthService_Expecter{mock: &_m.Mock}
+}
+
+// Details provides a mock function with given fields: ctx
+func (_m *HealthService) Details(ctx context.Context) health.DetailedReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Details")
+	}
+
+	var r0 health.DetailedReport
+	if rf, ok := ret.Get(0).(func(context.Context) health.DetailedReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(health.DetailedReport)
+	}
+
+	return r0
+}
+
+// HealthService_Details_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Details'
+type HealthService_Details_Call struct {
+	*mock.Call
+}
+
+// Details is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthService_Expecter) Details(ctx interface{}) *HealthService_Details_Call {
+	return &HealthService_Details_Call{Call: _e.mock.On("Details", ctx)}
+}
+
+func (_c *HealthService_Details_Call) Run(run func(ctx context.Context)) *HealthService_Details_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthService_Details_Call) Return(_a0 health.DetailedReport) *HealthService_Details_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_Details_Call) RunAndReturn(run func(context.Context) health.DetailedReport) *HealthService_Details_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Live provides a mock function with given fields: ctx
+func (_m *HealthService) Live(ctx context.Context) health.Report {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Live")
+	}
+
+	var r0 health.Report
+	if rf, ok := ret.Get(0).(func(context.Context) health.Report); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(health.Report)
+	}
+
+	return r0
+}
+
+// HealthService_Live_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Live'
+type HealthService_Live_Call struct {
+	*mock.Call
+}
+
+// Live is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthService_Expecter) Live(ctx interface{}) *HealthService_Live_Call {
+	return &HealthService_Live_Call{Call: _e.mock.On("Live", ctx)}
+}
+
+func (_c *HealthService_Live_Call) Run(run func(ctx context.Context)) *HealthService_Live_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthService_Live_Call) Return(_a0 health.Report) *HealthService_Live_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_Live_Call) RunAndReturn(run func(context.Context) health.Report) *HealthService_Live_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkShuttingDown provides a mock function with no fields
+func (_m *HealthService) MarkShuttingDown() {
+	_m.Called()
+}
+
+// HealthService_MarkShuttingDown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkShuttingDown'
+type HealthService_MarkShuttingDown_Call struct {
+	*mock.Call
+}
+
+// MarkShuttingDown is a helper method to define mock.On call
+func (_e *HealthService_Expecter) MarkShuttingDown() *HealthService_MarkShuttingDown_Call {
+	return &HealthService_MarkShuttingDown_Call{Call: _e.mock.On("MarkShuttingDown")}
+}
+
+func (_c *HealthService_MarkShuttingDown_Call) Run(run func()) *HealthService_MarkShuttingDown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_MarkShuttingDown_Call) Return() *HealthService_MarkShuttingDown_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HealthService_MarkShuttingDown_Call) RunAndReturn(run func()) *HealthService_MarkShuttingDown_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Ready provides a mock function with given fields: ctx
+func (_m *HealthService) Ready(ctx context.Context) health.Report {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 health.Report
+	if rf, ok := ret.Get(0).(func(context.Context) health.Report); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(health.Report)
+	}
+
+	return r0
+}
+
+// HealthService_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type HealthService_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthService_Expecter) Ready(ctx interface{}) *HealthService_Ready_Call {
+	return &HealthService_Ready_Call{Call: _e.mock.On("Ready", ctx)}
+}
+
+func (_c *HealthService_Ready_Call) Run(run func(ctx context.Context)) *HealthService_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthService_Ready_Call) Return(_a0 health.Report) *HealthService_Ready_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_Ready_Call) RunAndReturn(run func(context.Context) health.Report) *HealthService_Ready_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthService creates a new instance of HealthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthService {
+	mock := &HealthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
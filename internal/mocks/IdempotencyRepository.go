@@ -0,0 +1,360 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pvz/internal/repositories"
+
+	time "time"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+type IdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdempotencyRepository) EXPECT() *IdempotencyRepository_Expecter {
+	return &IdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, q, caller, key, status, contentType, body
+func (_m *IdempotencyRepository) Complete(ctx context.Context, q repositories.Querier, caller string, key string, status int, contentType string, body []byte) error {
+	ret := _m.Called(ctx, q, caller, key, status, contentType, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string, int, string, []byte) error); ok {
+		r0 = rf(ctx, q, caller, key, status, contentType, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IdempotencyRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - caller string
+//   - key string
+//   - status int
+//   - contentType string
+//   - body []byte
+func (_e *IdempotencyRepository_Expecter) Complete(ctx interface{}, q interface{}, caller interface{}, key interface{}, status interface{}, contentType interface{}, body interface{}) *IdempotencyRepository_Complete_Call {
+	return &IdempotencyRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, q, caller, key, status, contentType, body)}
+}
+
+func (_c *IdempotencyRepository_Complete_Call) Run(run func(ctx context.Context, q repositories.Querier, caller string, key string, status int, contentType string, body []byte)) *IdempotencyRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string), args[4].(int), args[5].(string), args[6].([]byte))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Complete_Call) Return(_a0 error) *IdempotencyRepository_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Complete_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string, int, string, []byte) error) *IdempotencyRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, q, caller, key
+func (_m *IdempotencyRepository) Delete(ctx context.Context, q repositories.Querier, caller string, key string) error {
+	ret := _m.Called(ctx, q, caller, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r0 = rf(ctx, q, caller, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type IdempotencyRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - caller string
+//   - key string
+func (_e *IdempotencyRepository_Expecter) Delete(ctx interface{}, q interface{}, caller interface{}, key interface{}) *IdempotencyRepository_Delete_Call {
+	return &IdempotencyRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, q, caller, key)}
+}
+
+func (_c *IdempotencyRepository_Delete_Call) Run(run func(ctx context.Context, q repositories.Querier, caller string, key string)) *IdempotencyRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Delete_Call) Return(_a0 error) *IdempotencyRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Delete_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) error) *IdempotencyRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, q, now
+func (_m *IdempotencyRepository) DeleteExpired(ctx context.Context, q repositories.Querier, now time.Time) error {
+	ret := _m.Called(ctx, q, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, time.Time) error); ok {
+		r0 = rf(ctx, q, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type IdempotencyRepository_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - now time.Time
+func (_e *IdempotencyRepository_Expecter) DeleteExpired(ctx interface{}, q interface{}, now interface{}) *IdempotencyRepository_DeleteExpired_Call {
+	return &IdempotencyRepository_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx, q, now)}
+}
+
+func (_c *IdempotencyRepository_DeleteExpired_Call) Run(run func(ctx context.Context, q repositories.Querier, now time.Time)) *IdempotencyRepository_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_DeleteExpired_Call) Return(_a0 error) *IdempotencyRepository_DeleteExpired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_DeleteExpired_Call) RunAndReturn(run func(context.Context, repositories.Querier, time.Time) error) *IdempotencyRepository_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, q, caller, key
+func (_m *IdempotencyRepository) Get(ctx context.Context, q repositories.Querier, caller string, key string) (*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, q, caller, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) (*models.IdempotencyKey, error)); ok {
+		return rf(ctx, q, caller, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) *models.IdempotencyKey); ok {
+		r0 = rf(ctx, q, caller, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r1 = rf(ctx, q, caller, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type IdempotencyRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - caller string
+//   - key string
+func (_e *IdempotencyRepository_Expecter) Get(ctx interface{}, q interface{}, caller interface{}, key interface{}) *IdempotencyRepository_Get_Call {
+	return &IdempotencyRepository_Get_Call{Call: _e.mock.On("Get", ctx, q, caller, key)}
+}
+
+func (_c *IdempotencyRepository_Get_Call) Run(run func(ctx context.Context, q repositories.Querier, caller string, key string)) *IdempotencyRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Get_Call) Return(_a0 *models.IdempotencyKey, _a1 error) *IdempotencyRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Get_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) (*models.IdempotencyKey, error)) *IdempotencyRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Renew provides a mock function with given fields: ctx, q, caller, key, now
+func (_m *IdempotencyRepository) Renew(ctx context.Context, q repositories.Querier, caller string, key string, now time.Time) error {
+	ret := _m.Called(ctx, q, caller, key, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Renew")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string, time.Time) error); ok {
+		r0 = rf(ctx, q, caller, key, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_Renew_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Renew'
+type IdempotencyRepository_Renew_Call struct {
+	*mock.Call
+}
+
+// Renew is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - caller string
+//   - key string
+//   - now time.Time
+func (_e *IdempotencyRepository_Expecter) Renew(ctx interface{}, q interface{}, caller interface{}, key interface{}, now interface{}) *IdempotencyRepository_Renew_Call {
+	return &IdempotencyRepository_Renew_Call{Call: _e.mock.On("Renew", ctx, q, caller, key, now)}
+}
+
+func (_c *IdempotencyRepository_Renew_Call) Run(run func(ctx context.Context, q repositories.Querier, caller string, key string, now time.Time)) *IdempotencyRepository_Renew_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Renew_Call) Return(_a0 error) *IdempotencyRepository_Renew_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Renew_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string, time.Time) error) *IdempotencyRepository_Renew_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, q, key, staleBefore
+func (_m *IdempotencyRepository) Reserve(ctx context.Context, q repositories.Querier, key models.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	ret := _m.Called(ctx, q, key, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.IdempotencyKey, time.Time) (bool, error)); ok {
+		return rf(ctx, q, key, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.IdempotencyKey, time.Time) bool); ok {
+		r0 = rf(ctx, q, key, staleBefore)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, models.IdempotencyKey, time.Time) error); ok {
+		r1 = rf(ctx, q, key, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type IdempotencyRepository_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - key models.IdempotencyKey
+//   - staleBefore time.Time
+func (_e *IdempotencyRepository_Expecter) Reserve(ctx interface{}, q interface{}, key interface{}, staleBefore interface{}) *IdempotencyRepository_Reserve_Call {
+	return &IdempotencyRepository_Reserve_Call{Call: _e.mock.On("Reserve", ctx, q, key, staleBefore)}
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) Run(run func(ctx context.Context, q repositories.Querier, key models.IdempotencyKey, staleBefore time.Time)) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.IdempotencyKey), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) Return(_a0 bool, _a1 error) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.IdempotencyKey, time.Time) (bool, error)) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
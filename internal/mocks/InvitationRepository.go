@@ -0,0 +1,208 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pvz/internal/repositories"
+)
+
+// InvitationRepository is an autogenerated mock type for the InvitationRepository type
+type InvitationRepository struct {
+	mock.Mock
+}
+
+type InvitationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationRepository) EXPECT() *InvitationRepository_Expecter {
+	return &InvitationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, q, inv
+func (_m *InvitationRepository) Create(ctx context.Context, q repositories.Querier, inv models.Invitation) (*models.Invitation, error) {
+	ret := _m.Called(ctx, q, inv)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.Invitation) (*models.Invitation, error)); ok {
+		return rf(ctx, q, inv)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.Invitation) *models.Invitation); ok {
+		r0 = rf(ctx, q, inv)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, models.Invitation) error); ok {
+		r1 = rf(ctx, q, inv)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type InvitationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - inv models.Invitation
+func (_e *InvitationRepository_Expecter) Create(ctx interface{}, q interface{}, inv interface{}) *InvitationRepository_Create_Call {
+	return &InvitationRepository_Create_Call{Call: _e.mock.On("Create", ctx, q, inv)}
+}
+
+func (_c *InvitationRepository_Create_Call) Run(run func(ctx context.Context, q repositories.Querier, inv models.Invitation)) *InvitationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.Invitation))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_Create_Call) Return(_a0 *models.Invitation, _a1 error) *InvitationRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepository_Create_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.Invitation) (*models.Invitation, error)) *InvitationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTokenHashForUpdate provides a mock function with given fields: ctx, q, tokenHash
+func (_m *InvitationRepository) GetByTokenHashForUpdate(ctx context.Context, q repositories.Querier, tokenHash string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, q, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTokenHashForUpdate")
+	}
+
+	var r0 *models.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.Invitation, error)); ok {
+		return rf(ctx, q, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.Invitation); ok {
+		r0 = rf(ctx, q, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepository_GetByTokenHashForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenHashForUpdate'
+type InvitationRepository_GetByTokenHashForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByTokenHashForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - tokenHash string
+func (_e *InvitationRepository_Expecter) GetByTokenHashForUpdate(ctx interface{}, q interface{}, tokenHash interface{}) *InvitationRepository_GetByTokenHashForUpdate_Call {
+	return &InvitationRepository_GetByTokenHashForUpdate_Call{Call: _e.mock.On("GetByTokenHashForUpdate", ctx, q, tokenHash)}
+}
+
+func (_c *InvitationRepository_GetByTokenHashForUpdate_Call) Run(run func(ctx context.Context, q repositories.Querier, tokenHash string)) *InvitationRepository_GetByTokenHashForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_GetByTokenHashForUpdate_Call) Return(_a0 *models.Invitation, _a1 error) *InvitationRepository_GetByTokenHashForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepository_GetByTokenHashForUpdate_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.Invitation, error)) *InvitationRepository_GetByTokenHashForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAccepted provides a mock function with given fields: ctx, q, id, userId
+func (_m *InvitationRepository) MarkAccepted(ctx context.Context, q repositories.Querier, id string, userId string) error {
+	ret := _m.Called(ctx, q, id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAccepted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r0 = rf(ctx, q, id, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationRepository_MarkAccepted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAccepted'
+type InvitationRepository_MarkAccepted_Call struct {
+	*mock.Call
+}
+
+// MarkAccepted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - id string
+//   - userId string
+func (_e *InvitationRepository_Expecter) MarkAccepted(ctx interface{}, q interface{}, id interface{}, userId interface{}) *InvitationRepository_MarkAccepted_Call {
+	return &InvitationRepository_MarkAccepted_Call{Call: _e.mock.On("MarkAccepted", ctx, q, id, userId)}
+}
+
+func (_c *InvitationRepository_MarkAccepted_Call) Run(run func(ctx context.Context, q repositories.Querier, id string, userId string)) *InvitationRepository_MarkAccepted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepository_MarkAccepted_Call) Return(_a0 error) *InvitationRepository_MarkAccepted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationRepository_MarkAccepted_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) error) *InvitationRepository_MarkAccepted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationRepository creates a new instance of InvitationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationRepository {
+	mock := &InvitationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
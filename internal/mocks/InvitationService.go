@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	audit "pvz/internal/models/audit"
+
+	invitation "pvz/internal/models/invitation"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "pvz/internal/models/user"
+)
+
+// InvitationService is an autogenerated mock type for the InvitationService type
+type InvitationService struct {
+	mock.Mock
+}
+
+type InvitationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationService) EXPECT() *InvitationService_Expecter {
+	return &InvitationService_Expecter{mock: &_m.Mock}
+}
+
+// Accept provides a mock function with given fields: ctx, meta, token, req
+func (_m *InvitationService) Accept(ctx context.Context, meta audit.Meta, token string, req invitation.AcceptRequest) (user.Response, error) {
+	ret := _m.Called(ctx, meta, token, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 user.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string, invitation.AcceptRequest) (user.Response, error)); ok {
+		return rf(ctx, meta, token, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string, invitation.AcceptRequest) user.Response); ok {
+		r0 = rf(ctx, meta, token, req)
+	} else {
+		r0 = ret.Get(0).(user.Response)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, string, invitation.AcceptRequest) error); ok {
+		r1 = rf(ctx, meta, token, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationService_Accept_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Accept'
+type InvitationService_Accept_Call struct {
+	*mock.Call
+}
+
+// Accept is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - token string
+//   - req invitation.AcceptRequest
+func (_e *InvitationService_Expecter) Accept(ctx interface{}, meta interface{}, token interface{}, req interface{}) *InvitationService_Accept_Call {
+	return &InvitationService_Accept_Call{Call: _e.mock.On("Accept", ctx, meta, token, req)}
+}
+
+func (_c *InvitationService_Accept_Call) Run(run func(ctx context.Context, meta audit.Meta, token string, req invitation.AcceptRequest)) *InvitationService_Accept_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(string), args[3].(invitation.AcceptRequest))
+	})
+	return _c
+}
+
+func (_c *InvitationService_Accept_Call) Return(_a0 user.Response, _a1 error) *InvitationService_Accept_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationService_Accept_Call) RunAndReturn(run func(context.Context, audit.Meta, string, invitation.AcceptRequest) (user.Response, error)) *InvitationService_Accept_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, meta, req
+func (_m *InvitationService) Create(ctx context.Context, meta audit.Meta, req invitation.CreateRequest) (invitation.CreateResponse, error) {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 invitation.CreateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, invitation.CreateRequest) (invitation.CreateResponse, error)); ok {
+		return rf(ctx, meta, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, invitation.CreateRequest) invitation.CreateResponse); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Get(0).(invitation.CreateResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, invitation.CreateRequest) error); ok {
+		r1 = rf(ctx, meta, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type InvitationService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req invitation.CreateRequest
+func (_e *InvitationService_Expecter) Create(ctx interface{}, meta interface{}, req interface{}) *InvitationService_Create_Call {
+	return &InvitationService_Create_Call{Call: _e.mock.On("Create", ctx, meta, req)}
+}
+
+func (_c *InvitationService_Create_Call) Run(run func(ctx context.Context, meta audit.Meta, req invitation.CreateRequest)) *InvitationService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(invitation.CreateRequest))
+	})
+	return _c
+}
+
+func (_c *InvitationService_Create_Call) Return(_a0 invitation.CreateResponse, _a1 error) *InvitationService_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationService_Create_Call) RunAndReturn(run func(context.Context, audit.Meta, invitation.CreateRequest) (invitation.CreateResponse, error)) *InvitationService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationService creates a new instance of InvitationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationService {
+	mock := &InvitationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
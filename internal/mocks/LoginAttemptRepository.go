@@ -0,0 +1,262 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pvz/internal/repositories"
+
+	time "time"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+type LoginAttemptRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginAttemptRepository) EXPECT() *LoginAttemptRepository_Expecter {
+	return &LoginAttemptRepository_Expecter{mock: &_m.Mock}
+}
+
+// Block provides a mock function with given fields: ctx, q, kind, subject, until
+func (_m *LoginAttemptRepository) Block(ctx context.Context, q repositories.Querier, kind string, subject string, until time.Time) error {
+	ret := _m.Called(ctx, q, kind, subject, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string, time.Time) error); ok {
+		r0 = rf(ctx, q, kind, subject, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginAttemptRepository_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type LoginAttemptRepository_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - kind string
+//   - subject string
+//   - until time.Time
+func (_e *LoginAttemptRepository_Expecter) Block(ctx interface{}, q interface{}, kind interface{}, subject interface{}, until interface{}) *LoginAttemptRepository_Block_Call {
+	return &LoginAttemptRepository_Block_Call{Call: _e.mock.On("Block", ctx, q, kind, subject, until)}
+}
+
+func (_c *LoginAttemptRepository_Block_Call) Run(run func(ctx context.Context, q repositories.Querier, kind string, subject string, until time.Time)) *LoginAttemptRepository_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Block_Call) Return(_a0 error) *LoginAttemptRepository_Block_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Block_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string, time.Time) error) *LoginAttemptRepository_Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, q, kind, subject
+func (_m *LoginAttemptRepository) Get(ctx context.Context, q repositories.Querier, kind string, subject string) (*models.LoginAttempt, error) {
+	ret := _m.Called(ctx, q, kind, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) (*models.LoginAttempt, error)); ok {
+		return rf(ctx, q, kind, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) *models.LoginAttempt); ok {
+		r0 = rf(ctx, q, kind, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r1 = rf(ctx, q, kind, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAttemptRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type LoginAttemptRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - kind string
+//   - subject string
+func (_e *LoginAttemptRepository_Expecter) Get(ctx interface{}, q interface{}, kind interface{}, subject interface{}) *LoginAttemptRepository_Get_Call {
+	return &LoginAttemptRepository_Get_Call{Call: _e.mock.On("Get", ctx, q, kind, subject)}
+}
+
+func (_c *LoginAttemptRepository_Get_Call) Run(run func(ctx context.Context, q repositories.Querier, kind string, subject string)) *LoginAttemptRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Get_Call) Return(_a0 *models.LoginAttempt, _a1 error) *LoginAttemptRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Get_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) (*models.LoginAttempt, error)) *LoginAttemptRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterFailure provides a mock function with given fields: ctx, q, kind, subject, now, windowStart
+func (_m *LoginAttemptRepository) RegisterFailure(ctx context.Context, q repositories.Querier, kind string, subject string, now time.Time, windowStart time.Time) (int, error) {
+	ret := _m.Called(ctx, q, kind, subject, now, windowStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterFailure")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string, time.Time, time.Time) (int, error)); ok {
+		return rf(ctx, q, kind, subject, now, windowStart)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, q, kind, subject, now, windowStart)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, q, kind, subject, now, windowStart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAttemptRepository_RegisterFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterFailure'
+type LoginAttemptRepository_RegisterFailure_Call struct {
+	*mock.Call
+}
+
+// RegisterFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - kind string
+//   - subject string
+//   - now time.Time
+//   - windowStart time.Time
+func (_e *LoginAttemptRepository_Expecter) RegisterFailure(ctx interface{}, q interface{}, kind interface{}, subject interface{}, now interface{}, windowStart interface{}) *LoginAttemptRepository_RegisterFailure_Call {
+	return &LoginAttemptRepository_RegisterFailure_Call{Call: _e.mock.On("RegisterFailure", ctx, q, kind, subject, now, windowStart)}
+}
+
+func (_c *LoginAttemptRepository_RegisterFailure_Call) Run(run func(ctx context.Context, q repositories.Querier, kind string, subject string, now time.Time, windowStart time.Time)) *LoginAttemptRepository_RegisterFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string), args[4].(time.Time), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_RegisterFailure_Call) Return(_a0 int, _a1 error) *LoginAttemptRepository_RegisterFailure_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_RegisterFailure_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string, time.Time, time.Time) (int, error)) *LoginAttemptRepository_RegisterFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with given fields: ctx, q, kind, subject
+func (_m *LoginAttemptRepository) Reset(ctx context.Context, q repositories.Querier, kind string, subject string) error {
+	ret := _m.Called(ctx, q, kind, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r0 = rf(ctx, q, kind, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginAttemptRepository_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type LoginAttemptRepository_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - kind string
+//   - subject string
+func (_e *LoginAttemptRepository_Expecter) Reset(ctx interface{}, q interface{}, kind interface{}, subject interface{}) *LoginAttemptRepository_Reset_Call {
+	return &LoginAttemptRepository_Reset_Call{Call: _e.mock.On("Reset", ctx, q, kind, subject)}
+}
+
+func (_c *LoginAttemptRepository_Reset_Call) Run(run func(ctx context.Context, q repositories.Querier, kind string, subject string)) *LoginAttemptRepository_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Reset_Call) Return(_a0 error) *LoginAttemptRepository_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Reset_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) error) *LoginAttemptRepository_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttemptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	repositories "pvz/internal/repositories"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MigrationRepository is an autogenerated mock type for the MigrationRepository type
+type MigrationRepository struct {
+	mock.Mock
+}
+
+type MigrationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MigrationRepository) EXPECT() *MigrationRepository_Expecter {
+	return &MigrationRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetVersion provides a mock function with given fields: ctx, q
+func (_m *MigrationRepository) GetVersion(ctx context.Context, q repositories.Querier) (uint, bool, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVersion")
+	}
+
+	var r0 uint
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier) (uint, bool, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier) uint); ok {
+		r0 = rf(ctx, q)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier) bool); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, repositories.Querier) error); ok {
+		r2 = rf(ctx, q)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MigrationRepository_GetVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVersion'
+type MigrationRepository_GetVersion_Call struct {
+	*mock.Call
+}
+
+// GetVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+func (_e *MigrationRepository_Expecter) GetVersion(ctx interface{}, q interface{}) *MigrationRepository_GetVersion_Call {
+	return &MigrationRepository_GetVersion_Call{Call: _e.mock.On("GetVersion", ctx, q)}
+}
+
+func (_c *MigrationRepository_GetVersion_Call) Run(run func(ctx context.Context, q repositories.Querier)) *MigrationRepository_GetVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier))
+	})
+	return _c
+}
+
+func (_c *MigrationRepository_GetVersion_Call) Return(version uint, dirty bool, err error) *MigrationRepository_GetVersion_Call {
+	_c.Call.Return(version, dirty, err)
+	return _c
+}
+
+func (_c *MigrationRepository_GetVersion_Call) RunAndReturn(run func(context.Context, repositories.Querier) (uint, bool, error)) *MigrationRepository_GetVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMigrationRepository creates a new instance of MigrationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMigrationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MigrationRepository {
+	mock := &MigrationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,195 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pvz/internal/repositories"
+)
+
+// PasswordResetRepository is an autogenerated mock type for the PasswordResetRepository type
+type PasswordResetRepository struct {
+	mock.Mock
+}
+
+type PasswordResetRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasswordResetRepository) EXPECT() *PasswordResetRepository_Expecter {
+	return &PasswordResetRepository_Expecter{mock: &_m.Mock}
+}
+
+// ConsumeAll provides a mock function with given fields: ctx, q, userId
+func (_m *PasswordResetRepository) ConsumeAll(ctx context.Context, q repositories.Querier, userId string) error {
+	ret := _m.Called(ctx, q, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) error); ok {
+		r0 = rf(ctx, q, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordResetRepository_ConsumeAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeAll'
+type PasswordResetRepository_ConsumeAll_Call struct {
+	*mock.Call
+}
+
+// ConsumeAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - userId string
+func (_e *PasswordResetRepository_Expecter) ConsumeAll(ctx interface{}, q interface{}, userId interface{}) *PasswordResetRepository_ConsumeAll_Call {
+	return &PasswordResetRepository_ConsumeAll_Call{Call: _e.mock.On("ConsumeAll", ctx, q, userId)}
+}
+
+func (_c *PasswordResetRepository_ConsumeAll_Call) Run(run func(ctx context.Context, q repositories.Querier, userId string)) *PasswordResetRepository_ConsumeAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordResetRepository_ConsumeAll_Call) Return(_a0 error) *PasswordResetRepository_ConsumeAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordResetRepository_ConsumeAll_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) error) *PasswordResetRepository_ConsumeAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, q, reset
+func (_m *PasswordResetRepository) Create(ctx context.Context, q repositories.Querier, reset models.PasswordReset) error {
+	ret := _m.Called(ctx, q, reset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.PasswordReset) error); ok {
+		r0 = rf(ctx, q, reset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordResetRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PasswordResetRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - reset models.PasswordReset
+func (_e *PasswordResetRepository_Expecter) Create(ctx interface{}, q interface{}, reset interface{}) *PasswordResetRepository_Create_Call {
+	return &PasswordResetRepository_Create_Call{Call: _e.mock.On("Create", ctx, q, reset)}
+}
+
+func (_c *PasswordResetRepository_Create_Call) Run(run func(ctx context.Context, q repositories.Querier, reset models.PasswordReset)) *PasswordResetRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.PasswordReset))
+	})
+	return _c
+}
+
+func (_c *PasswordResetRepository_Create_Call) Return(_a0 error) *PasswordResetRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordResetRepository_Create_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.PasswordReset) error) *PasswordResetRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTokenHashForUpdate provides a mock function with given fields: ctx, q, tokenHash
+func (_m *PasswordResetRepository) GetByTokenHashForUpdate(ctx context.Context, q repositories.Querier, tokenHash string) (*models.PasswordReset, error) {
+	ret := _m.Called(ctx, q, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTokenHashForUpdate")
+	}
+
+	var r0 *models.PasswordReset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.PasswordReset, error)); ok {
+		return rf(ctx, q, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.PasswordReset); ok {
+		r0 = rf(ctx, q, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordReset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasswordResetRepository_GetByTokenHashForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenHashForUpdate'
+type PasswordResetRepository_GetByTokenHashForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByTokenHashForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - tokenHash string
+func (_e *PasswordResetRepository_Expecter) GetByTokenHashForUpdate(ctx interface{}, q interface{}, tokenHash interface{}) *PasswordResetRepository_GetByTokenHashForUpdate_Call {
+	return &PasswordResetRepository_GetByTokenHashForUpdate_Call{Call: _e.mock.On("GetByTokenHashForUpdate", ctx, q, tokenHash)}
+}
+
+func (_c *PasswordResetRepository_GetByTokenHashForUpdate_Call) Run(run func(ctx context.Context, q repositories.Querier, tokenHash string)) *PasswordResetRepository_GetByTokenHashForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordResetRepository_GetByTokenHashForUpdate_Call) Return(_a0 *models.PasswordReset, _a1 error) *PasswordResetRepository_GetByTokenHashForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasswordResetRepository_GetByTokenHashForUpdate_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.PasswordReset, error)) *PasswordResetRepository_GetByTokenHashForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasswordResetRepository creates a new instance of PasswordResetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordResetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordResetRepository {
+	mock := &PasswordResetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,240 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	audit "pvz/internal/models/audit"
+	auth "pvz/internal/models/auth"
+
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordService is an autogenerated mock type for the PasswordService type
+type PasswordService struct {
+	mock.Mock
+}
+
+type PasswordService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasswordService) EXPECT() *PasswordService_Expecter {
+	return &PasswordService_Expecter{mock: &_m.Mock}
+}
+
+// ChangePassword provides a mock function with given fields: ctx, meta, req
+func (_m *PasswordService) ChangePassword(ctx context.Context, meta audit.Meta, req auth.ChangePasswordRequest) (string, error) {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.ChangePasswordRequest) (string, error)); ok {
+		return rf(ctx, meta, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.ChangePasswordRequest) string); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, auth.ChangePasswordRequest) error); ok {
+		r1 = rf(ctx, meta, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasswordService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type PasswordService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req auth.ChangePasswordRequest
+func (_e *PasswordService_Expecter) ChangePassword(ctx interface{}, meta interface{}, req interface{}) *PasswordService_ChangePassword_Call {
+	return &PasswordService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, meta, req)}
+}
+
+func (_c *PasswordService_ChangePassword_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.ChangePasswordRequest)) *PasswordService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.ChangePasswordRequest))
+	})
+	return _c
+}
+
+func (_c *PasswordService_ChangePassword_Call) Return(_a0 string, _a1 error) *PasswordService_ChangePassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasswordService_ChangePassword_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.ChangePasswordRequest) (string, error)) *PasswordService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmReset provides a mock function with given fields: ctx, meta, req
+func (_m *PasswordService) ConfirmReset(ctx context.Context, meta audit.Meta, req auth.ConfirmResetPasswordRequest) error {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.ConfirmResetPasswordRequest) error); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordService_ConfirmReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmReset'
+type PasswordService_ConfirmReset_Call struct {
+	*mock.Call
+}
+
+// ConfirmReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req auth.ConfirmResetPasswordRequest
+func (_e *PasswordService_Expecter) ConfirmReset(ctx interface{}, meta interface{}, req interface{}) *PasswordService_ConfirmReset_Call {
+	return &PasswordService_ConfirmReset_Call{Call: _e.mock.On("ConfirmReset", ctx, meta, req)}
+}
+
+func (_c *PasswordService_ConfirmReset_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.ConfirmResetPasswordRequest)) *PasswordService_ConfirmReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.ConfirmResetPasswordRequest))
+	})
+	return _c
+}
+
+func (_c *PasswordService_ConfirmReset_Call) Return(_a0 error) *PasswordService_ConfirmReset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordService_ConfirmReset_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.ConfirmResetPasswordRequest) error) *PasswordService_ConfirmReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestReset provides a mock function with given fields: ctx, meta, req
+func (_m *PasswordService) RequestReset(ctx context.Context, meta audit.Meta, req auth.ResetPasswordRequest) error {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, auth.ResetPasswordRequest) error); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordService_RequestReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestReset'
+type PasswordService_RequestReset_Call struct {
+	*mock.Call
+}
+
+// RequestReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req auth.ResetPasswordRequest
+func (_e *PasswordService_Expecter) RequestReset(ctx interface{}, meta interface{}, req interface{}) *PasswordService_RequestReset_Call {
+	return &PasswordService_RequestReset_Call{Call: _e.mock.On("RequestReset", ctx, meta, req)}
+}
+
+func (_c *PasswordService_RequestReset_Call) Run(run func(ctx context.Context, meta audit.Meta, req auth.ResetPasswordRequest)) *PasswordService_RequestReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(auth.ResetPasswordRequest))
+	})
+	return _c
+}
+
+func (_c *PasswordService_RequestReset_Call) Return(_a0 error) *PasswordService_RequestReset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordService_RequestReset_Call) RunAndReturn(run func(context.Context, audit.Meta, auth.ResetPasswordRequest) error) *PasswordService_RequestReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateSession provides a mock function with given fields: ctx, principal
+func (_m *PasswordService) ValidateSession(ctx context.Context, principal auth.Principal) error {
+	ret := _m.Called(ctx, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Principal) error); ok {
+		r0 = rf(ctx, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordService_ValidateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateSession'
+type PasswordService_ValidateSession_Call struct {
+	*mock.Call
+}
+
+// ValidateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal auth.Principal
+func (_e *PasswordService_Expecter) ValidateSession(ctx interface{}, principal interface{}) *PasswordService_ValidateSession_Call {
+	return &PasswordService_ValidateSession_Call{Call: _e.mock.On("ValidateSession", ctx, principal)}
+}
+
+func (_c *PasswordService_ValidateSession_Call) Run(run func(ctx context.Context, principal auth.Principal)) *PasswordService_ValidateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(auth.Principal))
+	})
+	return _c
+}
+
+func (_c *PasswordService_ValidateSession_Call) Return(_a0 error) *PasswordService_ValidateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordService_ValidateSession_Call) RunAndReturn(run func(context.Context, auth.Principal) error) *PasswordService_ValidateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasswordService creates a new instance of PasswordService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordService {
+	mock := &PasswordService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,8 +1,9 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	models "pvz/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -23,9 +24,9 @@ func (_m *ProductRepository) EXPECT() *ProductRepository_Expecter {
 	return &ProductRepository_Expecter{mock: &_m.Mock}
 }
 
-// AddInReception provides a mock function with given fields: q, reqProduct
-func (_m *ProductRepository) AddInReception(q repositories.Querier, reqProduct models.Product) (*models.Product, error) {
-	ret := _m.Called(q, reqProduct)
+// AddInReception provides a mock function with given fields: ctx, q, reqProduct
+func (_m *ProductRepository) AddInReception(ctx context.Context, q repositories.Querier, reqProduct models.Product) (*models.Product, error) {
+	ret := _m.Called(ctx, q, reqProduct)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddInReception")
@@ -33,19 +34,19 @@ func (_m *ProductRepository) AddInReception(q repositories.Querier, reqProduct m
 
 	var r0 *models.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, models.Product) (*models.Product, error)); ok {
-		return rf(q, reqProduct)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.Product) (*models.Product, error)); ok {
+		return rf(ctx, q, reqProduct)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, models.Product) *models.Product); ok {
-		r0 = rf(q, reqProduct)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.Product) *models.Product); ok {
+		r0 = rf(ctx, q, reqProduct)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Product)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, models.Product) error); ok {
-		r1 = rf(q, reqProduct)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, models.Product) error); ok {
+		r1 = rf(ctx, q, reqProduct)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,15 +60,16 @@ type ProductRepository_AddInReception_Call struct {
 }
 
 // AddInReception is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - reqProduct models.Product
-func (_e *ProductRepository_Expecter) AddInReception(q interface{}, reqProduct interface{}) *ProductRepository_AddInReception_Call {
-	return &ProductRepository_AddInReception_Call{Call: _e.mock.On("AddInReception", q, reqProduct)}
+func (_e *ProductRepository_Expecter) AddInReception(ctx interface{}, q interface{}, reqProduct interface{}) *ProductRepository_AddInReception_Call {
+	return &ProductRepository_AddInReception_Call{Call: _e.mock.On("AddInReception", ctx, q, reqProduct)}
 }
 
-func (_c *ProductRepository_AddInReception_Call) Run(run func(q repositories.Querier, reqProduct models.Product)) *ProductRepository_AddInReception_Call {
+func (_c *ProductRepository_AddInReception_Call) Run(run func(ctx context.Context, q repositories.Querier, reqProduct models.Product)) *ProductRepository_AddInReception_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(models.Product))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.Product))
 	})
 	return _c
 }
@@ -77,14 +79,14 @@ func (_c *ProductRepository_AddInReception_Call) Return(_a0 *models.Product, _a1
 	return _c
 }
 
-func (_c *ProductRepository_AddInReception_Call) RunAndReturn(run func(repositories.Querier, models.Product) (*models.Product, error)) *ProductRepository_AddInReception_Call {
+func (_c *ProductRepository_AddInReception_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.Product) (*models.Product, error)) *ProductRepository_AddInReception_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteLast provides a mock function with given fields: q, recId
-func (_m *ProductRepository) DeleteLast(q repositories.Querier, recId string) (*models.Product, error) {
-	ret := _m.Called(q, recId)
+// DeleteLast provides a mock function with given fields: ctx, q, recId
+func (_m *ProductRepository) DeleteLast(ctx context.Context, q repositories.Querier, recId string) (*models.Product, error) {
+	ret := _m.Called(ctx, q, recId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLast")
@@ -92,19 +94,19 @@ func (_m *ProductRepository) DeleteLast(q repositories.Querier, recId string) (*
 
 	var r0 *models.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) (*models.Product, error)); ok {
-		return rf(q, recId)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.Product, error)); ok {
+		return rf(ctx, q, recId)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) *models.Product); ok {
-		r0 = rf(q, recId)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.Product); ok {
+		r0 = rf(ctx, q, recId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Product)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, string) error); ok {
-		r1 = rf(q, recId)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, recId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,15 +120,16 @@ type ProductRepository_DeleteLast_Call struct {
 }
 
 // DeleteLast is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - recId string
-func (_e *ProductRepository_Expecter) DeleteLast(q interface{}, recId interface{}) *ProductRepository_DeleteLast_Call {
-	return &ProductRepository_DeleteLast_Call{Call: _e.mock.On("DeleteLast", q, recId)}
+func (_e *ProductRepository_Expecter) DeleteLast(ctx interface{}, q interface{}, recId interface{}) *ProductRepository_DeleteLast_Call {
+	return &ProductRepository_DeleteLast_Call{Call: _e.mock.On("DeleteLast", ctx, q, recId)}
 }
 
-func (_c *ProductRepository_DeleteLast_Call) Run(run func(q repositories.Querier, recId string)) *ProductRepository_DeleteLast_Call {
+func (_c *ProductRepository_DeleteLast_Call) Run(run func(ctx context.Context, q repositories.Querier, recId string)) *ProductRepository_DeleteLast_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(string))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
 	})
 	return _c
 }
@@ -136,7 +139,67 @@ func (_c *ProductRepository_DeleteLast_Call) Return(_a0 *models.Product, _a1 err
 	return _c
 }
 
-func (_c *ProductRepository_DeleteLast_Call) RunAndReturn(run func(repositories.Querier, string) (*models.Product, error)) *ProductRepository_DeleteLast_Call {
+func (_c *ProductRepository_DeleteLast_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.Product, error)) *ProductRepository_DeleteLast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByReception provides a mock function with given fields: ctx, q, recId
+func (_m *ProductRepository) ListByReception(ctx context.Context, q repositories.Querier, recId string) ([]models.Product, error) {
+	ret := _m.Called(ctx, q, recId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByReception")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) ([]models.Product, error)); ok {
+		return rf(ctx, q, recId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) []models.Product); ok {
+		r0 = rf(ctx, q, recId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, recId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProductRepository_ListByReception_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByReception'
+type ProductRepository_ListByReception_Call struct {
+	*mock.Call
+}
+
+// ListByReception is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - recId string
+func (_e *ProductRepository_Expecter) ListByReception(ctx interface{}, q interface{}, recId interface{}) *ProductRepository_ListByReception_Call {
+	return &ProductRepository_ListByReception_Call{Call: _e.mock.On("ListByReception", ctx, q, recId)}
+}
+
+func (_c *ProductRepository_ListByReception_Call) Run(run func(ctx context.Context, q repositories.Querier, recId string)) *ProductRepository_ListByReception_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProductRepository_ListByReception_Call) Return(_a0 []models.Product, _a1 error) *ProductRepository_ListByReception_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProductRepository_ListByReception_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) ([]models.Product, error)) *ProductRepository_ListByReception_Call {
 	_c.Call.Return(run)
 	return _c
 }
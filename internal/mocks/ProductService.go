@@ -1,11 +1,14 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
-	product "pvz/internal/models/product"
+	context "context"
+	audit "pvz/internal/models/audit"
 
 	mock "github.com/stretchr/testify/mock"
+
+	product "pvz/internal/models/product"
 )
 
 // ProductService is an autogenerated mock type for the ProductService type
@@ -21,9 +24,9 @@ func (_m *ProductService) EXPECT() *ProductService_Expecter {
 	return &ProductService_Expecter{mock: &_m.Mock}
 }
 
-// AddInReception provides a mock function with given fields: req
-func (_m *ProductService) AddInReception(req product.AddInReceptionRequest) (product.AddInReceptionResponse, error) {
-	ret := _m.Called(req)
+// AddInReception provides a mock function with given fields: ctx, meta, req
+func (_m *ProductService) AddInReception(ctx context.Context, meta audit.Meta, req product.AddInReceptionRequest) (product.AddInReceptionResponse, error) {
+	ret := _m.Called(ctx, meta, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddInReception")
@@ -31,17 +34,17 @@ func (_m *ProductService) AddInReception(req product.AddInReceptionRequest) (pro
 
 	var r0 product.AddInReceptionResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(product.AddInReceptionRequest) (product.AddInReceptionResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, product.AddInReceptionRequest) (product.AddInReceptionResponse, error)); ok {
+		return rf(ctx, meta, req)
 	}
-	if rf, ok := ret.Get(0).(func(product.AddInReceptionRequest) product.AddInReceptionResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, product.AddInReceptionRequest) product.AddInReceptionResponse); ok {
+		r0 = rf(ctx, meta, req)
 	} else {
 		r0 = ret.Get(0).(product.AddInReceptionResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(product.AddInReceptionRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, product.AddInReceptionRequest) error); ok {
+		r1 = rf(ctx, meta, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -55,14 +58,16 @@ type ProductService_AddInReception_Call struct {
 }
 
 // AddInReception is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - req product.AddInReceptionRequest
-func (_e *ProductService_Expecter) AddInReception(req interface{}) *ProductService_AddInReception_Call {
-	return &ProductService_AddInReception_Call{Call: _e.mock.On("AddInReception", req)}
+func (_e *ProductService_Expecter) AddInReception(ctx interface{}, meta interface{}, req interface{}) *ProductService_AddInReception_Call {
+	return &ProductService_AddInReception_Call{Call: _e.mock.On("AddInReception", ctx, meta, req)}
 }
 
-func (_c *ProductService_AddInReception_Call) Run(run func(req product.AddInReceptionRequest)) *ProductService_AddInReception_Call {
+func (_c *ProductService_AddInReception_Call) Run(run func(ctx context.Context, meta audit.Meta, req product.AddInReceptionRequest)) *ProductService_AddInReception_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(product.AddInReceptionRequest))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(product.AddInReceptionRequest))
 	})
 	return _c
 }
@@ -72,7 +77,7 @@ func (_c *ProductService_AddInReception_Call) Return(_a0 product.AddInReceptionR
 	return _c
 }
 
-func (_c *ProductService_AddInReception_Call) RunAndReturn(run func(product.AddInReceptionRequest) (product.AddInReceptionResponse, error)) *ProductService_AddInReception_Call {
+func (_c *ProductService_AddInReception_Call) RunAndReturn(run func(context.Context, audit.Meta, product.AddInReceptionRequest) (product.AddInReceptionResponse, error)) *ProductService_AddInReception_Call {
 	_c.Call.Return(run)
 	return _c
 }
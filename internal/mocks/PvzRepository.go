@@ -1,8 +1,9 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	models "pvz/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -10,6 +11,8 @@ import (
 	pvz "pvz/internal/models/pvz"
 
 	repositories "pvz/internal/repositories"
+
+	time "time"
 )
 
 // PvzRepository is an autogenerated mock type for the PvzRepository type
@@ -25,9 +28,9 @@ func (_m *PvzRepository) EXPECT() *PvzRepository_Expecter {
 	return &PvzRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: q, reqPvz
-func (_m *PvzRepository) Create(q repositories.Querier, reqPvz pvz.CreateRequest) (*models.Pvz, error) {
-	ret := _m.Called(q, reqPvz)
+// Create provides a mock function with given fields: ctx, q, reqPvz
+func (_m *PvzRepository) Create(ctx context.Context, q repositories.Querier, reqPvz pvz.CreateRequest) (*models.Pvz, error) {
+	ret := _m.Called(ctx, q, reqPvz)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -35,19 +38,19 @@ func (_m *PvzRepository) Create(q repositories.Querier, reqPvz pvz.CreateRequest
 
 	var r0 *models.Pvz
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, pvz.CreateRequest) (*models.Pvz, error)); ok {
-		return rf(q, reqPvz)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, pvz.CreateRequest) (*models.Pvz, error)); ok {
+		return rf(ctx, q, reqPvz)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, pvz.CreateRequest) *models.Pvz); ok {
-		r0 = rf(q, reqPvz)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, pvz.CreateRequest) *models.Pvz); ok {
+		r0 = rf(ctx, q, reqPvz)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Pvz)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, pvz.CreateRequest) error); ok {
-		r1 = rf(q, reqPvz)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, pvz.CreateRequest) error); ok {
+		r1 = rf(ctx, q, reqPvz)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,15 +64,16 @@ type PvzRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - reqPvz pvz.CreateRequest
-func (_e *PvzRepository_Expecter) Create(q interface{}, reqPvz interface{}) *PvzRepository_Create_Call {
-	return &PvzRepository_Create_Call{Call: _e.mock.On("Create", q, reqPvz)}
+func (_e *PvzRepository_Expecter) Create(ctx interface{}, q interface{}, reqPvz interface{}) *PvzRepository_Create_Call {
+	return &PvzRepository_Create_Call{Call: _e.mock.On("Create", ctx, q, reqPvz)}
 }
 
-func (_c *PvzRepository_Create_Call) Run(run func(q repositories.Querier, reqPvz pvz.CreateRequest)) *PvzRepository_Create_Call {
+func (_c *PvzRepository_Create_Call) Run(run func(ctx context.Context, q repositories.Querier, reqPvz pvz.CreateRequest)) *PvzRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(pvz.CreateRequest))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(pvz.CreateRequest))
 	})
 	return _c
 }
@@ -79,14 +83,65 @@ func (_c *PvzRepository_Create_Call) Return(_a0 *models.Pvz, _a1 error) *PvzRepo
 	return _c
 }
 
-func (_c *PvzRepository_Create_Call) RunAndReturn(run func(repositories.Querier, pvz.CreateRequest) (*models.Pvz, error)) *PvzRepository_Create_Call {
+func (_c *PvzRepository_Create_Call) RunAndReturn(run func(context.Context, repositories.Querier, pvz.CreateRequest) (*models.Pvz, error)) *PvzRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function with given fields: ctx, q, startDate, endDate, batchSize, fn
+func (_m *PvzRepository) Export(ctx context.Context, q repositories.Querier, startDate time.Time, endDate time.Time, batchSize int, fn func(pvz.RawList) error) error {
+	ret := _m.Called(ctx, q, startDate, endDate, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, time.Time, time.Time, int, func(pvz.RawList) error) error); ok {
+		r0 = rf(ctx, q, startDate, endDate, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PvzRepository_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type PvzRepository_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - startDate time.Time
+//   - endDate time.Time
+//   - batchSize int
+//   - fn func(pvz.RawList) error
+func (_e *PvzRepository_Expecter) Export(ctx interface{}, q interface{}, startDate interface{}, endDate interface{}, batchSize interface{}, fn interface{}) *PvzRepository_Export_Call {
+	return &PvzRepository_Export_Call{Call: _e.mock.On("Export", ctx, q, startDate, endDate, batchSize, fn)}
+}
+
+func (_c *PvzRepository_Export_Call) Run(run func(ctx context.Context, q repositories.Querier, startDate time.Time, endDate time.Time, batchSize int, fn func(pvz.RawList) error)) *PvzRepository_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(time.Time), args[3].(time.Time), args[4].(int), args[5].(func(pvz.RawList) error))
+	})
+	return _c
+}
+
+func (_c *PvzRepository_Export_Call) Return(_a0 error) *PvzRepository_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PvzRepository_Export_Call) RunAndReturn(run func(context.Context, repositories.Querier, time.Time, time.Time, int, func(pvz.RawList) error) error) *PvzRepository_Export_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetById provides a mock function with given fields: q, id
-func (_m *PvzRepository) GetById(q repositories.Querier, id string) (*models.Pvz, error) {
-	ret := _m.Called(q, id)
+// GetById provides a mock function with given fields: ctx, q, id
+func (_m *PvzRepository) GetById(ctx context.Context, q repositories.Querier, id string) (*models.Pvz, error) {
+	ret := _m.Called(ctx, q, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetById")
@@ -94,19 +149,19 @@ func (_m *PvzRepository) GetById(q repositories.Querier, id string) (*models.Pvz
 
 	var r0 *models.Pvz
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) (*models.Pvz, error)); ok {
-		return rf(q, id)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.Pvz, error)); ok {
+		return rf(ctx, q, id)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) *models.Pvz); ok {
-		r0 = rf(q, id)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.Pvz); ok {
+		r0 = rf(ctx, q, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Pvz)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, string) error); ok {
-		r1 = rf(q, id)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -120,15 +175,16 @@ type PvzRepository_GetById_Call struct {
 }
 
 // GetById is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - id string
-func (_e *PvzRepository_Expecter) GetById(q interface{}, id interface{}) *PvzRepository_GetById_Call {
-	return &PvzRepository_GetById_Call{Call: _e.mock.On("GetById", q, id)}
+func (_e *PvzRepository_Expecter) GetById(ctx interface{}, q interface{}, id interface{}) *PvzRepository_GetById_Call {
+	return &PvzRepository_GetById_Call{Call: _e.mock.On("GetById", ctx, q, id)}
 }
 
-func (_c *PvzRepository_GetById_Call) Run(run func(q repositories.Querier, id string)) *PvzRepository_GetById_Call {
+func (_c *PvzRepository_GetById_Call) Run(run func(ctx context.Context, q repositories.Querier, id string)) *PvzRepository_GetById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(string))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
 	})
 	return _c
 }
@@ -138,14 +194,73 @@ func (_c *PvzRepository_GetById_Call) Return(_a0 *models.Pvz, _a1 error) *PvzRep
 	return _c
 }
 
-func (_c *PvzRepository_GetById_Call) RunAndReturn(run func(repositories.Querier, string) (*models.Pvz, error)) *PvzRepository_GetById_Call {
+func (_c *PvzRepository_GetById_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.Pvz, error)) *PvzRepository_GetById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListWithFilterDate provides a mock function with given fields: q, reqPvz, offset
-func (_m *PvzRepository) ListWithFilterDate(q repositories.Querier, reqPvz pvz.ListRequest, offset int) ([]pvz.RawList, error) {
-	ret := _m.Called(q, reqPvz, offset)
+// List provides a mock function with given fields: ctx, q
+func (_m *PvzRepository) List(ctx context.Context, q repositories.Querier) ([]models.Pvz, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier) ([]models.Pvz, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier) []models.Pvz); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type PvzRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+func (_e *PvzRepository_Expecter) List(ctx interface{}, q interface{}) *PvzRepository_List_Call {
+	return &PvzRepository_List_Call{Call: _e.mock.On("List", ctx, q)}
+}
+
+func (_c *PvzRepository_List_Call) Run(run func(ctx context.Context, q repositories.Querier)) *PvzRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier))
+	})
+	return _c
+}
+
+func (_c *PvzRepository_List_Call) Return(_a0 []models.Pvz, _a1 error) *PvzRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzRepository_List_Call) RunAndReturn(run func(context.Context, repositories.Querier) ([]models.Pvz, error)) *PvzRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWithFilterDate provides a mock function with given fields: ctx, q, reqPvz, offset
+func (_m *PvzRepository) ListWithFilterDate(ctx context.Context, q repositories.Querier, reqPvz pvz.ListRequest, offset int) ([]pvz.RawList, error) {
+	ret := _m.Called(ctx, q, reqPvz, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWithFilterDate")
@@ -153,19 +268,19 @@ func (_m *PvzRepository) ListWithFilterDate(q repositories.Querier, reqPvz pvz.L
 
 	var r0 []pvz.RawList
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, pvz.ListRequest, int) ([]pvz.RawList, error)); ok {
-		return rf(q, reqPvz, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, pvz.ListRequest, int) ([]pvz.RawList, error)); ok {
+		return rf(ctx, q, reqPvz, offset)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, pvz.ListRequest, int) []pvz.RawList); ok {
-		r0 = rf(q, reqPvz, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, pvz.ListRequest, int) []pvz.RawList); ok {
+		r0 = rf(ctx, q, reqPvz, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pvz.RawList)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, pvz.ListRequest, int) error); ok {
-		r1 = rf(q, reqPvz, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, pvz.ListRequest, int) error); ok {
+		r1 = rf(ctx, q, reqPvz, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -179,16 +294,17 @@ type PvzRepository_ListWithFilterDate_Call struct {
 }
 
 // ListWithFilterDate is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - reqPvz pvz.ListRequest
 //   - offset int
-func (_e *PvzRepository_Expecter) ListWithFilterDate(q interface{}, reqPvz interface{}, offset interface{}) *PvzRepository_ListWithFilterDate_Call {
-	return &PvzRepository_ListWithFilterDate_Call{Call: _e.mock.On("ListWithFilterDate", q, reqPvz, offset)}
+func (_e *PvzRepository_Expecter) ListWithFilterDate(ctx interface{}, q interface{}, reqPvz interface{}, offset interface{}) *PvzRepository_ListWithFilterDate_Call {
+	return &PvzRepository_ListWithFilterDate_Call{Call: _e.mock.On("ListWithFilterDate", ctx, q, reqPvz, offset)}
 }
 
-func (_c *PvzRepository_ListWithFilterDate_Call) Run(run func(q repositories.Querier, reqPvz pvz.ListRequest, offset int)) *PvzRepository_ListWithFilterDate_Call {
+func (_c *PvzRepository_ListWithFilterDate_Call) Run(run func(ctx context.Context, q repositories.Querier, reqPvz pvz.ListRequest, offset int)) *PvzRepository_ListWithFilterDate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(pvz.ListRequest), args[2].(int))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(pvz.ListRequest), args[3].(int))
 	})
 	return _c
 }
@@ -198,7 +314,131 @@ func (_c *PvzRepository_ListWithFilterDate_Call) Return(_a0 []pvz.RawList, _a1 e
 	return _c
 }
 
-func (_c *PvzRepository_ListWithFilterDate_Call) RunAndReturn(run func(repositories.Querier, pvz.ListRequest, int) ([]pvz.RawList, error)) *PvzRepository_ListWithFilterDate_Call {
+func (_c *PvzRepository_ListWithFilterDate_Call) RunAndReturn(run func(context.Context, repositories.Querier, pvz.ListRequest, int) ([]pvz.RawList, error)) *PvzRepository_ListWithFilterDate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Nearby provides a mock function with given fields: ctx, q, latitude, longitude, radiusMeters, limit
+func (_m *PvzRepository) Nearby(ctx context.Context, q repositories.Querier, latitude float64, longitude float64, radiusMeters float64, limit int) ([]models.PvzDistance, error) {
+	ret := _m.Called(ctx, q, latitude, longitude, radiusMeters, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Nearby")
+	}
+
+	var r0 []models.PvzDistance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, float64, float64, float64, int) ([]models.PvzDistance, error)); ok {
+		return rf(ctx, q, latitude, longitude, radiusMeters, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, float64, float64, float64, int) []models.PvzDistance); ok {
+		r0 = rf(ctx, q, latitude, longitude, radiusMeters, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PvzDistance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, float64, float64, float64, int) error); ok {
+		r1 = rf(ctx, q, latitude, longitude, radiusMeters, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzRepository_Nearby_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Nearby'
+type PvzRepository_Nearby_Call struct {
+	*mock.Call
+}
+
+// Nearby is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - latitude float64
+//   - longitude float64
+//   - radiusMeters float64
+//   - limit int
+func (_e *PvzRepository_Expecter) Nearby(ctx interface{}, q interface{}, latitude interface{}, longitude interface{}, radiusMeters interface{}, limit interface{}) *PvzRepository_Nearby_Call {
+	return &PvzRepository_Nearby_Call{Call: _e.mock.On("Nearby", ctx, q, latitude, longitude, radiusMeters, limit)}
+}
+
+func (_c *PvzRepository_Nearby_Call) Run(run func(ctx context.Context, q repositories.Querier, latitude float64, longitude float64, radiusMeters float64, limit int)) *PvzRepository_Nearby_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(float64), args[3].(float64), args[4].(float64), args[5].(int))
+	})
+	return _c
+}
+
+func (_c *PvzRepository_Nearby_Call) Return(_a0 []models.PvzDistance, _a1 error) *PvzRepository_Nearby_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzRepository_Nearby_Call) RunAndReturn(run func(context.Context, repositories.Querier, float64, float64, float64, int) ([]models.PvzDistance, error)) *PvzRepository_Nearby_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, q, id, reqPvz
+func (_m *PvzRepository) Update(ctx context.Context, q repositories.Querier, id string, reqPvz pvz.UpdateRequest) (*models.Pvz, error) {
+	ret := _m.Called(ctx, q, id, reqPvz)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, pvz.UpdateRequest) (*models.Pvz, error)); ok {
+		return rf(ctx, q, id, reqPvz)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, pvz.UpdateRequest) *models.Pvz); ok {
+		r0 = rf(ctx, q, id, reqPvz)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string, pvz.UpdateRequest) error); ok {
+		r1 = rf(ctx, q, id, reqPvz)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type PvzRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - id string
+//   - reqPvz pvz.UpdateRequest
+func (_e *PvzRepository_Expecter) Update(ctx interface{}, q interface{}, id interface{}, reqPvz interface{}) *PvzRepository_Update_Call {
+	return &PvzRepository_Update_Call{Call: _e.mock.On("Update", ctx, q, id, reqPvz)}
+}
+
+func (_c *PvzRepository_Update_Call) Run(run func(ctx context.Context, q repositories.Querier, id string, reqPvz pvz.UpdateRequest)) *PvzRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(pvz.UpdateRequest))
+	})
+	return _c
+}
+
+func (_c *PvzRepository_Update_Call) Return(_a0 *models.Pvz, _a1 error) *PvzRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzRepository_Update_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, pvz.UpdateRequest) (*models.Pvz, error)) *PvzRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
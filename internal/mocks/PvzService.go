@@ -1,11 +1,16 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
-	pvz "pvz/internal/models/pvz"
+	context "context"
+	audit "pvz/internal/models/audit"
+
+	export "pvz/internal/models/export"
 
 	mock "github.com/stretchr/testify/mock"
+
+	pvz "pvz/internal/models/pvz"
 )
 
 // PvzService is an autogenerated mock type for the PvzService type
@@ -21,9 +26,9 @@ func (_m *PvzService) EXPECT() *PvzService_Expecter {
 	return &PvzService_Expecter{mock: &_m.Mock}
 }
 
-// CLoseLastReception provides a mock function with given fields: pvzId
-func (_m *PvzService) CLoseLastReception(pvzId string) (pvz.CloseLastProductResponse, error) {
-	ret := _m.Called(pvzId)
+// CLoseLastReception provides a mock function with given fields: ctx, meta, pvzId
+func (_m *PvzService) CLoseLastReception(ctx context.Context, meta audit.Meta, pvzId string) (pvz.CloseLastProductResponse, error) {
+	ret := _m.Called(ctx, meta, pvzId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CLoseLastReception")
@@ -31,17 +36,17 @@ func (_m *PvzService) CLoseLastReception(pvzId string) (pvz.CloseLastProductResp
 
 	var r0 pvz.CloseLastProductResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (pvz.CloseLastProductResponse, error)); ok {
-		return rf(pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string) (pvz.CloseLastProductResponse, error)); ok {
+		return rf(ctx, meta, pvzId)
 	}
-	if rf, ok := ret.Get(0).(func(string) pvz.CloseLastProductResponse); ok {
-		r0 = rf(pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string) pvz.CloseLastProductResponse); ok {
+		r0 = rf(ctx, meta, pvzId)
 	} else {
 		r0 = ret.Get(0).(pvz.CloseLastProductResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(pvzId)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, string) error); ok {
+		r1 = rf(ctx, meta, pvzId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -55,14 +60,16 @@ type PvzService_CLoseLastReception_Call struct {
 }
 
 // CLoseLastReception is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - pvzId string
-func (_e *PvzService_Expecter) CLoseLastReception(pvzId interface{}) *PvzService_CLoseLastReception_Call {
-	return &PvzService_CLoseLastReception_Call{Call: _e.mock.On("CLoseLastReception", pvzId)}
+func (_e *PvzService_Expecter) CLoseLastReception(ctx interface{}, meta interface{}, pvzId interface{}) *PvzService_CLoseLastReception_Call {
+	return &PvzService_CLoseLastReception_Call{Call: _e.mock.On("CLoseLastReception", ctx, meta, pvzId)}
 }
 
-func (_c *PvzService_CLoseLastReception_Call) Run(run func(pvzId string)) *PvzService_CLoseLastReception_Call {
+func (_c *PvzService_CLoseLastReception_Call) Run(run func(ctx context.Context, meta audit.Meta, pvzId string)) *PvzService_CLoseLastReception_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(string))
 	})
 	return _c
 }
@@ -72,14 +79,14 @@ func (_c *PvzService_CLoseLastReception_Call) Return(_a0 pvz.CloseLastProductRes
 	return _c
 }
 
-func (_c *PvzService_CLoseLastReception_Call) RunAndReturn(run func(string) (pvz.CloseLastProductResponse, error)) *PvzService_CLoseLastReception_Call {
+func (_c *PvzService_CLoseLastReception_Call) RunAndReturn(run func(context.Context, audit.Meta, string) (pvz.CloseLastProductResponse, error)) *PvzService_CLoseLastReception_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Create provides a mock function with given fields: req
-func (_m *PvzService) Create(req pvz.CreateRequest) (pvz.CreateResponse, error) {
-	ret := _m.Called(req)
+// Create provides a mock function with given fields: ctx, meta, req
+func (_m *PvzService) Create(ctx context.Context, meta audit.Meta, req pvz.CreateRequest) (pvz.CreateResponse, error) {
+	ret := _m.Called(ctx, meta, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -87,17 +94,17 @@ func (_m *PvzService) Create(req pvz.CreateRequest) (pvz.CreateResponse, error)
 
 	var r0 pvz.CreateResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(pvz.CreateRequest) (pvz.CreateResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, pvz.CreateRequest) (pvz.CreateResponse, error)); ok {
+		return rf(ctx, meta, req)
 	}
-	if rf, ok := ret.Get(0).(func(pvz.CreateRequest) pvz.CreateResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, pvz.CreateRequest) pvz.CreateResponse); ok {
+		r0 = rf(ctx, meta, req)
 	} else {
 		r0 = ret.Get(0).(pvz.CreateResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(pvz.CreateRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, pvz.CreateRequest) error); ok {
+		r1 = rf(ctx, meta, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,14 +118,16 @@ type PvzService_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - req pvz.CreateRequest
-func (_e *PvzService_Expecter) Create(req interface{}) *PvzService_Create_Call {
-	return &PvzService_Create_Call{Call: _e.mock.On("Create", req)}
+func (_e *PvzService_Expecter) Create(ctx interface{}, meta interface{}, req interface{}) *PvzService_Create_Call {
+	return &PvzService_Create_Call{Call: _e.mock.On("Create", ctx, meta, req)}
 }
 
-func (_c *PvzService_Create_Call) Run(run func(req pvz.CreateRequest)) *PvzService_Create_Call {
+func (_c *PvzService_Create_Call) Run(run func(ctx context.Context, meta audit.Meta, req pvz.CreateRequest)) *PvzService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(pvz.CreateRequest))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(pvz.CreateRequest))
 	})
 	return _c
 }
@@ -128,14 +137,14 @@ func (_c *PvzService_Create_Call) Return(_a0 pvz.CreateResponse, _a1 error) *Pvz
 	return _c
 }
 
-func (_c *PvzService_Create_Call) RunAndReturn(run func(pvz.CreateRequest) (pvz.CreateResponse, error)) *PvzService_Create_Call {
+func (_c *PvzService_Create_Call) RunAndReturn(run func(context.Context, audit.Meta, pvz.CreateRequest) (pvz.CreateResponse, error)) *PvzService_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteLastProduct provides a mock function with given fields: pvzId
-func (_m *PvzService) DeleteLastProduct(pvzId string) (pvz.DeleteLastProductResponse, error) {
-	ret := _m.Called(pvzId)
+// DeleteLastProduct provides a mock function with given fields: ctx, meta, pvzId
+func (_m *PvzService) DeleteLastProduct(ctx context.Context, meta audit.Meta, pvzId string) (pvz.DeleteLastProductResponse, error) {
+	ret := _m.Called(ctx, meta, pvzId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLastProduct")
@@ -143,17 +152,17 @@ func (_m *PvzService) DeleteLastProduct(pvzId string) (pvz.DeleteLastProductResp
 
 	var r0 pvz.DeleteLastProductResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (pvz.DeleteLastProductResponse, error)); ok {
-		return rf(pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string) (pvz.DeleteLastProductResponse, error)); ok {
+		return rf(ctx, meta, pvzId)
 	}
-	if rf, ok := ret.Get(0).(func(string) pvz.DeleteLastProductResponse); ok {
-		r0 = rf(pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string) pvz.DeleteLastProductResponse); ok {
+		r0 = rf(ctx, meta, pvzId)
 	} else {
 		r0 = ret.Get(0).(pvz.DeleteLastProductResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(pvzId)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, string) error); ok {
+		r1 = rf(ctx, meta, pvzId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,14 +176,16 @@ type PvzService_DeleteLastProduct_Call struct {
 }
 
 // DeleteLastProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - pvzId string
-func (_e *PvzService_Expecter) DeleteLastProduct(pvzId interface{}) *PvzService_DeleteLastProduct_Call {
-	return &PvzService_DeleteLastProduct_Call{Call: _e.mock.On("DeleteLastProduct", pvzId)}
+func (_e *PvzService_Expecter) DeleteLastProduct(ctx interface{}, meta interface{}, pvzId interface{}) *PvzService_DeleteLastProduct_Call {
+	return &PvzService_DeleteLastProduct_Call{Call: _e.mock.On("DeleteLastProduct", ctx, meta, pvzId)}
 }
 
-func (_c *PvzService_DeleteLastProduct_Call) Run(run func(pvzId string)) *PvzService_DeleteLastProduct_Call {
+func (_c *PvzService_DeleteLastProduct_Call) Run(run func(ctx context.Context, meta audit.Meta, pvzId string)) *PvzService_DeleteLastProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(string))
 	})
 	return _c
 }
@@ -184,14 +195,178 @@ func (_c *PvzService_DeleteLastProduct_Call) Return(_a0 pvz.DeleteLastProductRes
 	return _c
 }
 
-func (_c *PvzService_DeleteLastProduct_Call) RunAndReturn(run func(string) (pvz.DeleteLastProductResponse, error)) *PvzService_DeleteLastProduct_Call {
+func (_c *PvzService_DeleteLastProduct_Call) RunAndReturn(run func(context.Context, audit.Meta, string) (pvz.DeleteLastProductResponse, error)) *PvzService_DeleteLastProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function with given fields: ctx, req, fn
+func (_m *PvzService) Export(ctx context.Context, req export.Request, fn func(export.Row) error) error {
+	ret := _m.Called(ctx, req, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, export.Request, func(export.Row) error) error); ok {
+		r0 = rf(ctx, req, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PvzService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type PvzService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req export.Request
+//   - fn func(export.Row) error
+func (_e *PvzService_Expecter) Export(ctx interface{}, req interface{}, fn interface{}) *PvzService_Export_Call {
+	return &PvzService_Export_Call{Call: _e.mock.On("Export", ctx, req, fn)}
+}
+
+func (_c *PvzService_Export_Call) Run(run func(ctx context.Context, req export.Request, fn func(export.Row) error)) *PvzService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(export.Request), args[2].(func(export.Row) error))
+	})
+	return _c
+}
+
+func (_c *PvzService_Export_Call) Return(_a0 error) *PvzService_Export_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PvzService_Export_Call) RunAndReturn(run func(context.Context, export.Request, func(export.Row) error) error) *PvzService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Import provides a mock function with given fields: ctx, meta, req
+func (_m *PvzService) Import(ctx context.Context, meta audit.Meta, req pvz.ImportRequest) (pvz.ImportResponse, error) {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 pvz.ImportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, pvz.ImportRequest) (pvz.ImportResponse, error)); ok {
+		return rf(ctx, meta, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, pvz.ImportRequest) pvz.ImportResponse); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Get(0).(pvz.ImportResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, pvz.ImportRequest) error); ok {
+		r1 = rf(ctx, meta, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzService_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type PvzService_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req pvz.ImportRequest
+func (_e *PvzService_Expecter) Import(ctx interface{}, meta interface{}, req interface{}) *PvzService_Import_Call {
+	return &PvzService_Import_Call{Call: _e.mock.On("Import", ctx, meta, req)}
+}
+
+func (_c *PvzService_Import_Call) Run(run func(ctx context.Context, meta audit.Meta, req pvz.ImportRequest)) *PvzService_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(pvz.ImportRequest))
+	})
+	return _c
+}
+
+func (_c *PvzService_Import_Call) Return(_a0 pvz.ImportResponse, _a1 error) *PvzService_Import_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzService_Import_Call) RunAndReturn(run func(context.Context, audit.Meta, pvz.ImportRequest) (pvz.ImportResponse, error)) *PvzService_Import_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListWithFilterDate provides a mock function with given fields: req
-func (_m *PvzService) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListResponse, error) {
-	ret := _m.Called(req)
+// List provides a mock function with given fields: ctx
+func (_m *PvzService) List(ctx context.Context) ([]pvz.Pvz, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []pvz.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pvz.Pvz, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []pvz.Pvz); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pvz.Pvz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type PvzService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PvzService_Expecter) List(ctx interface{}) *PvzService_List_Call {
+	return &PvzService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *PvzService_List_Call) Run(run func(ctx context.Context)) *PvzService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PvzService_List_Call) Return(_a0 []pvz.Pvz, _a1 error) *PvzService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzService_List_Call) RunAndReturn(run func(context.Context) ([]pvz.Pvz, error)) *PvzService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWithFilterDate provides a mock function with given fields: ctx, req
+func (_m *PvzService) ListWithFilterDate(ctx context.Context, req pvz.ListRequest) ([]pvz.ListResponse, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWithFilterDate")
@@ -199,19 +374,19 @@ func (_m *PvzService) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListRespons
 
 	var r0 []pvz.ListResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(pvz.ListRequest) ([]pvz.ListResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, pvz.ListRequest) ([]pvz.ListResponse, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(pvz.ListRequest) []pvz.ListResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, pvz.ListRequest) []pvz.ListResponse); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pvz.ListResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(pvz.ListRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, pvz.ListRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -225,14 +400,15 @@ type PvzService_ListWithFilterDate_Call struct {
 }
 
 // ListWithFilterDate is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req pvz.ListRequest
-func (_e *PvzService_Expecter) ListWithFilterDate(req interface{}) *PvzService_ListWithFilterDate_Call {
-	return &PvzService_ListWithFilterDate_Call{Call: _e.mock.On("ListWithFilterDate", req)}
+func (_e *PvzService_Expecter) ListWithFilterDate(ctx interface{}, req interface{}) *PvzService_ListWithFilterDate_Call {
+	return &PvzService_ListWithFilterDate_Call{Call: _e.mock.On("ListWithFilterDate", ctx, req)}
 }
 
-func (_c *PvzService_ListWithFilterDate_Call) Run(run func(req pvz.ListRequest)) *PvzService_ListWithFilterDate_Call {
+func (_c *PvzService_ListWithFilterDate_Call) Run(run func(ctx context.Context, req pvz.ListRequest)) *PvzService_ListWithFilterDate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(pvz.ListRequest))
+		run(args[0].(context.Context), args[1].(pvz.ListRequest))
 	})
 	return _c
 }
@@ -242,7 +418,125 @@ func (_c *PvzService_ListWithFilterDate_Call) Return(_a0 []pvz.ListResponse, _a1
 	return _c
 }
 
-func (_c *PvzService_ListWithFilterDate_Call) RunAndReturn(run func(pvz.ListRequest) ([]pvz.ListResponse, error)) *PvzService_ListWithFilterDate_Call {
+func (_c *PvzService_ListWithFilterDate_Call) RunAndReturn(run func(context.Context, pvz.ListRequest) ([]pvz.ListResponse, error)) *PvzService_ListWithFilterDate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Nearby provides a mock function with given fields: ctx, req
+func (_m *PvzService) Nearby(ctx context.Context, req pvz.NearbyRequest) ([]pvz.NearbyResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Nearby")
+	}
+
+	var r0 []pvz.NearbyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pvz.NearbyRequest) ([]pvz.NearbyResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pvz.NearbyRequest) []pvz.NearbyResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pvz.NearbyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pvz.NearbyRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzService_Nearby_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Nearby'
+type PvzService_Nearby_Call struct {
+	*mock.Call
+}
+
+// Nearby is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req pvz.NearbyRequest
+func (_e *PvzService_Expecter) Nearby(ctx interface{}, req interface{}) *PvzService_Nearby_Call {
+	return &PvzService_Nearby_Call{Call: _e.mock.On("Nearby", ctx, req)}
+}
+
+func (_c *PvzService_Nearby_Call) Run(run func(ctx context.Context, req pvz.NearbyRequest)) *PvzService_Nearby_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pvz.NearbyRequest))
+	})
+	return _c
+}
+
+func (_c *PvzService_Nearby_Call) Return(_a0 []pvz.NearbyResponse, _a1 error) *PvzService_Nearby_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzService_Nearby_Call) RunAndReturn(run func(context.Context, pvz.NearbyRequest) ([]pvz.NearbyResponse, error)) *PvzService_Nearby_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, meta, pvzId, req
+func (_m *PvzService) Update(ctx context.Context, meta audit.Meta, pvzId string, req pvz.UpdateRequest) (pvz.Pvz, error) {
+	ret := _m.Called(ctx, meta, pvzId, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 pvz.Pvz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string, pvz.UpdateRequest) (pvz.Pvz, error)); ok {
+		return rf(ctx, meta, pvzId, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, string, pvz.UpdateRequest) pvz.Pvz); ok {
+		r0 = rf(ctx, meta, pvzId, req)
+	} else {
+		r0 = ret.Get(0).(pvz.Pvz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, string, pvz.UpdateRequest) error); ok {
+		r1 = rf(ctx, meta, pvzId, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PvzService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type PvzService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - pvzId string
+//   - req pvz.UpdateRequest
+func (_e *PvzService_Expecter) Update(ctx interface{}, meta interface{}, pvzId interface{}, req interface{}) *PvzService_Update_Call {
+	return &PvzService_Update_Call{Call: _e.mock.On("Update", ctx, meta, pvzId, req)}
+}
+
+func (_c *PvzService_Update_Call) Run(run func(ctx context.Context, meta audit.Meta, pvzId string, req pvz.UpdateRequest)) *PvzService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(string), args[3].(pvz.UpdateRequest))
+	})
+	return _c
+}
+
+func (_c *PvzService_Update_Call) Return(_a0 pvz.Pvz, _a1 error) *PvzService_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PvzService_Update_Call) RunAndReturn(run func(context.Context, audit.Meta, string, pvz.UpdateRequest) (pvz.Pvz, error)) *PvzService_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,8 +1,10 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	sql "database/sql"
@@ -21,32 +23,32 @@ func (_m *Querier) EXPECT() *Querier_Expecter {
 	return &Querier_Expecter{mock: &_m.Mock}
 }
 
-// Exec provides a mock function with given fields: query, args
-func (_m *Querier) Exec(query string, args ...interface{}) (sql.Result, error) {
+// ExecContext provides a mock function with given fields: ctx, query, args
+func (_m *Querier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	var _ca []interface{}
-	_ca = append(_ca, query)
+	_ca = append(_ca, ctx, query)
 	_ca = append(_ca, args...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exec")
+		panic("no return value specified for ExecContext")
 	}
 
 	var r0 sql.Result
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, ...interface{}) (sql.Result, error)); ok {
-		return rf(query, args...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (sql.Result, error)); ok {
+		return rf(ctx, query, args...)
 	}
-	if rf, ok := ret.Get(0).(func(string, ...interface{}) sql.Result); ok {
-		r0 = rf(query, args...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) sql.Result); ok {
+		r0 = rf(ctx, query, args...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(sql.Result)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, ...interface{}) error); ok {
-		r1 = rf(query, args...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(ctx, query, args...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,68 +56,69 @@ func (_m *Querier) Exec(query string, args ...interface{}) (sql.Result, error) {
 	return r0, r1
 }
 
-// Querier_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
-type Querier_Exec_Call struct {
+// Querier_ExecContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecContext'
+type Querier_ExecContext_Call struct {
 	*mock.Call
 }
 
-// Exec is a helper method to define mock.On call
+// ExecContext is a helper method to define mock.On call
+//   - ctx context.Context
 //   - query string
 //   - args ...interface{}
-func (_e *Querier_Expecter) Exec(query interface{}, args ...interface{}) *Querier_Exec_Call {
-	return &Querier_Exec_Call{Call: _e.mock.On("Exec",
-		append([]interface{}{query}, args...)...)}
+func (_e *Querier_Expecter) ExecContext(ctx interface{}, query interface{}, args ...interface{}) *Querier_ExecContext_Call {
+	return &Querier_ExecContext_Call{Call: _e.mock.On("ExecContext",
+		append([]interface{}{ctx, query}, args...)...)}
 }
 
-func (_c *Querier_Exec_Call) Run(run func(query string, args ...interface{})) *Querier_Exec_Call {
+func (_c *Querier_ExecContext_Call) Run(run func(ctx context.Context, query string, args ...interface{})) *Querier_ExecContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]interface{}, len(args)-1)
-		for i, a := range args[1:] {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
 			if a != nil {
 				variadicArgs[i] = a.(interface{})
 			}
 		}
-		run(args[0].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Querier_Exec_Call) Return(_a0 sql.Result, _a1 error) *Querier_Exec_Call {
+func (_c *Querier_ExecContext_Call) Return(_a0 sql.Result, _a1 error) *Querier_ExecContext_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Querier_Exec_Call) RunAndReturn(run func(string, ...interface{}) (sql.Result, error)) *Querier_Exec_Call {
+func (_c *Querier_ExecContext_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (sql.Result, error)) *Querier_ExecContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Query provides a mock function with given fields: query, args
-func (_m *Querier) Query(query string, args ...interface{}) (*sql.Rows, error) {
+// QueryContext provides a mock function with given fields: ctx, query, args
+func (_m *Querier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	var _ca []interface{}
-	_ca = append(_ca, query)
+	_ca = append(_ca, ctx, query)
 	_ca = append(_ca, args...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Query")
+		panic("no return value specified for QueryContext")
 	}
 
 	var r0 *sql.Rows
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, ...interface{}) (*sql.Rows, error)); ok {
-		return rf(query, args...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (*sql.Rows, error)); ok {
+		return rf(ctx, query, args...)
 	}
-	if rf, ok := ret.Get(0).(func(string, ...interface{}) *sql.Rows); ok {
-		r0 = rf(query, args...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) *sql.Rows); ok {
+		r0 = rf(ctx, query, args...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sql.Rows)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, ...interface{}) error); ok {
-		r1 = rf(query, args...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(ctx, query, args...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,56 +126,57 @@ func (_m *Querier) Query(query string, args ...interface{}) (*sql.Rows, error) {
 	return r0, r1
 }
 
-// Querier_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
-type Querier_Query_Call struct {
+// Querier_QueryContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryContext'
+type Querier_QueryContext_Call struct {
 	*mock.Call
 }
 
-// Query is a helper method to define mock.On call
+// QueryContext is a helper method to define mock.On call
+//   - ctx context.Context
 //   - query string
 //   - args ...interface{}
-func (_e *Querier_Expecter) Query(query interface{}, args ...interface{}) *Querier_Query_Call {
-	return &Querier_Query_Call{Call: _e.mock.On("Query",
-		append([]interface{}{query}, args...)...)}
+func (_e *Querier_Expecter) QueryContext(ctx interface{}, query interface{}, args ...interface{}) *Querier_QueryContext_Call {
+	return &Querier_QueryContext_Call{Call: _e.mock.On("QueryContext",
+		append([]interface{}{ctx, query}, args...)...)}
 }
 
-func (_c *Querier_Query_Call) Run(run func(query string, args ...interface{})) *Querier_Query_Call {
+func (_c *Querier_QueryContext_Call) Run(run func(ctx context.Context, query string, args ...interface{})) *Querier_QueryContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]interface{}, len(args)-1)
-		for i, a := range args[1:] {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
 			if a != nil {
 				variadicArgs[i] = a.(interface{})
 			}
 		}
-		run(args[0].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Querier_Query_Call) Return(_a0 *sql.Rows, _a1 error) *Querier_Query_Call {
+func (_c *Querier_QueryContext_Call) Return(_a0 *sql.Rows, _a1 error) *Querier_QueryContext_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Querier_Query_Call) RunAndReturn(run func(string, ...interface{}) (*sql.Rows, error)) *Querier_Query_Call {
+func (_c *Querier_QueryContext_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (*sql.Rows, error)) *Querier_QueryContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryRow provides a mock function with given fields: query, args
-func (_m *Querier) QueryRow(query string, args ...interface{}) *sql.Row {
+// QueryRowContext provides a mock function with given fields: ctx, query, args
+func (_m *Querier) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	var _ca []interface{}
-	_ca = append(_ca, query)
+	_ca = append(_ca, ctx, query)
 	_ca = append(_ca, args...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryRow")
+		panic("no return value specified for QueryRowContext")
 	}
 
 	var r0 *sql.Row
-	if rf, ok := ret.Get(0).(func(string, ...interface{}) *sql.Row); ok {
-		r0 = rf(query, args...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) *sql.Row); ok {
+		r0 = rf(ctx, query, args...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sql.Row)
@@ -182,38 +186,39 @@ func (_m *Querier) QueryRow(query string, args ...interface{}) *sql.Row {
 	return r0
 }
 
-// Querier_QueryRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRow'
-type Querier_QueryRow_Call struct {
+// Querier_QueryRowContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRowContext'
+type Querier_QueryRowContext_Call struct {
 	*mock.Call
 }
 
-// QueryRow is a helper method to define mock.On call
+// QueryRowContext is a helper method to define mock.On call
+//   - ctx context.Context
 //   - query string
 //   - args ...interface{}
-func (_e *Querier_Expecter) QueryRow(query interface{}, args ...interface{}) *Querier_QueryRow_Call {
-	return &Querier_QueryRow_Call{Call: _e.mock.On("QueryRow",
-		append([]interface{}{query}, args...)...)}
+func (_e *Querier_Expecter) QueryRowContext(ctx interface{}, query interface{}, args ...interface{}) *Querier_QueryRowContext_Call {
+	return &Querier_QueryRowContext_Call{Call: _e.mock.On("QueryRowContext",
+		append([]interface{}{ctx, query}, args...)...)}
 }
 
-func (_c *Querier_QueryRow_Call) Run(run func(query string, args ...interface{})) *Querier_QueryRow_Call {
+func (_c *Querier_QueryRowContext_Call) Run(run func(ctx context.Context, query string, args ...interface{})) *Querier_QueryRowContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]interface{}, len(args)-1)
-		for i, a := range args[1:] {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
 			if a != nil {
 				variadicArgs[i] = a.(interface{})
 			}
 		}
-		run(args[0].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Querier_QueryRow_Call) Return(_a0 *sql.Row) *Querier_QueryRow_Call {
+func (_c *Querier_QueryRowContext_Call) Return(_a0 *sql.Row) *Querier_QueryRowContext_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Querier_QueryRow_Call) RunAndReturn(run func(string, ...interface{}) *sql.Row) *Querier_QueryRow_Call {
+func (_c *Querier_QueryRowContext_Call) RunAndReturn(run func(context.Context, string, ...interface{}) *sql.Row) *Querier_QueryRowContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
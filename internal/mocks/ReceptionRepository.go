@@ -1,13 +1,16 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	models "pvz/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
 
 	repositories "pvz/internal/repositories"
+
+	time "time"
 )
 
 // ReceptionRepository is an autogenerated mock type for the ReceptionRepository type
@@ -23,9 +26,70 @@ func (_m *ReceptionRepository) EXPECT() *ReceptionRepository_Expecter {
 	return &ReceptionRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: q, req
-func (_m *ReceptionRepository) Create(q repositories.Querier, req models.Reception) (*models.Reception, error) {
-	ret := _m.Called(q, req)
+// Close provides a mock function with given fields: ctx, q, pvzId, closedBy
+func (_m *ReceptionRepository) Close(ctx context.Context, q repositories.Querier, pvzId string, closedBy string) (*models.Reception, error) {
+	ret := _m.Called(ctx, q, pvzId, closedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 *models.Reception
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) (*models.Reception, error)); ok {
+		return rf(ctx, q, pvzId, closedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) *models.Reception); ok {
+		r0 = rf(ctx, q, pvzId, closedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Reception)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r1 = rf(ctx, q, pvzId, closedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionRepository_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type ReceptionRepository_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - pvzId string
+//   - closedBy string
+func (_e *ReceptionRepository_Expecter) Close(ctx interface{}, q interface{}, pvzId interface{}, closedBy interface{}) *ReceptionRepository_Close_Call {
+	return &ReceptionRepository_Close_Call{Call: _e.mock.On("Close", ctx, q, pvzId, closedBy)}
+}
+
+func (_c *ReceptionRepository_Close_Call) Run(run func(ctx context.Context, q repositories.Querier, pvzId string, closedBy string)) *ReceptionRepository_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ReceptionRepository_Close_Call) Return(_a0 *models.Reception, _a1 error) *ReceptionRepository_Close_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionRepository_Close_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) (*models.Reception, error)) *ReceptionRepository_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, q, req
+func (_m *ReceptionRepository) Create(ctx context.Context, q repositories.Querier, req models.Reception) (*models.Reception, error) {
+	ret := _m.Called(ctx, q, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -33,19 +97,19 @@ func (_m *ReceptionRepository) Create(q repositories.Querier, req models.Recepti
 
 	var r0 *models.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, models.Reception) (*models.Reception, error)); ok {
-		return rf(q, req)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.Reception) (*models.Reception, error)); ok {
+		return rf(ctx, q, req)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, models.Reception) *models.Reception); ok {
-		r0 = rf(q, req)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.Reception) *models.Reception); ok {
+		r0 = rf(ctx, q, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, models.Reception) error); ok {
-		r1 = rf(q, req)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, models.Reception) error); ok {
+		r1 = rf(ctx, q, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,15 +123,16 @@ type ReceptionRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - req models.Reception
-func (_e *ReceptionRepository_Expecter) Create(q interface{}, req interface{}) *ReceptionRepository_Create_Call {
-	return &ReceptionRepository_Create_Call{Call: _e.mock.On("Create", q, req)}
+func (_e *ReceptionRepository_Expecter) Create(ctx interface{}, q interface{}, req interface{}) *ReceptionRepository_Create_Call {
+	return &ReceptionRepository_Create_Call{Call: _e.mock.On("Create", ctx, q, req)}
 }
 
-func (_c *ReceptionRepository_Create_Call) Run(run func(q repositories.Querier, req models.Reception)) *ReceptionRepository_Create_Call {
+func (_c *ReceptionRepository_Create_Call) Run(run func(ctx context.Context, q repositories.Querier, req models.Reception)) *ReceptionRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(models.Reception))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.Reception))
 	})
 	return _c
 }
@@ -77,14 +142,14 @@ func (_c *ReceptionRepository_Create_Call) Return(_a0 *models.Reception, _a1 err
 	return _c
 }
 
-func (_c *ReceptionRepository_Create_Call) RunAndReturn(run func(repositories.Querier, models.Reception) (*models.Reception, error)) *ReceptionRepository_Create_Call {
+func (_c *ReceptionRepository_Create_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.Reception) (*models.Reception, error)) *ReceptionRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByPvzId provides a mock function with given fields: q, pvzId
-func (_m *ReceptionRepository) GetByPvzId(q repositories.Querier, pvzId string) (*models.Reception, error) {
-	ret := _m.Called(q, pvzId)
+// GetByPvzId provides a mock function with given fields: ctx, q, pvzId
+func (_m *ReceptionRepository) GetByPvzId(ctx context.Context, q repositories.Querier, pvzId string) (*models.Reception, error) {
+	ret := _m.Called(ctx, q, pvzId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByPvzId")
@@ -92,19 +157,19 @@ func (_m *ReceptionRepository) GetByPvzId(q repositories.Querier, pvzId string)
 
 	var r0 *models.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) (*models.Reception, error)); ok {
-		return rf(q, pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.Reception, error)); ok {
+		return rf(ctx, q, pvzId)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) *models.Reception); ok {
-		r0 = rf(q, pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.Reception); ok {
+		r0 = rf(ctx, q, pvzId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, string) error); ok {
-		r1 = rf(q, pvzId)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, pvzId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,15 +183,16 @@ type ReceptionRepository_GetByPvzId_Call struct {
 }
 
 // GetByPvzId is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - pvzId string
-func (_e *ReceptionRepository_Expecter) GetByPvzId(q interface{}, pvzId interface{}) *ReceptionRepository_GetByPvzId_Call {
-	return &ReceptionRepository_GetByPvzId_Call{Call: _e.mock.On("GetByPvzId", q, pvzId)}
+func (_e *ReceptionRepository_Expecter) GetByPvzId(ctx interface{}, q interface{}, pvzId interface{}) *ReceptionRepository_GetByPvzId_Call {
+	return &ReceptionRepository_GetByPvzId_Call{Call: _e.mock.On("GetByPvzId", ctx, q, pvzId)}
 }
 
-func (_c *ReceptionRepository_GetByPvzId_Call) Run(run func(q repositories.Querier, pvzId string)) *ReceptionRepository_GetByPvzId_Call {
+func (_c *ReceptionRepository_GetByPvzId_Call) Run(run func(ctx context.Context, q repositories.Querier, pvzId string)) *ReceptionRepository_GetByPvzId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(string))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
 	})
 	return _c
 }
@@ -136,34 +202,34 @@ func (_c *ReceptionRepository_GetByPvzId_Call) Return(_a0 *models.Reception, _a1
 	return _c
 }
 
-func (_c *ReceptionRepository_GetByPvzId_Call) RunAndReturn(run func(repositories.Querier, string) (*models.Reception, error)) *ReceptionRepository_GetByPvzId_Call {
+func (_c *ReceptionRepository_GetByPvzId_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.Reception, error)) *ReceptionRepository_GetByPvzId_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetStatus provides a mock function with given fields: q, status, pvzId
-func (_m *ReceptionRepository) SetStatus(q repositories.Querier, status string, pvzId string) (*models.Reception, error) {
-	ret := _m.Called(q, status, pvzId)
+// ListInProgressBefore provides a mock function with given fields: ctx, q, before
+func (_m *ReceptionRepository) ListInProgressBefore(ctx context.Context, q repositories.Querier, before time.Time) ([]models.Reception, error) {
+	ret := _m.Called(ctx, q, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetStatus")
+		panic("no return value specified for ListInProgressBefore")
 	}
 
-	var r0 *models.Reception
+	var r0 []models.Reception
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string, string) (*models.Reception, error)); ok {
-		return rf(q, status, pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, time.Time) ([]models.Reception, error)); ok {
+		return rf(ctx, q, before)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string, string) *models.Reception); ok {
-		r0 = rf(q, status, pvzId)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, time.Time) []models.Reception); ok {
+		r0 = rf(ctx, q, before)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Reception)
+			r0 = ret.Get(0).([]models.Reception)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, string, string) error); ok {
-		r1 = rf(q, status, pvzId)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, time.Time) error); ok {
+		r1 = rf(ctx, q, before)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -171,32 +237,32 @@ func (_m *ReceptionRepository) SetStatus(q repositories.Querier, status string,
 	return r0, r1
 }
 
-// ReceptionRepository_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
-type ReceptionRepository_SetStatus_Call struct {
+// ReceptionRepository_ListInProgressBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInProgressBefore'
+type ReceptionRepository_ListInProgressBefore_Call struct {
 	*mock.Call
 }
 
-// SetStatus is a helper method to define mock.On call
+// ListInProgressBefore is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
-//   - status string
-//   - pvzId string
-func (_e *ReceptionRepository_Expecter) SetStatus(q interface{}, status interface{}, pvzId interface{}) *ReceptionRepository_SetStatus_Call {
-	return &ReceptionRepository_SetStatus_Call{Call: _e.mock.On("SetStatus", q, status, pvzId)}
+//   - before time.Time
+func (_e *ReceptionRepository_Expecter) ListInProgressBefore(ctx interface{}, q interface{}, before interface{}) *ReceptionRepository_ListInProgressBefore_Call {
+	return &ReceptionRepository_ListInProgressBefore_Call{Call: _e.mock.On("ListInProgressBefore", ctx, q, before)}
 }
 
-func (_c *ReceptionRepository_SetStatus_Call) Run(run func(q repositories.Querier, status string, pvzId string)) *ReceptionRepository_SetStatus_Call {
+func (_c *ReceptionRepository_ListInProgressBefore_Call) Run(run func(ctx context.Context, q repositories.Querier, before time.Time)) *ReceptionRepository_ListInProgressBefore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *ReceptionRepository_SetStatus_Call) Return(_a0 *models.Reception, _a1 error) *ReceptionRepository_SetStatus_Call {
+func (_c *ReceptionRepository_ListInProgressBefore_Call) Return(_a0 []models.Reception, _a1 error) *ReceptionRepository_ListInProgressBefore_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ReceptionRepository_SetStatus_Call) RunAndReturn(run func(repositories.Querier, string, string) (*models.Reception, error)) *ReceptionRepository_SetStatus_Call {
+func (_c *ReceptionRepository_ListInProgressBefore_Call) RunAndReturn(run func(context.Context, repositories.Querier, time.Time) ([]models.Reception, error)) *ReceptionRepository_ListInProgressBefore_Call {
 	_c.Call.Return(run)
 	return _c
 }
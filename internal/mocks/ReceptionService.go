@@ -1,11 +1,16 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
-	reception "pvz/internal/models/reception"
+	context "context"
+	audit "pvz/internal/models/audit"
 
 	mock "github.com/stretchr/testify/mock"
+
+	reception "pvz/internal/models/reception"
+
+	time "time"
 )
 
 // ReceptionService is an autogenerated mock type for the ReceptionService type
@@ -21,9 +26,9 @@ func (_m *ReceptionService) EXPECT() *ReceptionService_Expecter {
 	return &ReceptionService_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: req
-func (_m *ReceptionService) Create(req reception.CreateRequest) (reception.CreateResponse, error) {
-	ret := _m.Called(req)
+// Create provides a mock function with given fields: ctx, meta, req
+func (_m *ReceptionService) Create(ctx context.Context, meta audit.Meta, req reception.CreateRequest) (reception.CreateResponse, error) {
+	ret := _m.Called(ctx, meta, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -31,17 +36,17 @@ func (_m *ReceptionService) Create(req reception.CreateRequest) (reception.Creat
 
 	var r0 reception.CreateResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(reception.CreateRequest) (reception.CreateResponse, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, reception.CreateRequest) (reception.CreateResponse, error)); ok {
+		return rf(ctx, meta, req)
 	}
-	if rf, ok := ret.Get(0).(func(reception.CreateRequest) reception.CreateResponse); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, reception.CreateRequest) reception.CreateResponse); ok {
+		r0 = rf(ctx, meta, req)
 	} else {
 		r0 = ret.Get(0).(reception.CreateResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(reception.CreateRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, reception.CreateRequest) error); ok {
+		r1 = rf(ctx, meta, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -55,14 +60,16 @@ type ReceptionService_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
 //   - req reception.CreateRequest
-func (_e *ReceptionService_Expecter) Create(req interface{}) *ReceptionService_Create_Call {
-	return &ReceptionService_Create_Call{Call: _e.mock.On("Create", req)}
+func (_e *ReceptionService_Expecter) Create(ctx interface{}, meta interface{}, req interface{}) *ReceptionService_Create_Call {
+	return &ReceptionService_Create_Call{Call: _e.mock.On("Create", ctx, meta, req)}
 }
 
-func (_c *ReceptionService_Create_Call) Run(run func(req reception.CreateRequest)) *ReceptionService_Create_Call {
+func (_c *ReceptionService_Create_Call) Run(run func(ctx context.Context, meta audit.Meta, req reception.CreateRequest)) *ReceptionService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(reception.CreateRequest))
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(reception.CreateRequest))
 	})
 	return _c
 }
@@ -72,7 +79,123 @@ func (_c *ReceptionService_Create_Call) Return(_a0 reception.CreateResponse, _a1
 	return _c
 }
 
-func (_c *ReceptionService_Create_Call) RunAndReturn(run func(reception.CreateRequest) (reception.CreateResponse, error)) *ReceptionService_Create_Call {
+func (_c *ReceptionService_Create_Call) RunAndReturn(run func(context.Context, audit.Meta, reception.CreateRequest) (reception.CreateResponse, error)) *ReceptionService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCurrent provides a mock function with given fields: ctx, pvzId
+func (_m *ReceptionService) GetCurrent(ctx context.Context, pvzId string) (reception.CurrentResponse, error) {
+	ret := _m.Called(ctx, pvzId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrent")
+	}
+
+	var r0 reception.CurrentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (reception.CurrentResponse, error)); ok {
+		return rf(ctx, pvzId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) reception.CurrentResponse); ok {
+		r0 = rf(ctx, pvzId)
+	} else {
+		r0 = ret.Get(0).(reception.CurrentResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pvzId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionService_GetCurrent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrent'
+type ReceptionService_GetCurrent_Call struct {
+	*mock.Call
+}
+
+// GetCurrent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pvzId string
+func (_e *ReceptionService_Expecter) GetCurrent(ctx interface{}, pvzId interface{}) *ReceptionService_GetCurrent_Call {
+	return &ReceptionService_GetCurrent_Call{Call: _e.mock.On("GetCurrent", ctx, pvzId)}
+}
+
+func (_c *ReceptionService_GetCurrent_Call) Run(run func(ctx context.Context, pvzId string)) *ReceptionService_GetCurrent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ReceptionService_GetCurrent_Call) Return(_a0 reception.CurrentResponse, _a1 error) *ReceptionService_GetCurrent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionService_GetCurrent_Call) RunAndReturn(run func(context.Context, string) (reception.CurrentResponse, error)) *ReceptionService_GetCurrent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStuck provides a mock function with given fields: ctx, olderThan
+func (_m *ReceptionService) ListStuck(ctx context.Context, olderThan time.Duration) ([]reception.CreateResponse, error) {
+	ret := _m.Called(ctx, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStuck")
+	}
+
+	var r0 []reception.CreateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) ([]reception.CreateResponse, error)); ok {
+		return rf(ctx, olderThan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []reception.CreateResponse); ok {
+		r0 = rf(ctx, olderThan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reception.CreateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceptionService_ListStuck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStuck'
+type ReceptionService_ListStuck_Call struct {
+	*mock.Call
+}
+
+// ListStuck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - olderThan time.Duration
+func (_e *ReceptionService_Expecter) ListStuck(ctx interface{}, olderThan interface{}) *ReceptionService_ListStuck_Call {
+	return &ReceptionService_ListStuck_Call{Call: _e.mock.On("ListStuck", ctx, olderThan)}
+}
+
+func (_c *ReceptionService_ListStuck_Call) Run(run func(ctx context.Context, olderThan time.Duration)) *ReceptionService_ListStuck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *ReceptionService_ListStuck_Call) Return(_a0 []reception.CreateResponse, _a1 error) *ReceptionService_ListStuck_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceptionService_ListStuck_Call) RunAndReturn(run func(context.Context, time.Duration) ([]reception.CreateResponse, error)) *ReceptionService_ListStuck_Call {
 	_c.Call.Return(run)
 	return _c
 }
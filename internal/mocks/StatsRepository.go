@@ -0,0 +1,103 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pvz/internal/repositories"
+
+	time "time"
+)
+
+// StatsRepository is an autogenerated mock type for the StatsRepository type
+type StatsRepository struct {
+	mock.Mock
+}
+
+type StatsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StatsRepository) EXPECT() *StatsRepository_Expecter {
+	return &StatsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Aggregate provides a mock function with given fields: ctx, q, groupBy, startDate, endDate
+func (_m *StatsRepository) Aggregate(ctx context.Context, q repositories.Querier, groupBy []string, startDate *time.Time, endDate *time.Time) ([]models.StatsRow, error) {
+	ret := _m.Called(ctx, q, groupBy, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 []models.StatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, []string, *time.Time, *time.Time) ([]models.StatsRow, error)); ok {
+		return rf(ctx, q, groupBy, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, []string, *time.Time, *time.Time) []models.StatsRow); ok {
+		r0 = rf(ctx, q, groupBy, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StatsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, []string, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, q, groupBy, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StatsRepository_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type StatsRepository_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - groupBy []string
+//   - startDate *time.Time
+//   - endDate *time.Time
+func (_e *StatsRepository_Expecter) Aggregate(ctx interface{}, q interface{}, groupBy interface{}, startDate interface{}, endDate interface{}) *StatsRepository_Aggregate_Call {
+	return &StatsRepository_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, q, groupBy, startDate, endDate)}
+}
+
+func (_c *StatsRepository_Aggregate_Call) Run(run func(ctx context.Context, q repositories.Querier, groupBy []string, startDate *time.Time, endDate *time.Time)) *StatsRepository_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].([]string), args[3].(*time.Time), args[4].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *StatsRepository_Aggregate_Call) Return(_a0 []models.StatsRow, _a1 error) *StatsRepository_Aggregate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StatsRepository_Aggregate_Call) RunAndReturn(run func(context.Context, repositories.Querier, []string, *time.Time, *time.Time) ([]models.StatsRow, error)) *StatsRepository_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStatsRepository creates a new instance of StatsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatsRepository {
+	mock := &StatsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
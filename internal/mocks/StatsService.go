@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	stats "pvz/internal/models/stats"
+)
+
+// StatsService is an autogenerated mock type for the StatsService type
+type StatsService struct {
+	mock.Mock
+}
+
+type StatsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StatsService) EXPECT() *StatsService_Expecter {
+	return &StatsService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, req
+func (_m *StatsService) Get(ctx context.Context, req stats.Request) (stats.Response, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 stats.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, stats.Request) (stats.Response, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, stats.Request) stats.Response); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(stats.Response)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, stats.Request) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StatsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type StatsService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req stats.Request
+func (_e *StatsService_Expecter) Get(ctx interface{}, req interface{}) *StatsService_Get_Call {
+	return &StatsService_Get_Call{Call: _e.mock.On("Get", ctx, req)}
+}
+
+func (_c *StatsService_Get_Call) Run(run func(ctx context.Context, req stats.Request)) *StatsService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(stats.Request))
+	})
+	return _c
+}
+
+func (_c *StatsService_Get_Call) Return(_a0 stats.Response, _a1 error) *StatsService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StatsService_Get_Call) RunAndReturn(run func(context.Context, stats.Request) (stats.Response, error)) *StatsService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStatsService creates a new instance of StatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatsService {
+	mock := &StatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,13 +1,16 @@
-// Code generated by mockery v2.53.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
 	models "pvz/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
 
 	repositories "pvz/internal/repositories"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -23,9 +26,58 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: q, user
-func (_m *UserRepository) Create(q repositories.Querier, user models.User) (string, error) {
-	ret := _m.Called(q, user)
+// AssignPvzs provides a mock function with given fields: ctx, q, userId, pvzIds
+func (_m *UserRepository) AssignPvzs(ctx context.Context, q repositories.Querier, userId string, pvzIds []string) error {
+	ret := _m.Called(ctx, q, userId, pvzIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignPvzs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, []string) error); ok {
+		r0 = rf(ctx, q, userId, pvzIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_AssignPvzs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignPvzs'
+type UserRepository_AssignPvzs_Call struct {
+	*mock.Call
+}
+
+// AssignPvzs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - userId string
+//   - pvzIds []string
+func (_e *UserRepository_Expecter) AssignPvzs(ctx interface{}, q interface{}, userId interface{}, pvzIds interface{}) *UserRepository_AssignPvzs_Call {
+	return &UserRepository_AssignPvzs_Call{Call: _e.mock.On("AssignPvzs", ctx, q, userId, pvzIds)}
+}
+
+func (_c *UserRepository_AssignPvzs_Call) Run(run func(ctx context.Context, q repositories.Querier, userId string, pvzIds []string)) *UserRepository_AssignPvzs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_AssignPvzs_Call) Return(_a0 error) *UserRepository_AssignPvzs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_AssignPvzs_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, []string) error) *UserRepository_AssignPvzs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, q, user
+func (_m *UserRepository) Create(ctx context.Context, q repositories.Querier, user models.User) (string, error) {
+	ret := _m.Called(ctx, q, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -33,17 +85,17 @@ func (_m *UserRepository) Create(q repositories.Querier, user models.User) (stri
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, models.User) (string, error)); ok {
-		return rf(q, user)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.User) (string, error)); ok {
+		return rf(ctx, q, user)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, models.User) string); ok {
-		r0 = rf(q, user)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, models.User) string); ok {
+		r0 = rf(ctx, q, user)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, models.User) error); ok {
-		r1 = rf(q, user)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, models.User) error); ok {
+		r1 = rf(ctx, q, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,15 +109,16 @@ type UserRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - user models.User
-func (_e *UserRepository_Expecter) Create(q interface{}, user interface{}) *UserRepository_Create_Call {
-	return &UserRepository_Create_Call{Call: _e.mock.On("Create", q, user)}
+func (_e *UserRepository_Expecter) Create(ctx interface{}, q interface{}, user interface{}) *UserRepository_Create_Call {
+	return &UserRepository_Create_Call{Call: _e.mock.On("Create", ctx, q, user)}
 }
 
-func (_c *UserRepository_Create_Call) Run(run func(q repositories.Querier, user models.User)) *UserRepository_Create_Call {
+func (_c *UserRepository_Create_Call) Run(run func(ctx context.Context, q repositories.Querier, user models.User)) *UserRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(models.User))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(models.User))
 	})
 	return _c
 }
@@ -75,14 +128,14 @@ func (_c *UserRepository_Create_Call) Return(_a0 string, _a1 error) *UserReposit
 	return _c
 }
 
-func (_c *UserRepository_Create_Call) RunAndReturn(run func(repositories.Querier, models.User) (string, error)) *UserRepository_Create_Call {
+func (_c *UserRepository_Create_Call) RunAndReturn(run func(context.Context, repositories.Querier, models.User) (string, error)) *UserRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByEmail provides a mock function with given fields: q, email
-func (_m *UserRepository) GetByEmail(q repositories.Querier, email string) (*models.User, error) {
-	ret := _m.Called(q, email)
+// GetByEmail provides a mock function with given fields: ctx, q, email
+func (_m *UserRepository) GetByEmail(ctx context.Context, q repositories.Querier, email string) (*models.User, error) {
+	ret := _m.Called(ctx, q, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByEmail")
@@ -90,19 +143,19 @@ func (_m *UserRepository) GetByEmail(q repositories.Querier, email string) (*mod
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) (*models.User, error)); ok {
-		return rf(q, email)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.User, error)); ok {
+		return rf(ctx, q, email)
 	}
-	if rf, ok := ret.Get(0).(func(repositories.Querier, string) *models.User); ok {
-		r0 = rf(q, email)
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.User); ok {
+		r0 = rf(ctx, q, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(repositories.Querier, string) error); ok {
-		r1 = rf(q, email)
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -116,15 +169,16 @@ type UserRepository_GetByEmail_Call struct {
 }
 
 // GetByEmail is a helper method to define mock.On call
+//   - ctx context.Context
 //   - q repositories.Querier
 //   - email string
-func (_e *UserRepository_Expecter) GetByEmail(q interface{}, email interface{}) *UserRepository_GetByEmail_Call {
-	return &UserRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", q, email)}
+func (_e *UserRepository_Expecter) GetByEmail(ctx interface{}, q interface{}, email interface{}) *UserRepository_GetByEmail_Call {
+	return &UserRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, q, email)}
 }
 
-func (_c *UserRepository_GetByEmail_Call) Run(run func(q repositories.Querier, email string)) *UserRepository_GetByEmail_Call {
+func (_c *UserRepository_GetByEmail_Call) Run(run func(ctx context.Context, q repositories.Querier, email string)) *UserRepository_GetByEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(repositories.Querier), args[1].(string))
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
 	})
 	return _c
 }
@@ -134,7 +188,333 @@ func (_c *UserRepository_GetByEmail_Call) Return(_a0 *models.User, _a1 error) *U
 	return _c
 }
 
-func (_c *UserRepository_GetByEmail_Call) RunAndReturn(run func(repositories.Querier, string) (*models.User, error)) *UserRepository_GetByEmail_Call {
+func (_c *UserRepository_GetByEmail_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.User, error)) *UserRepository_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: ctx, q, id
+func (_m *UserRepository) GetById(ctx context.Context, q repositories.Querier, id string) (*models.User, error) {
+	ret := _m.Called(ctx, q, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) (*models.User, error)); ok {
+		return rf(ctx, q, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) *models.User); ok {
+		r0 = rf(ctx, q, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type UserRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - id string
+func (_e *UserRepository_Expecter) GetById(ctx interface{}, q interface{}, id interface{}) *UserRepository_GetById_Call {
+	return &UserRepository_GetById_Call{Call: _e.mock.On("GetById", ctx, q, id)}
+}
+
+func (_c *UserRepository_GetById_Call) Run(run func(ctx context.Context, q repositories.Querier, id string)) *UserRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetById_Call) Return(_a0 *models.User, _a1 error) *UserRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_GetById_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) (*models.User, error)) *UserRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, q
+func (_m *UserRepository) List(ctx context.Context, q repositories.Querier) ([]models.User, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier) ([]models.User, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier) []models.User); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type UserRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+func (_e *UserRepository_Expecter) List(ctx interface{}, q interface{}) *UserRepository_List_Call {
+	return &UserRepository_List_Call{Call: _e.mock.On("List", ctx, q)}
+}
+
+func (_c *UserRepository_List_Call) Run(run func(ctx context.Context, q repositories.Querier)) *UserRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier))
+	})
+	return _c
+}
+
+func (_c *UserRepository_List_Call) Return(_a0 []models.User, _a1 error) *UserRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_List_Call) RunAndReturn(run func(context.Context, repositories.Querier) ([]models.User, error)) *UserRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPvzIds provides a mock function with given fields: ctx, q, userId
+func (_m *UserRepository) ListPvzIds(ctx context.Context, q repositories.Querier, userId string) ([]string, error) {
+	ret := _m.Called(ctx, q, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPvzIds")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) ([]string, error)); ok {
+		return rf(ctx, q, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string) []string); ok {
+		r0 = rf(ctx, q, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.Querier, string) error); ok {
+		r1 = rf(ctx, q, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_ListPvzIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPvzIds'
+type UserRepository_ListPvzIds_Call struct {
+	*mock.Call
+}
+
+// ListPvzIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - userId string
+func (_e *UserRepository_Expecter) ListPvzIds(ctx interface{}, q interface{}, userId interface{}) *UserRepository_ListPvzIds_Call {
+	return &UserRepository_ListPvzIds_Call{Call: _e.mock.On("ListPvzIds", ctx, q, userId)}
+}
+
+func (_c *UserRepository_ListPvzIds_Call) Run(run func(ctx context.Context, q repositories.Querier, userId string)) *UserRepository_ListPvzIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_ListPvzIds_Call) Return(_a0 []string, _a1 error) *UserRepository_ListPvzIds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_ListPvzIds_Call) RunAndReturn(run func(context.Context, repositories.Querier, string) ([]string, error)) *UserRepository_ListPvzIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, q, userId, validAfter
+func (_m *UserRepository) RevokeSessions(ctx context.Context, q repositories.Querier, userId string, validAfter time.Time) error {
+	ret := _m.Called(ctx, q, userId, validAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, time.Time) error); ok {
+		r0 = rf(ctx, q, userId, validAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_RevokeSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessions'
+type UserRepository_RevokeSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - userId string
+//   - validAfter time.Time
+func (_e *UserRepository_Expecter) RevokeSessions(ctx interface{}, q interface{}, userId interface{}, validAfter interface{}) *UserRepository_RevokeSessions_Call {
+	return &UserRepository_RevokeSessions_Call{Call: _e.mock.On("RevokeSessions", ctx, q, userId, validAfter)}
+}
+
+func (_c *UserRepository_RevokeSessions_Call) Run(run func(ctx context.Context, q repositories.Querier, userId string, validAfter time.Time)) *UserRepository_RevokeSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepository_RevokeSessions_Call) Return(_a0 error) *UserRepository_RevokeSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_RevokeSessions_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, time.Time) error) *UserRepository_RevokeSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLanguage provides a mock function with given fields: ctx, q, userId, language
+func (_m *UserRepository) SetLanguage(ctx context.Context, q repositories.Querier, userId string, language string) error {
+	ret := _m.Called(ctx, q, userId, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r0 = rf(ctx, q, userId, language)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_SetLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLanguage'
+type UserRepository_SetLanguage_Call struct {
+	*mock.Call
+}
+
+// SetLanguage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - userId string
+//   - language string
+func (_e *UserRepository_Expecter) SetLanguage(ctx interface{}, q interface{}, userId interface{}, language interface{}) *UserRepository_SetLanguage_Call {
+	return &UserRepository_SetLanguage_Call{Call: _e.mock.On("SetLanguage", ctx, q, userId, language)}
+}
+
+func (_c *UserRepository_SetLanguage_Call) Run(run func(ctx context.Context, q repositories.Querier, userId string, language string)) *UserRepository_SetLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_SetLanguage_Call) Return(_a0 error) *UserRepository_SetLanguage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_SetLanguage_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) error) *UserRepository_SetLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePasswordHash provides a mock function with given fields: ctx, q, userId, passwordHash
+func (_m *UserRepository) UpdatePasswordHash(ctx context.Context, q repositories.Querier, userId string, passwordHash string) error {
+	ret := _m.Called(ctx, q, userId, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.Querier, string, string) error); ok {
+		r0 = rf(ctx, q, userId, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepository_UpdatePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePasswordHash'
+type UserRepository_UpdatePasswordHash_Call struct {
+	*mock.Call
+}
+
+// UpdatePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q repositories.Querier
+//   - userId string
+//   - passwordHash string
+func (_e *UserRepository_Expecter) UpdatePasswordHash(ctx interface{}, q interface{}, userId interface{}, passwordHash interface{}) *UserRepository_UpdatePasswordHash_Call {
+	return &UserRepository_UpdatePasswordHash_Call{Call: _e.mock.On("UpdatePasswordHash", ctx, q, userId, passwordHash)}
+}
+
+func (_c *UserRepository_UpdatePasswordHash_Call) Run(run func(ctx context.Context, q repositories.Querier, userId string, passwordHash string)) *UserRepository_UpdatePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repositories.Querier), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdatePasswordHash_Call) Return(_a0 error) *UserRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepository_UpdatePasswordHash_Call) RunAndReturn(run func(context.Context, repositories.Querier, string, string) error) *UserRepository_UpdatePasswordHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,260 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	audit "pvz/internal/models/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "pvz/internal/models/user"
+)
+
+// UserService is an autogenerated mock type for the UserService type
+type UserService struct {
+	mock.Mock
+}
+
+type UserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserService) EXPECT() *UserService_Expecter {
+	return &UserService_Expecter{mock: &_m.Mock}
+}
+
+// AssignPvzs provides a mock function with given fields: ctx, meta, req
+func (_m *UserService) AssignPvzs(ctx context.Context, meta audit.Meta, req user.AssignPvzsRequest) (user.Response, error) {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignPvzs")
+	}
+
+	var r0 user.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, user.AssignPvzsRequest) (user.Response, error)); ok {
+		return rf(ctx, meta, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, user.AssignPvzsRequest) user.Response); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Get(0).(user.Response)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Meta, user.AssignPvzsRequest) error); ok {
+		r1 = rf(ctx, meta, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_AssignPvzs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignPvzs'
+type UserService_AssignPvzs_Call struct {
+	*mock.Call
+}
+
+// AssignPvzs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req user.AssignPvzsRequest
+func (_e *UserService_Expecter) AssignPvzs(ctx interface{}, meta interface{}, req interface{}) *UserService_AssignPvzs_Call {
+	return &UserService_AssignPvzs_Call{Call: _e.mock.On("AssignPvzs", ctx, meta, req)}
+}
+
+func (_c *UserService_AssignPvzs_Call) Run(run func(ctx context.Context, meta audit.Meta, req user.AssignPvzsRequest)) *UserService_AssignPvzs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(user.AssignPvzsRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_AssignPvzs_Call) Return(_a0 user.Response, _a1 error) *UserService_AssignPvzs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_AssignPvzs_Call) RunAndReturn(run func(context.Context, audit.Meta, user.AssignPvzsRequest) (user.Response, error)) *UserService_AssignPvzs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *UserService) GetByEmail(ctx context.Context, email string) (user.Response, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 user.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.Response, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.Response); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(user.Response)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type UserService_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *UserService_Expecter) GetByEmail(ctx interface{}, email interface{}) *UserService_GetByEmail_Call {
+	return &UserService_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
+}
+
+func (_c *UserService_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *UserService_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_GetByEmail_Call) Return(_a0 user.Response, _a1 error) *UserService_GetByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_GetByEmail_Call) RunAndReturn(run func(context.Context, string) (user.Response, error)) *UserService_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *UserService) List(ctx context.Context) ([]user.Response, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []user.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.Response, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.Response); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type UserService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserService_Expecter) List(ctx interface{}) *UserService_List_Call {
+	return &UserService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *UserService_List_Call) Run(run func(ctx context.Context)) *UserService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserService_List_Call) Return(_a0 []user.Response, _a1 error) *UserService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_List_Call) RunAndReturn(run func(context.Context) ([]user.Response, error)) *UserService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPassword provides a mock function with given fields: ctx, meta, req
+func (_m *UserService) SetPassword(ctx context.Context, meta audit.Meta, req user.SetPasswordRequest) error {
+	ret := _m.Called(ctx, meta, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Meta, user.SetPasswordRequest) error); ok {
+		r0 = rf(ctx, meta, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_SetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPassword'
+type UserService_SetPassword_Call struct {
+	*mock.Call
+}
+
+// SetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta audit.Meta
+//   - req user.SetPasswordRequest
+func (_e *UserService_Expecter) SetPassword(ctx interface{}, meta interface{}, req interface{}) *UserService_SetPassword_Call {
+	return &UserService_SetPassword_Call{Call: _e.mock.On("SetPassword", ctx, meta, req)}
+}
+
+func (_c *UserService_SetPassword_Call) Run(run func(ctx context.Context, meta audit.Meta, req user.SetPasswordRequest)) *UserService_SetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Meta), args[2].(user.SetPasswordRequest))
+	})
+	return _c
+}
+
+func (_c *UserService_SetPassword_Call) Return(_a0 error) *UserService_SetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_SetPassword_Call) RunAndReturn(run func(context.Context, audit.Meta, user.SetPasswordRequest) error) *UserService_SetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserService {
+	mock := &UserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
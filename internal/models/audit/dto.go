@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"encoding/json"
+	"pvz/internal/models/auth"
+	"time"
+)
+
+type Meta struct {
+	Actor     auth.Principal
+	RequestId string
+	Ip        string
+}
+
+type ListRequest struct {
+	ActorId   *string    `json:"actorId" validate:"omitempty,uuid"`
+	PvzId     *string    `json:"pvzId" validate:"omitempty,uuid"`
+	StartDate *time.Time `json:"startDate" validate:"omitempty"`
+	EndDate   *time.Time `json:"endDate" validate:"omitempty"`
+	Page      int        `json:"page" validate:"min=1" default:"1"`
+	Limit     int        `json:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type Record struct {
+	Id        int64             `json:"id"`
+	DateTime  time.Time         `json:"dateTime"`
+	ActorId   *string           `json:"actorId"`
+	ActorRole *string           `json:"actorRole"`
+	Action    Action            `json:"action"`
+	PvzId     *string           `json:"pvzId"`
+	TargetIds map[string]string `json:"targetIds"`
+	RequestId *string           `json:"requestId"`
+	Ip        *string           `json:"ip"`
+	Before    json.RawMessage   `json:"before"`
+	After     json.RawMessage   `json:"after"`
+}
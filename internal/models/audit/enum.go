@@ -0,0 +1,22 @@
+package audit
+
+type Action string
+
+const (
+	UserRegister       Action = "user.register"
+	UserCreate         Action = "user.create"
+	UserPasswordSet    Action = "user.password_set"
+	UserPasswordChange Action = "user.password_change"
+	UserPasswordReset  Action = "user.password_reset"
+	UserPvzsAssign     Action = "user.pvzs_assign"
+	UserLanguageSet    Action = "user.language_set"
+	InvitationCreate   Action = "invitation.create"
+	InvitationAccept   Action = "invitation.accept"
+	PvzCreate          Action = "pvz.create"
+	PvzImport          Action = "pvz.import"
+	PvzUpdate          Action = "pvz.update"
+	ReceptionOpen      Action = "reception.open"
+	ReceptionClose     Action = "reception.close"
+	ProductAdd         Action = "product.add"
+	ProductDeleteOne   Action = "product.delete_last"
+)
@@ -1,5 +1,16 @@
 package auth
 
+import "time"
+
+type Principal struct {
+	UserId   string
+	Role     Role
+	TokenId  string
+	PvzIds   []string
+	IssuedAt time.Time
+	Language string
+}
+
 type DummyLoginRequest struct {
 	Role string `json:"role" validate:"required,oneof=moderator employee"`
 }
@@ -12,7 +23,7 @@ type LoginRequest struct {
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
-	Role     string `json:"role" validate:"required,oneof=moderator employee"`
+	Role     string `json:"role" validate:"omitempty,oneof=moderator employee"`
 }
 
 type RegisterResponse struct {
@@ -20,3 +31,21 @@ type RegisterResponse struct {
 	Email string `json:"email" validate:"required"`
 	Role  string `json:"role" validate:"required"`
 }
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
+
+type ResetPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
+
+type SetLanguageRequest struct {
+	Language string `json:"language" validate:"required,oneof=ru en"`
+}
@@ -1,21 +1,34 @@
 package models
 
 import (
+	"database/sql"
 	"pvz/internal/models/auth"
 	"time"
 )
 
 type User struct {
-	Id           string
-	Email        string
-	PasswordHash string
-	Role         auth.Role
+	Id                 string
+	Email              string
+	PasswordHash       string
+	Role               auth.Role
+	SessionsValidAfter sql.NullTime
+	Language           sql.NullString
 }
 
 type Pvz struct {
 	Id               string
 	RegistrationDate time.Time
 	City             string
+	Address          sql.NullString
+	Latitude         sql.NullFloat64
+	Longitude        sql.NullFloat64
+	WorkingHours     sql.NullString
+	Phone            sql.NullString
+}
+
+type PvzDistance struct {
+	Pvz
+	DistanceMeters float64
 }
 
 type Reception struct {
@@ -23,6 +36,9 @@ type Reception struct {
 	DateTime time.Time
 	PvzId    string
 	Status   string
+	OpenedBy sql.NullString
+	ClosedBy sql.NullString
+	ClosedAt sql.NullTime
 }
 
 type Product struct {
@@ -30,9 +46,76 @@ type Product struct {
 	DateTime    time.Time
 	Type        string
 	ReceptionId string
+	AcceptedBy  sql.NullString
 }
 
 type PvzReception struct {
 	PvzId       string
 	ReceptionId string
 }
+
+type AuditEntry struct {
+	Id        int64
+	DateTime  time.Time
+	ActorId   sql.NullString
+	ActorRole sql.NullString
+	Action    string
+	PvzId     sql.NullString
+	TargetIds []byte
+	RequestId sql.NullString
+	Ip        sql.NullString
+	Before    []byte
+	After     []byte
+}
+
+type Invitation struct {
+	Id         string
+	Email      string
+	Role       auth.Role
+	PvzIds     []string
+	TokenHash  string
+	CreatedBy  sql.NullString
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt sql.NullTime
+	AcceptedBy sql.NullString
+}
+
+type PasswordReset struct {
+	Id        string
+	UserId    string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type LoginAttempt struct {
+	Kind          string
+	Subject       string
+	Failures      int
+	LastFailureAt time.Time
+	BlockedUntil  sql.NullTime
+}
+
+type IdempotencyKey struct {
+	Caller       string
+	Key          string
+	RequestHash  string
+	Status       sql.NullInt32
+	ContentType  sql.NullString
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+type StatsRow struct {
+	PvzId          sql.NullString
+	City           sql.NullString
+	Type           sql.NullString
+	Day            sql.NullTime
+	Products       int
+	Receptions     int
+	OpenReceptions int
+	AvgDuration    sql.NullFloat64
+}
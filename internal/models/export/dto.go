@@ -0,0 +1,32 @@
+package export
+
+import (
+	"pvz/internal/models/pvz"
+	"time"
+)
+
+const (
+	FormatCsv    = "csv"
+	FormatNdjson = "ndjson"
+	FormatXlsx   = "xlsx"
+)
+
+const (
+	LayoutFlat   = "flat"
+	LayoutNested = "nested"
+)
+
+type Request struct {
+	StartDate *time.Time `json:"startDate" validate:"required"`
+	EndDate   *time.Time `json:"endDate" validate:"required"`
+	Format    string     `json:"format" validate:"required,oneof=csv ndjson xlsx"`
+	Layout    string     `json:"layout" validate:"required,oneof=flat nested"`
+}
+
+// Row is one product of a reception together with its pvz. Product is nil for a reception without products.
+// Rows come ordered by pvz, then reception, then product.
+type Row struct {
+	Pvz       pvz.Pvz
+	Reception pvz.Reception
+	Product   *pvz.Product
+}
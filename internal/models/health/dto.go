@@ -0,0 +1,46 @@
+package health
+
+import "time"
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// ErrorUnavailable is all a failed dependency check tells the caller; the cause is logged.
+const ErrorUnavailable = "unavailable"
+
+type Check struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string           `json:"status"`
+	Checks map[string]Check `json:"checks"`
+}
+
+type DetailedReport struct {
+	Report
+	Build Build   `json:"build"`
+	Pool  DBStats `json:"pool"`
+}
+
+type Build struct {
+	Version   string    `json:"version"`
+	Revision  string    `json:"revision,omitempty"`
+	GoVersion string    `json:"goVersion"`
+	StartedAt time.Time `json:"startedAt"`
+	Uptime    string    `json:"uptime"`
+}
+
+type DBStats struct {
+	MaxOpenConnections int    `json:"maxOpenConnections"`
+	OpenConnections    int    `json:"openConnections"`
+	InUse              int    `json:"inUse"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"waitCount"`
+	WaitDuration       string `json:"waitDuration"`
+	MaxIdleClosed      int64  `json:"maxIdleClosed"`
+	MaxLifetimeClosed  int64  `json:"maxLifetimeClosed"`
+}
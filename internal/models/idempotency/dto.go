@@ -0,0 +1,8 @@
+package idempotency
+
+// Response is what gets replayed to a client repeating a request with the same key.
+type Response struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
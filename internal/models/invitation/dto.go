@@ -0,0 +1,23 @@
+package invitation
+
+import "time"
+
+type CreateRequest struct {
+	Email  string   `json:"email" validate:"required,email"`
+	Role   string   `json:"role" validate:"required,oneof=moderator employee"`
+	PvzIds []string `json:"pvzIds" validate:"omitempty,dive,uuid"`
+}
+
+type CreateResponse struct {
+	Id        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	PvzIds    []string  `json:"pvzIds"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// DeliveryFailed is set when the invitation was created but its mail could not be sent
+	DeliveryFailed bool `json:"deliveryFailed,omitempty"`
+}
+
+type AcceptRequest struct {
+	Password string `json:"password" validate:"required,min=8"`
+}
@@ -12,4 +12,5 @@ type AddInReceptionResponse struct {
 	DateTime    time.Time `json:"dateTime"`
 	Type        string    `json:"type"`
 	ReceptionId string    `json:"receptionId"`
+	AcceptedBy  *string   `json:"acceptedBy"`
 }
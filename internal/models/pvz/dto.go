@@ -7,14 +7,46 @@ import (
 
 type CreateRequest struct {
 	Id               *string    `json:"id" validate:"omitempty,uuid"`
-	RegistrationDate *time.Time `json:"registrationDate" validate:"omitempty"`
+	RegistrationDate *time.Time `json:"registrationDate" validate:"omitempty,notfuture"`
 	City             string     `json:"city" validate:"required,oneof=Москва Санкт-Петербург Казань"`
+	Address          *string    `json:"address" validate:"omitempty,max=255"`
+	Latitude         *float64   `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude        *float64   `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	WorkingHours     *string    `json:"workingHours" validate:"omitempty,max=100"`
+	Phone            *string    `json:"phone" validate:"omitempty,phone"`
 }
 
 type CreateResponse struct {
 	Id               string    `json:"id"`
 	RegistrationDate time.Time `json:"registrationDate"`
 	City             string    `json:"city"`
+	Address          *string   `json:"address,omitempty"`
+	Latitude         *float64  `json:"latitude,omitempty"`
+	Longitude        *float64  `json:"longitude,omitempty"`
+	WorkingHours     *string   `json:"workingHours,omitempty"`
+	Phone            *string   `json:"phone,omitempty"`
+}
+
+// UpdateRequest replaces the properties of a pvz; the ones left out are cleared.
+type UpdateRequest struct {
+	City         string   `json:"city" validate:"required,oneof=Москва Санкт-Петербург Казань"`
+	Address      *string  `json:"address" validate:"omitempty,max=255"`
+	Latitude     *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude    *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	WorkingHours *string  `json:"workingHours" validate:"omitempty,max=100"`
+	Phone        *string  `json:"phone" validate:"omitempty,phone"`
+}
+
+type NearbyRequest struct {
+	Latitude     *float64 `json:"lat" validate:"required,min=-90,max=90"`
+	Longitude    *float64 `json:"lon" validate:"required,min=-180,max=180"`
+	RadiusMeters float64  `json:"radius" validate:"gt=0,max=100000" default:"5000"`
+	Limit        int      `json:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type NearbyResponse struct {
+	Pvz            Pvz     `json:"pvz"`
+	DistanceMeters float64 `json:"distanceMeters"`
 }
 
 type DeleteLastProductRequest struct {
@@ -26,10 +58,13 @@ type DeleteLastProductResponse struct {
 }
 
 type CloseLastProductResponse struct {
-	Id       string    `json:"id"`
-	DateTime time.Time `json:"dateTime"`
-	PvzId    string    `json:"pvzId"`
-	Status   string    `json:"status"`
+	Id       string     `json:"id"`
+	DateTime time.Time  `json:"dateTime"`
+	PvzId    string     `json:"pvzId"`
+	Status   string     `json:"status"`
+	OpenedBy *string    `json:"openedBy"`
+	ClosedBy *string    `json:"closedBy"`
+	ClosedAt *time.Time `json:"closedAt"`
 }
 
 type ListRequest struct {
@@ -48,6 +83,11 @@ type Pvz struct {
 	Id               string    `json:"id"`
 	RegistrationDate time.Time `json:"registrationDate"`
 	City             string    `json:"city"`
+	Address          *string   `json:"address,omitempty"`
+	Latitude         *float64  `json:"latitude,omitempty"`
+	Longitude        *float64  `json:"longitude,omitempty"`
+	WorkingHours     *string   `json:"workingHours,omitempty"`
+	Phone            *string   `json:"phone,omitempty"`
 }
 
 type ReceptionProducts struct {
@@ -56,10 +96,13 @@ type ReceptionProducts struct {
 }
 
 type Reception struct {
-	Id       string    `json:"id"`
-	DateTime time.Time `json:"dateTime"`
-	PvzId    string    `json:"pvzId"`
-	Status   string    `json:"status"`
+	Id       string     `json:"id"`
+	DateTime time.Time  `json:"dateTime"`
+	PvzId    string     `json:"pvzId"`
+	Status   string     `json:"status"`
+	OpenedBy *string    `json:"openedBy"`
+	ClosedBy *string    `json:"closedBy"`
+	ClosedAt *time.Time `json:"closedAt"`
 }
 
 type Product struct {
@@ -67,16 +110,26 @@ type Product struct {
 	DateTime    time.Time `json:"dateTime"`
 	Type        string    `json:"type"`
 	ReceptionId string    `json:"receptionId"`
+	AcceptedBy  *string   `json:"acceptedBy"`
 }
 
 type RawList struct {
 	PvzId           string
 	PvzRegDate      time.Time
 	PvzCity         string
+	PvzAddress      sql.NullString
+	PvzLatitude     sql.NullFloat64
+	PvzLongitude    sql.NullFloat64
+	PvzWorkingHours sql.NullString
+	PvzPhone        sql.NullString
 	ReceptionId     string
 	ReceptionDate   time.Time
 	ReceptionStatus string
+	OpenedBy        sql.NullString
+	ClosedBy        sql.NullString
+	ClosedAt        sql.NullTime
 	ProductId       sql.NullString
 	ProductDate     sql.NullTime
 	ProductType     sql.NullString
+	AcceptedBy      sql.NullString
 }
@@ -0,0 +1,36 @@
+package pvz
+
+import "pvz/pkg/errors"
+
+const (
+	ImportFormatCsv  = "csv"
+	ImportFormatJson = "json"
+)
+
+// ImportRow is a pvz read from an import file. Row is its position in the file counted from 1, without the csv header.
+// Errors holds what failed reading or validating it; such rows are reported and never inserted.
+type ImportRow struct {
+	Row    int
+	Pvz    CreateRequest
+	Errors []errors.FieldError
+}
+
+type ImportRequest struct {
+	Rows   []ImportRow
+	DryRun bool
+}
+
+type ImportResult struct {
+	Row    int                 `json:"row"`
+	Pvz    *CreateResponse     `json:"pvz,omitempty"`
+	Errors []errors.FieldError `json:"errors,omitempty"`
+}
+
+// ImportResponse lists every row of the file in order. In a dry run Imported counts the rows that would be inserted.
+type ImportResponse struct {
+	DryRun   bool           `json:"dryRun"`
+	Total    int            `json:"total"`
+	Imported int            `json:"imported"`
+	Failed   int            `json:"failed"`
+	Rows     []ImportResult `json:"rows"`
+}
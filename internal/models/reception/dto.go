@@ -11,4 +11,22 @@ type CreateResponse struct {
 	DateTime time.Time `json:"dateTime"`
 	PvzId    string    `json:"pvzId"`
 	Status   string    `json:"status"`
+	OpenedBy *string   `json:"openedBy"`
+}
+
+type CurrentResponse struct {
+	Id            string         `json:"id"`
+	DateTime      time.Time      `json:"dateTime"`
+	PvzId         string         `json:"pvzId"`
+	Status        string         `json:"status"`
+	OpenedBy      *string        `json:"openedBy"`
+	Products      []Product      `json:"products"`
+	ProductCounts map[string]int `json:"productCounts"`
+}
+
+type Product struct {
+	Id         string    `json:"id"`
+	DateTime   time.Time `json:"dateTime"`
+	Type       string    `json:"type"`
+	AcceptedBy *string   `json:"acceptedBy"`
 }
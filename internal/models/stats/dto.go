@@ -0,0 +1,42 @@
+package stats
+
+import "time"
+
+const (
+	GroupPvz  = "pvz"
+	GroupCity = "city"
+	GroupType = "type"
+	GroupDay  = "day"
+)
+
+const (
+	FormatJson = "json"
+	FormatCsv  = "csv"
+)
+
+type Request struct {
+	StartDate *time.Time `json:"startDate" validate:"omitempty"`
+	EndDate   *time.Time `json:"endDate" validate:"omitempty"`
+	GroupBy   []string   `json:"groupBy" validate:"omitempty,unique,dive,oneof=pvz city type day"`
+	Format    string     `json:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// Row holds the figures of one group. Only the fields named in groupBy are set; Type is empty
+// for receptions without products when grouping by type.
+type Row struct {
+	PvzId                       *string  `json:"pvzId,omitempty"`
+	City                        *string  `json:"city,omitempty"`
+	Type                        *string  `json:"type,omitempty"`
+	Day                         *string  `json:"day,omitempty"`
+	Products                    int      `json:"products"`
+	Receptions                  int      `json:"receptions"`
+	OpenReceptions              int      `json:"openReceptions"`
+	AvgReceptionDurationSeconds *float64 `json:"avgReceptionDurationSeconds"`
+}
+
+type Response struct {
+	StartDate *time.Time `json:"startDate"`
+	EndDate   *time.Time `json:"endDate"`
+	GroupBy   []string   `json:"groupBy"`
+	Rows      []Row      `json:"rows"`
+}
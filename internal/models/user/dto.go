@@ -1 +1,18 @@
 package user
+
+type Response struct {
+	Id     string   `json:"id"`
+	Email  string   `json:"email"`
+	Role   string   `json:"role"`
+	PvzIds []string `json:"pvzIds"`
+}
+
+type SetPasswordRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+type AssignPvzsRequest struct {
+	Email  string   `json:"email" validate:"required,email"`
+	PvzIds []string `json:"pvzIds" validate:"required,min=1,dive,uuid"`
+}
@@ -0,0 +1,13 @@
+package ratelimit
+
+import "time"
+
+func NewMemoryLimiterWithClock(now func() time.Time) *MemoryLimiter {
+	return newMemoryLimiter(now)
+}
+
+func (ml *MemoryLimiter) Len() int {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	return len(ml.buckets)
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"pvz/configs"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	budget    configs.Budget
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+		b.tokens = min(float64(b.budget.Burst), b.tokens+elapsed*b.budget.Rate)
+		b.updatedAt = now
+	}
+}
+
+// MemoryLimiter keeps buckets in process memory, so every replica enforces its own budget.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return newMemoryLimiter(time.Now)
+}
+
+func newMemoryLimiter(now func() time.Time) *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: now(),
+		now:       now,
+	}
+}
+
+func (ml *MemoryLimiter) Allow(ctx context.Context, key string, budget configs.Budget) (Decision, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	now := ml.now()
+	ml.sweep(now)
+
+	b, ok := ml.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(budget.Burst), updatedAt: now}
+		ml.buckets[key] = b
+	}
+	b.budget = budget
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return Decision{RetryAfter: retryAfter(b.tokens, budget)}, nil
+	}
+	b.tokens--
+	return Decision{Allowed: true}, nil
+}
+
+// sweep drops buckets that have refilled completely: a fresh bucket would behave the same.
+func (ml *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(ml.lastSweep) < memorySweepInterval {
+		return
+	}
+	ml.lastSweep = now
+	for key, b := range ml.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.budget.Burst) {
+			delete(ml.buckets, key)
+		}
+	}
+}
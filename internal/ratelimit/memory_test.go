@@ -0,0 +1,70 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/configs"
+	"pvz/internal/ratelimit"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewMemoryLimiterWithClock(clock.Now)
+	budget := configs.Budget{Rate: 2, Burst: 3}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		decision, err := limiter.Allow(ctx, "user:1", budget)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "request %d is within burst", i)
+	}
+
+	decision, err := limiter.Allow(ctx, "user:1", budget)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+
+	decision, err = limiter.Allow(ctx, "user:2", budget)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "other keys have their own bucket")
+
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	decision, err = limiter.Allow(ctx, "user:1", budget)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed, "one token refilled")
+
+	decision, err = limiter.Allow(ctx, "user:1", budget)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+
+	clock.now = clock.now.Add(time.Hour)
+	_, err = limiter.Allow(ctx, "user:3", budget)
+	require.NoError(t, err)
+	assert.Equal(t, 1, limiter.Len(), "refilled buckets are swept")
+}
+
+func TestNew(t *testing.T) {
+	limiter, err := ratelimit.New(configs.RateLimitConfig{Enabled: false, Backend: configs.RateLimitMemory}, nil, nil)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		decision, err := limiter.Allow(context.Background(), "key", configs.Budget{Rate: 1, Burst: 1})
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+	}
+
+	_, err = ratelimit.New(configs.RateLimitConfig{Enabled: true, Backend: "redis"}, nil, nil)
+	require.Error(t, err)
+}
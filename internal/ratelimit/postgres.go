@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/repositories"
+	"sync"
+	"time"
+)
+
+const (
+	postgresSweepInterval = time.Minute
+	// postgresIdleTtl should exceed the time any configured budget needs to refill completely,
+	// otherwise a dropped row hands a partially drained client a full bucket.
+	postgresIdleTtl = time.Hour
+)
+
+// PostgresLimiter keeps buckets in a shared table, so all replicas draw from one budget per client.
+type PostgresLimiter struct {
+	repo repositories.RateLimitRepository
+	conn *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresLimiter(repo repositories.RateLimitRepository, conn *sql.DB) *PostgresLimiter {
+	return &PostgresLimiter{
+		repo:      repo,
+		conn:      conn,
+		lastSweep: time.Now(),
+	}
+}
+
+func (pl *PostgresLimiter) Allow(ctx context.Context, key string, budget configs.Budget) (Decision, error) {
+	now := repositories.Now()
+	pl.sweep(ctx, now)
+
+	allowed, tokens, err := pl.repo.Take(ctx, pl.conn, key, budget.Rate, budget.Burst, now)
+	if err != nil {
+		return Decision{}, err
+	}
+	if !allowed {
+		return Decision{RetryAfter: retryAfter(tokens, budget)}, nil
+	}
+	return Decision{Allowed: true}, nil
+}
+
+func (pl *PostgresLimiter) sweep(ctx context.Context, now time.Time) {
+	pl.mu.Lock()
+	if now.Sub(pl.lastSweep) < postgresSweepInterval {
+		pl.mu.Unlock()
+		return
+	}
+	pl.lastSweep = now
+	pl.mu.Unlock()
+
+	if err := pl.repo.DeleteIdle(ctx, pl.conn, now.Add(-postgresIdleTtl)); err != nil {
+		logger.FromContext(ctx).Warn("failed to delete idle rate limit buckets", "err", err)
+	}
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"pvz/configs"
+	"pvz/internal/repositories"
+	"time"
+)
+
+// Decision is the outcome of taking one token from a bucket. RetryAfter is only set when the
+// request is not allowed and says when the next token will be available.
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, budget configs.Budget) (Decision, error)
+}
+
+func New(cfg configs.RateLimitConfig, repo repositories.RateLimitRepository, db *sql.DB) (Limiter, error) {
+	if !cfg.Enabled {
+		return NewUnlimited(), nil
+	}
+	switch cfg.Backend {
+	case configs.RateLimitMemory:
+		return NewMemoryLimiter(), nil
+	case configs.RateLimitPostgres:
+		return NewPostgresLimiter(repo, db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", cfg.Backend)
+	}
+}
+
+type unlimited struct{}
+
+func NewUnlimited() Limiter {
+	return unlimited{}
+}
+
+func (unlimited) Allow(ctx context.Context, key string, budget configs.Budget) (Decision, error) {
+	return Decision{Allowed: true}, nil
+}
+
+// retryAfter is how long an empty bucket holding tokens needs to refill to one whole token.
+func retryAfter(tokens float64, budget configs.Budget) time.Duration {
+	return time.Duration((1 - tokens) / budget.Rate * float64(time.Second))
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/tracing"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, q Querier, entry models.AuditEntry) error
+	List(ctx context.Context, q Querier, req audit.ListRequest, offset int) ([]models.AuditEntry, error)
+}
+
+type auditRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) AuditRepository {
+	return &auditRepositoryPsql{
+		db: db,
+	}
+}
+
+func (ar *auditRepositoryPsql) Create(ctx context.Context, q Querier, entry models.AuditEntry) error {
+	ctx, span := tracing.StartQuery(ctx, "audit.Create")
+	defer span.End()
+
+	query := `INSERT INTO audit_log (actorId, actorRole, action, pvzId, targetIds, requestId, ip, before, after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := q.ExecContext(ctx, query,
+		entry.ActorId, entry.ActorRole, entry.Action, entry.PvzId, entry.TargetIds,
+		entry.RequestId, entry.Ip, entry.Before, entry.After,
+	)
+	return err
+}
+
+func (ar *auditRepositoryPsql) List(ctx context.Context, q Querier, req audit.ListRequest, offset int) ([]models.AuditEntry, error) {
+	ctx, span := tracing.StartQuery(ctx, "audit.List")
+	defer span.End()
+
+	query := `SELECT id, createdAt, actorId, actorRole, action, pvzId, targetIds, requestId, ip, before, after
+		FROM audit_log
+		WHERE ($1::uuid IS NULL OR actorId = $1::uuid)
+		  AND ($2::uuid IS NULL OR pvzId = $2::uuid)
+		  AND ($3::timestamp IS NULL OR createdAt >= $3::timestamp)
+		  AND ($4::timestamp IS NULL OR createdAt <= $4::timestamp)
+		ORDER BY createdAt DESC, id DESC
+		OFFSET $5 LIMIT $6;
+	`
+
+	rows, err := q.QueryContext(ctx, query, req.ActorId, req.PvzId, req.StartDate, req.EndDate, offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		err := rows.Scan(
+			&entry.Id, &entry.DateTime, &entry.ActorId, &entry.ActorRole, &entry.Action, &entry.PvzId,
+			&entry.TargetIds, &entry.RequestId, &entry.Ip, &entry.Before, &entry.After,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entry)
+	}
+
+	return result, rows.Err()
+}
@@ -1,9 +1,19 @@
 package repositories
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"time"
+)
 
 type Querier interface {
-	QueryRow(query string, args ...any) *sql.Row
-	Query(query string, args ...any) (*sql.Rows, error)
-	Exec(query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Now is the current time the way TIMESTAMP columns hold it: UTC wall time, whatever the time zone of
+// the host. Statements pass it instead of SQL now(), which follows the time zone of the session.
+func Now() time.Time {
+	return time.Now().UTC()
 }
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pvz/internal/models"
+	"pvz/internal/tracing"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, q Querier, key models.IdempotencyKey, staleBefore time.Time) (bool, error)
+	Get(ctx context.Context, q Querier, caller, key string) (*models.IdempotencyKey, error)
+	Renew(ctx context.Context, q Querier, caller, key string, now time.Time) error
+	Complete(ctx context.Context, q Querier, caller, key string, status int, contentType string, body []byte) error
+	Delete(ctx context.Context, q Querier, caller, key string) error
+	DeleteExpired(ctx context.Context, q Querier, now time.Time) error
+}
+
+type idempotencyRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+	return &idempotencyRepositoryPsql{
+		db: db,
+	}
+}
+
+// Reserve claims the key for a new request. It succeeds when the key is unused, expired, or still
+// in progress and not renewed since staleBefore (the request that took it must have died), and
+// reports false when another live request owns the key.
+func (ir *idempotencyRepositoryPsql) Reserve(ctx context.Context, q Querier, key models.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	ctx, span := tracing.StartQuery(ctx, "idempotency.Reserve")
+	defer span.End()
+
+	query := `INSERT INTO idempotency_keys AS i (caller, key, requestHash, createdAt, expiresAt)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (caller, key) DO UPDATE SET
+			requestHash = EXCLUDED.requestHash,
+			status = NULL,
+			contentType = NULL,
+			responseBody = NULL,
+			createdAt = EXCLUDED.createdAt,
+			expiresAt = EXCLUDED.expiresAt
+		WHERE i.expiresAt < EXCLUDED.createdAt OR (i.status IS NULL AND i.createdAt < $6)
+		RETURNING true`
+
+	var reserved bool
+	err := q.QueryRowContext(ctx, query, key.Caller, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt, staleBefore).Scan(&reserved)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return reserved, nil
+}
+
+func (ir *idempotencyRepositoryPsql) Get(ctx context.Context, q Querier, caller, key string) (*models.IdempotencyKey, error) {
+	ctx, span := tracing.StartQuery(ctx, "idempotency.Get")
+	defer span.End()
+
+	query := `SELECT caller, key, requestHash, status, contentType, responseBody, createdAt, expiresAt
+		FROM idempotency_keys WHERE caller = $1 AND key = $2`
+
+	var k models.IdempotencyKey
+	err := q.QueryRowContext(ctx, query, caller, key).Scan(
+		&k.Caller, &k.Key, &k.RequestHash, &k.Status, &k.ContentType, &k.ResponseBody, &k.CreatedAt, &k.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// Renew moves createdAt of a key that is still in progress, so Reserve does not take it over as stale.
+func (ir *idempotencyRepositoryPsql) Renew(ctx context.Context, q Querier, caller, key string, now time.Time) error {
+	ctx, span := tracing.StartQuery(ctx, "idempotency.Renew")
+	defer span.End()
+
+	query := `UPDATE idempotency_keys SET createdAt = $1 WHERE caller = $2 AND key = $3 AND status IS NULL`
+
+	_, err := q.ExecContext(ctx, query, now, caller, key)
+	return err
+}
+
+func (ir *idempotencyRepositoryPsql) Complete(ctx context.Context, q Querier, caller, key string, status int, contentType string, body []byte) error {
+	ctx, span := tracing.StartQuery(ctx, "idempotency.Complete")
+	defer span.End()
+
+	query := `UPDATE idempotency_keys SET status = $1, contentType = $2, responseBody = $3
+		WHERE caller = $4 AND key = $5`
+
+	_, err := q.ExecContext(ctx, query, status, contentType, body, caller, key)
+	return err
+}
+
+func (ir *idempotencyRepositoryPsql) Delete(ctx context.Context, q Querier, caller, key string) error {
+	ctx, span := tracing.StartQuery(ctx, "idempotency.Delete")
+	defer span.End()
+
+	query := `DELETE FROM idempotency_keys WHERE caller = $1 AND key = $2`
+
+	_, err := q.ExecContext(ctx, query, caller, key)
+	return err
+}
+
+func (ir *idempotencyRepositoryPsql) DeleteExpired(ctx context.Context, q Querier, now time.Time) error {
+	ctx, span := tracing.StartQuery(ctx, "idempotency.DeleteExpired")
+	defer span.End()
+
+	query := `DELETE FROM idempotency_keys WHERE expiresAt < $1`
+
+	_, err := q.ExecContext(ctx, query, now)
+	return err
+}
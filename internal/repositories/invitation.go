@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"pvz/internal/models"
+	"pvz/internal/tracing"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, q Querier, inv models.Invitation) (*models.Invitation, error)
+	GetByTokenHashForUpdate(ctx context.Context, q Querier, tokenHash string) (*models.Invitation, error)
+	MarkAccepted(ctx context.Context, q Querier, id, userId string) error
+}
+
+type invitationRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewInvitationRepository(db *sql.DB) InvitationRepository {
+	return &invitationRepositoryPsql{
+		db: db,
+	}
+}
+
+const invitationColumns = `id, email, role, pvzIds, tokenHash, createdBy, createdAt, expiresAt, acceptedAt, acceptedBy`
+
+func scanInvitation(row *sql.Row) (*models.Invitation, error) {
+	var inv models.Invitation
+	err := row.Scan(
+		&inv.Id, &inv.Email, &inv.Role, (*pq.StringArray)(&inv.PvzIds), &inv.TokenHash,
+		&inv.CreatedBy, &inv.CreatedAt, &inv.ExpiresAt, &inv.AcceptedAt, &inv.AcceptedBy,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (ir *invitationRepositoryPsql) Create(ctx context.Context, q Querier, inv models.Invitation) (*models.Invitation, error) {
+	ctx, span := tracing.StartQuery(ctx, "invitation.Create")
+	defer span.End()
+
+	query := `INSERT INTO invitations (email, role, pvzIds, tokenHash, createdBy, expiresAt)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + invitationColumns
+	return scanInvitation(q.QueryRowContext(ctx, query,
+		inv.Email, inv.Role, pq.StringArray(inv.PvzIds), inv.TokenHash, inv.CreatedBy, inv.ExpiresAt,
+	))
+}
+
+// GetByTokenHashForUpdate locks the invitation row so concurrent accepts of the same token serialize.
+func (ir *invitationRepositoryPsql) GetByTokenHashForUpdate(ctx context.Context, q Querier, tokenHash string) (*models.Invitation, error) {
+	ctx, span := tracing.StartQuery(ctx, "invitation.GetByTokenHashForUpdate")
+	defer span.End()
+
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE tokenHash = $1 FOR UPDATE`
+	return scanInvitation(q.QueryRowContext(ctx, query, tokenHash))
+}
+
+func (ir *invitationRepositoryPsql) MarkAccepted(ctx context.Context, q Querier, id, userId string) error {
+	ctx, span := tracing.StartQuery(ctx, "invitation.MarkAccepted")
+	defer span.End()
+
+	query := `UPDATE invitations SET acceptedAt = $1, acceptedBy = $2 WHERE id = $3`
+
+	_, err := q.ExecContext(ctx, query, Now(), userId, id)
+	return err
+}
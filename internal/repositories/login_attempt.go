@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pvz/internal/models"
+	"pvz/internal/tracing"
+	"time"
+)
+
+const (
+	LoginAttemptEmail = "email"
+	LoginAttemptIp    = "ip"
+)
+
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, q Querier, kind, subject string) (*models.LoginAttempt, error)
+	RegisterFailure(ctx context.Context, q Querier, kind, subject string, now, windowStart time.Time) (int, error)
+	Block(ctx context.Context, q Querier, kind, subject string, until time.Time) error
+	Reset(ctx context.Context, q Querier, kind, subject string) error
+}
+
+type loginAttemptRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepository(db *sql.DB) LoginAttemptRepository {
+	return &loginAttemptRepositoryPsql{
+		db: db,
+	}
+}
+
+func (lr *loginAttemptRepositoryPsql) Get(ctx context.Context, q Querier, kind, subject string) (*models.LoginAttempt, error) {
+	ctx, span := tracing.StartQuery(ctx, "loginAttempt.Get")
+	defer span.End()
+
+	query := `SELECT kind, subject, failures, lastFailureAt, blockedUntil
+		FROM login_attempts WHERE kind = $1 AND subject = $2`
+
+	var attempt models.LoginAttempt
+	err := q.QueryRowContext(ctx, query, kind, subject).Scan(
+		&attempt.Kind, &attempt.Subject, &attempt.Failures, &attempt.LastFailureAt, &attempt.BlockedUntil,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RegisterFailure atomically bumps the failure counter and returns its new value. A counter whose
+// last failure is older than windowStart starts over, so stale failures don't add up forever.
+func (lr *loginAttemptRepositoryPsql) RegisterFailure(ctx context.Context, q Querier, kind, subject string, now, windowStart time.Time) (int, error) {
+	ctx, span := tracing.StartQuery(ctx, "loginAttempt.RegisterFailure")
+	defer span.End()
+
+	query := `INSERT INTO login_attempts (kind, subject, failures, lastFailureAt)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (kind, subject) DO UPDATE SET
+			failures = CASE WHEN login_attempts.lastFailureAt < $4 THEN 1 ELSE login_attempts.failures + 1 END,
+			lastFailureAt = EXCLUDED.lastFailureAt
+		RETURNING failures`
+
+	var failures int
+	err := q.QueryRowContext(ctx, query, kind, subject, now, windowStart).Scan(&failures)
+	return failures, err
+}
+
+func (lr *loginAttemptRepositoryPsql) Block(ctx context.Context, q Querier, kind, subject string, until time.Time) error {
+	ctx, span := tracing.StartQuery(ctx, "loginAttempt.Block")
+	defer span.End()
+
+	query := `UPDATE login_attempts SET blockedUntil = $1 WHERE kind = $2 AND subject = $3`
+
+	_, err := q.ExecContext(ctx, query, until, kind, subject)
+	return err
+}
+
+func (lr *loginAttemptRepositoryPsql) Reset(ctx context.Context, q Querier, kind, subject string) error {
+	ctx, span := tracing.StartQuery(ctx, "loginAttempt.Reset")
+	defer span.End()
+
+	query := `DELETE FROM login_attempts WHERE kind = $1 AND subject = $2`
+
+	_, err := q.ExecContext(ctx, query, kind, subject)
+	return err
+}
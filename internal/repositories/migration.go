@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pvz/internal/tracing"
+)
+
+type MigrationRepository interface {
+	GetVersion(ctx context.Context, q Querier) (version uint, dirty bool, err error)
+}
+
+type migrationRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewMigrationRepository(db *sql.DB) MigrationRepository {
+	return &migrationRepositoryPsql{
+		db: db,
+	}
+}
+
+func (mr *migrationRepositoryPsql) GetVersion(ctx context.Context, q Querier) (uint, bool, error) {
+	ctx, span := tracing.StartQuery(ctx, "migration.GetVersion")
+	defer span.End()
+
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	var version uint
+	var dirty bool
+	err := q.QueryRowContext(ctx, query).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pvz/internal/models"
+	"pvz/internal/tracing"
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, q Querier, reset models.PasswordReset) error
+	GetByTokenHashForUpdate(ctx context.Context, q Querier, tokenHash string) (*models.PasswordReset, error)
+	ConsumeAll(ctx context.Context, q Querier, userId string) error
+}
+
+type passwordResetRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepository(db *sql.DB) PasswordResetRepository {
+	return &passwordResetRepositoryPsql{
+		db: db,
+	}
+}
+
+func (pr *passwordResetRepositoryPsql) Create(ctx context.Context, q Querier, reset models.PasswordReset) error {
+	ctx, span := tracing.StartQuery(ctx, "passwordReset.Create")
+	defer span.End()
+
+	query := `INSERT INTO password_resets (userId, tokenHash, expiresAt) VALUES ($1, $2, $3)`
+
+	_, err := q.ExecContext(ctx, query, reset.UserId, reset.TokenHash, reset.ExpiresAt)
+	return err
+}
+
+func (pr *passwordResetRepositoryPsql) GetByTokenHashForUpdate(ctx context.Context, q Querier, tokenHash string) (*models.PasswordReset, error) {
+	ctx, span := tracing.StartQuery(ctx, "passwordReset.GetByTokenHashForUpdate")
+	defer span.End()
+
+	query := `SELECT id, userId, tokenHash, createdAt, expiresAt, usedAt
+		FROM password_resets WHERE tokenHash = $1 FOR UPDATE`
+
+	var reset models.PasswordReset
+	err := q.QueryRowContext(ctx, query, tokenHash).Scan(
+		&reset.Id, &reset.UserId, &reset.TokenHash, &reset.CreatedAt, &reset.ExpiresAt, &reset.UsedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reset, nil
+}
+
+// ConsumeAll marks every outstanding reset token of the user as used, so one reset burns the rest.
+func (pr *passwordResetRepositoryPsql) ConsumeAll(ctx context.Context, q Querier, userId string) error {
+	ctx, span := tracing.StartQuery(ctx, "passwordReset.ConsumeAll")
+	defer span.End()
+
+	query := `UPDATE password_resets SET usedAt = $1 WHERE userId = $2 AND usedAt IS NULL`
+
+	_, err := q.ExecContext(ctx, query, Now(), userId)
+	return err
+}
@@ -1,14 +1,17 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"pvz/internal/models"
+	"pvz/internal/tracing"
 )
 
 type ProductRepository interface {
-	AddInReception(q Querier, reqProduct models.Product) (*models.Product, error)
-	DeleteLast(q Querier, recId string) (*models.Product, error)
+	AddInReception(ctx context.Context, q Querier, reqProduct models.Product) (*models.Product, error)
+	DeleteLast(ctx context.Context, q Querier, recId string) (*models.Product, error)
+	ListByReception(ctx context.Context, q Querier, recId string) ([]models.Product, error)
 }
 
 type productRepositoryPsql struct {
@@ -21,20 +24,27 @@ func NewProductRepository(db *sql.DB) ProductRepository {
 	}
 }
 
-func (pr *productRepositoryPsql) AddInReception(q Querier, reqProduct models.Product) (*models.Product, error) {
-	query := `INSERT INTO products (receptionId, type) VALUES ($1, $2) RETURNING id, receivedAt, receptionId, type`
+func (pr *productRepositoryPsql) AddInReception(ctx context.Context, q Querier, reqProduct models.Product) (*models.Product, error) {
+	ctx, span := tracing.StartQuery(ctx, "product.AddInReception")
+	defer span.End()
+
+	query := `INSERT INTO products (receptionId, type, acceptedBy) VALUES ($1, $2, $3) RETURNING id, receivedAt, receptionId, type, acceptedBy`
 	var product models.Product
-	err := q.QueryRow(query, reqProduct.ReceptionId, reqProduct.Type).Scan(&product.Id, &product.DateTime, &product.ReceptionId, &product.Type)
+	err := q.QueryRowContext(ctx, query, reqProduct.ReceptionId, reqProduct.Type, reqProduct.AcceptedBy).
+		Scan(&product.Id, &product.DateTime, &product.ReceptionId, &product.Type, &product.AcceptedBy)
 	if err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
-func (pr *productRepositoryPsql) DeleteLast(q Querier, recId string) (*models.Product, error) {
-	query := `DELETE FROM products WHERE id = (SELECT id FROM products WHERE receptionId = $1 ORDER BY receivedAt DESC LIMIT 1) RETURNING id, receivedAt, receptionId, type`
+func (pr *productRepositoryPsql) DeleteLast(ctx context.Context, q Querier, recId string) (*models.Product, error) {
+	ctx, span := tracing.StartQuery(ctx, "product.DeleteLast")
+	defer span.End()
+
+	query := `DELETE FROM products WHERE id = (SELECT id FROM products WHERE receptionId = $1 ORDER BY receivedAt DESC LIMIT 1) RETURNING id, receivedAt, receptionId, type, acceptedBy`
 	var product models.Product
-	err := q.QueryRow(query, recId).Scan(&product.Id, &product.DateTime, &product.ReceptionId, &product.Type)
+	err := q.QueryRowContext(ctx, query, recId).Scan(&product.Id, &product.DateTime, &product.ReceptionId, &product.Type, &product.AcceptedBy)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -43,3 +53,28 @@ func (pr *productRepositoryPsql) DeleteLast(q Querier, recId string) (*models.Pr
 	}
 	return &product, nil
 }
+
+func (pr *productRepositoryPsql) ListByReception(ctx context.Context, q Querier, recId string) ([]models.Product, error) {
+	ctx, span := tracing.StartQuery(ctx, "product.ListByReception")
+	defer span.End()
+
+	query := `SELECT id, receivedAt, receptionId, type, acceptedBy FROM products WHERE receptionId = $1 ORDER BY receivedAt`
+
+	rows, err := q.QueryContext(ctx, query, recId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.Product
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(&product.Id, &product.DateTime, &product.ReceptionId, &product.Type, &product.AcceptedBy)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, product)
+	}
+
+	return result, rows.Err()
+}
@@ -1,18 +1,37 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"pvz/internal/models"
 	"pvz/internal/models/pvz"
+	"pvz/internal/tracing"
+	"time"
 )
 
 type PvzRepository interface {
-	GetById(q Querier, id string) (*models.Pvz, error)
-	Create(q Querier, reqPvz pvz.CreateRequest) (*models.Pvz, error)
-	ListWithFilterDate(q Querier, reqPvz pvz.ListRequest, offset int) ([]pvz.RawList, error)
+	GetById(ctx context.Context, q Querier, id string) (*models.Pvz, error)
+	Create(ctx context.Context, q Querier, reqPvz pvz.CreateRequest) (*models.Pvz, error)
+	Update(ctx context.Context, q Querier, id string, reqPvz pvz.UpdateRequest) (*models.Pvz, error)
+	Nearby(ctx context.Context, q Querier, latitude, longitude, radiusMeters float64, limit int) ([]models.PvzDistance, error)
+	ListWithFilterDate(ctx context.Context, q Querier, reqPvz pvz.ListRequest, offset int) ([]pvz.RawList, error)
+	List(ctx context.Context, q Querier) ([]models.Pvz, error)
+	Export(ctx context.Context, q Querier, startDate, endDate time.Time, batchSize int, fn func(pvz.RawList) error) error
 }
 
+const pvzColumns = `id, registrationDate, city, address, latitude, longitude, workingHours, phone`
+
+// earthRadiusMeters is the mean radius used by the haversine distance.
+const earthRadiusMeters = 6371000
+
+// metersPerDegree is the length of a degree of latitude, used to cut the search down to a band of latitudes first.
+const metersPerDegree = 111320
+
+// exportCursor lives only until the end of the transaction Export is called in.
+const exportCursor = "pvz_export"
+
 type pvzRepositoryPsql struct {
 	db *sql.DB
 }
@@ -23,36 +42,109 @@ func NewPvzRepository(db *sql.DB) PvzRepository {
 	}
 }
 
-func (pr *pvzRepositoryPsql) GetById(q Querier, id string) (*models.Pvz, error) {
-	query := `SELECT * FROM pvzs WHERE id = $1`
+func (pr *pvzRepositoryPsql) GetById(ctx context.Context, q Querier, id string) (*models.Pvz, error) {
+	ctx, span := tracing.StartQuery(ctx, "pvz.GetById")
+	defer span.End()
 
-	var scanPvz models.Pvz
-	err := q.QueryRow(query, id).Scan(&scanPvz.Id, &scanPvz.RegistrationDate, &scanPvz.City)
+	query := `SELECT ` + pvzColumns + ` FROM pvzs WHERE id = $1`
+	return scanPvz(q.QueryRowContext(ctx, query, id))
+}
+
+func pvzFields(p *models.Pvz) []any {
+	return []any{&p.Id, &p.RegistrationDate, &p.City, &p.Address, &p.Latitude, &p.Longitude, &p.WorkingHours, &p.Phone}
+}
+
+func scanPvz(row *sql.Row) (*models.Pvz, error) {
+	var p models.Pvz
+	err := row.Scan(pvzFields(&p)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
-
 	if err != nil {
 		return nil, err
 	}
-	return &scanPvz, nil
+	return &p, nil
+}
+
+// Create keeps the id and registration date of the request when they are given. It returns nil if a pvz with
+// the id already exists, which leaves the transaction usable.
+func (pr *pvzRepositoryPsql) Create(ctx context.Context, q Querier, reqPvz pvz.CreateRequest) (*models.Pvz, error) {
+	ctx, span := tracing.StartQuery(ctx, "pvz.Create")
+	defer span.End()
+
+	query := `INSERT INTO pvzs(id, registrationDate, city, address, latitude, longitude, workingHours, phone)
+		VALUES (COALESCE($1::uuid, gen_random_uuid()), $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING ` + pvzColumns
+
+	// registrationDate has no time zone, so the date is stored as UTC instead of its local wall clock
+	registrationDate := Now()
+	if reqPvz.RegistrationDate != nil {
+		registrationDate = reqPvz.RegistrationDate.UTC()
+	}
+
+	return scanPvz(q.QueryRowContext(ctx, query, reqPvz.Id, registrationDate, reqPvz.City, reqPvz.Address,
+		reqPvz.Latitude, reqPvz.Longitude, reqPvz.WorkingHours, reqPvz.Phone))
+}
+
+// Update replaces everything but the id and registration date. It returns nil if there is no such pvz.
+func (pr *pvzRepositoryPsql) Update(ctx context.Context, q Querier, id string, reqPvz pvz.UpdateRequest) (*models.Pvz, error) {
+	ctx, span := tracing.StartQuery(ctx, "pvz.Update")
+	defer span.End()
+
+	query := `UPDATE pvzs
+		SET city = $2, address = $3, latitude = $4, longitude = $5, workingHours = $6, phone = $7
+		WHERE id = $1
+		RETURNING ` + pvzColumns
+
+	return scanPvz(q.QueryRowContext(ctx, query, id, reqPvz.City, reqPvz.Address,
+		reqPvz.Latitude, reqPvz.Longitude, reqPvz.WorkingHours, reqPvz.Phone))
 }
 
-func (pr *pvzRepositoryPsql) Create(q Querier, reqPvz pvz.CreateRequest) (*models.Pvz, error) {
-	query := `INSERT INTO pvzs(city) VALUES ($1) RETURNING id, registrationDate, city`
+// Nearby returns the pvzs within radiusMeters of the point, closest first. Distances are great-circle ones by the
+// haversine formula; pvzs without coordinates are never returned.
+func (pr *pvzRepositoryPsql) Nearby(ctx context.Context, q Querier, latitude, longitude, radiusMeters float64, limit int) ([]models.PvzDistance, error) {
+	ctx, span := tracing.StartQuery(ctx, "pvz.Nearby")
+	defer span.End()
+
+	query := `SELECT ` + pvzColumns + `, distance FROM (
+			SELECT ` + pvzColumns + `,
+				   2 * $5::float8 * asin(least(1, sqrt(
+					   power(sin(radians(latitude - $1::float8) / 2), 2) +
+					   cos(radians($1::float8)) * cos(radians(latitude)) * power(sin(radians(longitude - $2::float8) / 2), 2)
+				   ))) AS distance
+			FROM pvzs
+			WHERE latitude BETWEEN $1::float8 - $6::float8 AND $1::float8 + $6::float8
+		) AS candidates
+		WHERE distance <= $3::float8
+		ORDER BY distance, id
+		LIMIT $4`
 
-	var scanPvz models.Pvz
-	err := q.QueryRow(query, reqPvz.City).Scan(&scanPvz.Id, &scanPvz.RegistrationDate, &scanPvz.City)
+	latitudeBand := radiusMeters / metersPerDegree
+	rows, err := q.QueryContext(ctx, query, latitude, longitude, radiusMeters, limit, earthRadiusMeters, latitudeBand)
 	if err != nil {
 		return nil, err
 	}
-	return &scanPvz, nil
+	defer rows.Close()
+
+	var result []models.PvzDistance
+	for rows.Next() {
+		var p models.PvzDistance
+		if err := rows.Scan(append(pvzFields(&p.Pvz), &p.DistanceMeters)...); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
 }
 
-func (pr *pvzRepositoryPsql) ListWithFilterDate(q Querier, reqPvz pvz.ListRequest, offset int) ([]pvz.RawList, error) {
-	query := `SELECT p.id, p.registrationDate, p.city,
-					 r.id, r.createdAt, r.status,
-					 pr.id, pr.receivedAt, pr.type
+func (pr *pvzRepositoryPsql) ListWithFilterDate(ctx context.Context, q Querier, reqPvz pvz.ListRequest, offset int) ([]pvz.RawList, error) {
+	ctx, span := tracing.StartQuery(ctx, "pvz.ListWithFilterDate")
+	defer span.End()
+
+	query := `SELECT p.id, p.registrationDate, p.city, p.address, p.latitude, p.longitude, p.workingHours, p.phone,
+					 r.id, r.createdAt, r.status, r.openedBy, r.closedBy, r.closedAt,
+					 pr.id, pr.receivedAt, pr.type, pr.acceptedBy
 		FROM pvzs p
 		LEFT JOIN receptions r ON r.pvzId = p.id
 		LEFT JOIN products pr ON pr.receptionId = r.id
@@ -61,7 +153,7 @@ func (pr *pvzRepositoryPsql) ListWithFilterDate(q Querier, reqPvz pvz.ListReques
 		OFFSET $3 LIMIT $4;
 	`
 
-	rows, err := q.Query(query, reqPvz.StartDate, reqPvz.EndDate, offset, reqPvz.Limit)
+	rows, err := q.QueryContext(ctx, query, reqPvz.StartDate, reqPvz.EndDate, offset, reqPvz.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +161,7 @@ func (pr *pvzRepositoryPsql) ListWithFilterDate(q Querier, reqPvz pvz.ListReques
 
 	var result []pvz.RawList
 	for rows.Next() {
-		var row pvz.RawList
-		err := rows.Scan(
-			&row.PvzId, &row.PvzRegDate, &row.PvzCity,
-			&row.ReceptionId, &row.ReceptionDate, &row.ReceptionStatus,
-			&row.ProductId, &row.ProductDate, &row.ProductType,
-		)
+		row, err := scanRawList(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -83,3 +170,92 @@ func (pr *pvzRepositoryPsql) ListWithFilterDate(q Querier, reqPvz pvz.ListReques
 
 	return result, nil
 }
+
+func (pr *pvzRepositoryPsql) List(ctx context.Context, q Querier) ([]models.Pvz, error) {
+	ctx, span := tracing.StartQuery(ctx, "pvz.List")
+	defer span.End()
+
+	query := `SELECT ` + pvzColumns + ` FROM pvzs ORDER BY registrationDate`
+
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.Pvz
+	for rows.Next() {
+		var p models.Pvz
+		if err := rows.Scan(pvzFields(&p)...); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
+
+// Export walks the pvz history of the window through a server-side cursor, so only batchSize rows are held
+// in memory at a time. q must be a transaction: the cursor is closed with it.
+func (pr *pvzRepositoryPsql) Export(ctx context.Context, q Querier, startDate, endDate time.Time, batchSize int, fn func(pvz.RawList) error) error {
+	ctx, span := tracing.StartQuery(ctx, "pvz.Export")
+	defer span.End()
+
+	declare := `DECLARE ` + exportCursor + ` NO SCROLL CURSOR FOR
+		SELECT p.id, p.registrationDate, p.city, p.address, p.latitude, p.longitude, p.workingHours, p.phone,
+			   r.id, r.createdAt, r.status, r.openedBy, r.closedBy, r.closedAt,
+			   pr.id, pr.receivedAt, pr.type, pr.acceptedBy
+		FROM pvzs p
+		JOIN receptions r ON r.pvzId = p.id
+		LEFT JOIN products pr ON pr.receptionId = r.id
+		WHERE r.createdAt BETWEEN $1 AND $2
+		ORDER BY p.id, r.createdAt, r.id, pr.receivedAt, pr.id`
+
+	if _, err := q.ExecContext(ctx, declare, startDate, endDate); err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf(`FETCH FORWARD %d FROM %s`, batchSize, exportCursor)
+	for {
+		fetched, err := pr.exportBatch(ctx, q, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < batchSize {
+			break
+		}
+	}
+
+	_, err := q.ExecContext(ctx, `CLOSE `+exportCursor)
+	return err
+}
+
+func (pr *pvzRepositoryPsql) exportBatch(ctx context.Context, q Querier, fetch string, fn func(pvz.RawList) error) (int, error) {
+	rows, err := q.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		row, err := scanRawList(rows)
+		if err != nil {
+			return fetched, err
+		}
+		fetched++
+		if err := fn(row); err != nil {
+			return fetched, err
+		}
+	}
+	return fetched, rows.Err()
+}
+
+func scanRawList(rows *sql.Rows) (pvz.RawList, error) {
+	var row pvz.RawList
+	err := rows.Scan(
+		&row.PvzId, &row.PvzRegDate, &row.PvzCity, &row.PvzAddress, &row.PvzLatitude, &row.PvzLongitude, &row.PvzWorkingHours, &row.PvzPhone,
+		&row.ReceptionId, &row.ReceptionDate, &row.ReceptionStatus, &row.OpenedBy, &row.ClosedBy, &row.ClosedAt,
+		&row.ProductId, &row.ProductDate, &row.ProductType, &row.AcceptedBy,
+	)
+	return row, err
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"pvz/internal/tracing"
+	"time"
+)
+
+type RateLimitRepository interface {
+	Take(ctx context.Context, q Querier, key string, rate float64, burst int, now time.Time) (bool, float64, error)
+	DeleteIdle(ctx context.Context, q Querier, before time.Time) error
+}
+
+type rateLimitRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewRateLimitRepository(db *sql.DB) RateLimitRepository {
+	return &rateLimitRepositoryPsql{
+		db: db,
+	}
+}
+
+// refilledTokens is the bucket of an existing row topped up for the time since its last update;
+// $2 is the refill rate per second, $3 the burst and $4 the current time.
+const refilledTokens = `LEAST($3::float8, r.tokens + GREATEST(EXTRACT(EPOCH FROM ($4::timestamp - r.updatedAt)), 0) * $2::float8)`
+
+// Take refills the bucket for the time passed since its last update and takes one token if there is one,
+// all in a single statement so concurrent replicas never spend the same token twice. It reports whether
+// a token was taken and how many are left.
+func (rr *rateLimitRepositoryPsql) Take(ctx context.Context, q Querier, key string, rate float64, burst int, now time.Time) (bool, float64, error) {
+	ctx, span := tracing.StartQuery(ctx, "rateLimit.Take")
+	defer span.End()
+
+	query := `INSERT INTO rate_limits AS r (key, tokens, allowed, updatedAt)
+		VALUES ($1, $3::float8 - 1, true, $4::timestamp)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = ` + refilledTokens + ` - CASE WHEN ` + refilledTokens + ` >= 1 THEN 1 ELSE 0 END,
+			allowed = ` + refilledTokens + ` >= 1,
+			updatedAt = GREATEST(r.updatedAt, EXCLUDED.updatedAt)
+		RETURNING allowed, tokens`
+
+	var allowed bool
+	var tokens float64
+	err := q.QueryRowContext(ctx, query, key, rate, burst, now).Scan(&allowed, &tokens)
+	return allowed, tokens, err
+}
+
+func (rr *rateLimitRepositoryPsql) DeleteIdle(ctx context.Context, q Querier, before time.Time) error {
+	ctx, span := tracing.StartQuery(ctx, "rateLimit.DeleteIdle")
+	defer span.End()
+
+	query := `DELETE FROM rate_limits WHERE updatedAt < $1`
+
+	_, err := q.ExecContext(ctx, query, before)
+	return err
+}
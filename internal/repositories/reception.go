@@ -1,15 +1,20 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"pvz/internal/models"
+	"pvz/internal/models/reception"
+	"pvz/internal/tracing"
+	"time"
 )
 
 type ReceptionRepository interface {
-	Create(q Querier, req models.Reception) (*models.Reception, error)
-	GetByPvzId(q Querier, pvzId string) (*models.Reception, error)
-	SetStatus(q Querier, status, pvzId string) (*models.Reception, error)
+	Create(ctx context.Context, q Querier, req models.Reception) (*models.Reception, error)
+	GetByPvzId(ctx context.Context, q Querier, pvzId string) (*models.Reception, error)
+	Close(ctx context.Context, q Querier, pvzId, closedBy string) (*models.Reception, error)
+	ListInProgressBefore(ctx context.Context, q Querier, before time.Time) ([]models.Reception, error)
 }
 
 type receptionRepositoryPsql struct {
@@ -22,41 +27,76 @@ func NewReceptionRepository(db *sql.DB) ReceptionRepository {
 	}
 }
 
-func (rr *receptionRepositoryPsql) GetByPvzId(q Querier, pvzId string) (*models.Reception, error) {
-	query := `SELECT * FROM receptions WHERE pvzId = $1 ORDER BY createdAt DESC LIMIT 1`
-	var scanReception models.Reception
-	err := q.QueryRow(query, pvzId).Scan(&scanReception.Id, &scanReception.DateTime, &scanReception.PvzId, &scanReception.Status)
+const receptionColumns = `id, createdAt, pvzId, status, openedBy, closedBy, closedAt`
+
+func scanReception(row *sql.Row) (*models.Reception, error) {
+	var rec models.Reception
+	err := row.Scan(
+		&rec.Id, &rec.DateTime, &rec.PvzId, &rec.Status,
+		&rec.OpenedBy, &rec.ClosedBy, &rec.ClosedAt,
+	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &scanReception, nil
+	return &rec, nil
 }
 
-func (rr *receptionRepositoryPsql) Create(q Querier, req models.Reception) (*models.Reception, error) {
-	query := `INSERT INTO receptions (pvzId, status) VALUES ($1,$2) RETURNING id, createdAt, pvzId, status`
-	var reception models.Reception
-	err := q.QueryRow(query, req.PvzId, req.Status).Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-	return &reception, nil
+func (rr *receptionRepositoryPsql) GetByPvzId(ctx context.Context, q Querier, pvzId string) (*models.Reception, error) {
+	ctx, span := tracing.StartQuery(ctx, "reception.GetByPvzId")
+	defer span.End()
+
+	query := `SELECT ` + receptionColumns + ` FROM receptions WHERE pvzId = $1 ORDER BY createdAt DESC LIMIT 1`
+	return scanReception(q.QueryRowContext(ctx, query, pvzId))
 }
 
-func (rr *receptionRepositoryPsql) SetStatus(q Querier, status, pvzId string) (*models.Reception, error) {
-	query := `UPDATE receptions SET status = $1 WHERE pvzId = $2 RETURNING id, createdAt, pvzId, status`
-	var reception models.Reception
-	err := q.QueryRow(query, status, pvzId).Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, nil
-	}
+func (rr *receptionRepositoryPsql) Create(ctx context.Context, q Querier, req models.Reception) (*models.Reception, error) {
+	ctx, span := tracing.StartQuery(ctx, "reception.Create")
+	defer span.End()
+
+	query := `INSERT INTO receptions (pvzId, status, openedBy) VALUES ($1, $2, $3) RETURNING ` + receptionColumns
+	return scanReception(q.QueryRowContext(ctx, query, req.PvzId, req.Status, req.OpenedBy))
+}
+
+func (rr *receptionRepositoryPsql) Close(ctx context.Context, q Querier, pvzId, closedBy string) (*models.Reception, error) {
+	ctx, span := tracing.StartQuery(ctx, "reception.Close")
+	defer span.End()
+
+	query := `UPDATE receptions SET status = $1, closedBy = $2, closedAt = $3
+		WHERE pvzId = $4 AND status = $5
+		RETURNING ` + receptionColumns
+	return scanReception(q.QueryRowContext(ctx, query,
+		reception.CloseStatus, sql.NullString{String: closedBy, Valid: closedBy != ""}, Now(), pvzId, reception.InProgressStatus,
+	))
+}
+
+func (rr *receptionRepositoryPsql) ListInProgressBefore(ctx context.Context, q Querier, before time.Time) ([]models.Reception, error) {
+	ctx, span := tracing.StartQuery(ctx, "reception.ListInProgressBefore")
+	defer span.End()
+
+	query := `SELECT ` + receptionColumns + ` FROM receptions
+		WHERE status = $1 AND createdAt < $2
+		ORDER BY createdAt`
+
+	rows, err := q.QueryContext(ctx, query, reception.InProgressStatus, before)
 	if err != nil {
 		return nil, err
 	}
-	return &reception, nil
+	defer rows.Close()
+
+	var result []models.Reception
+	for rows.Next() {
+		var rec models.Reception
+		err := rows.Scan(
+			&rec.Id, &rec.DateTime, &rec.PvzId, &rec.Status,
+			&rec.OpenedBy, &rec.ClosedBy, &rec.ClosedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rec)
+	}
+	return result, rows.Err()
 }
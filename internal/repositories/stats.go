@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"pvz/internal/models"
+	"pvz/internal/models/reception"
+	"pvz/internal/models/stats"
+	"pvz/internal/tracing"
+	"slices"
+	"strings"
+	"time"
+)
+
+type StatsRepository interface {
+	Aggregate(ctx context.Context, q Querier, groupBy []string, startDate, endDate *time.Time) ([]models.StatsRow, error)
+}
+
+type statsRepositoryPsql struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) StatsRepository {
+	return &statsRepositoryPsql{
+		db: db,
+	}
+}
+
+// statsDimensions lists the columns of statsFacts a caller may group by, in the order they are selected.
+// Dimensions that are not grouped by are selected as typed NULLs so every query scans the same way.
+var statsDimensions = []struct {
+	group  string
+	column string
+	null   string
+}{
+	{stats.GroupPvz, "pvzId", "NULL::uuid"},
+	{stats.GroupCity, "city", "NULL::varchar"},
+	{stats.GroupType, "type", "NULL::varchar"},
+	{stats.GroupDay, "day", "NULL::timestamp"},
+}
+
+// statsFacts has a row per product, or a single row for a reception without products. The window
+// applies to the opening of the reception; a product counts towards the day it was received.
+const statsFacts = `SELECT r.id AS receptionId, r.pvzId AS pvzId, p.city AS city, r.status AS status,
+			EXTRACT(EPOCH FROM r.closedAt - r.createdAt) AS duration,
+			pr.id AS productId, pr.type AS type,
+			date_trunc('day', COALESCE(pr.receivedAt, r.createdAt)) AS day
+		FROM receptions r
+		JOIN pvzs p ON p.id = r.pvzId
+		LEFT JOIN products pr ON pr.receptionId = r.id
+		WHERE ($1::timestamp IS NULL OR r.createdAt >= $1::timestamp)
+		  AND ($2::timestamp IS NULL OR r.createdAt <= $2::timestamp)`
+
+// Aggregate counts products and receptions per group. A reception with several products in a group
+// is ranked so that its duration enters the group average only once.
+func (sr *statsRepositoryPsql) Aggregate(ctx context.Context, q Querier, groupBy []string, startDate, endDate *time.Time) ([]models.StatsRow, error) {
+	ctx, span := tracing.StartQuery(ctx, "stats.Aggregate")
+	defer span.End()
+
+	columns := make(map[string]string, len(statsDimensions))
+	for _, dimension := range statsDimensions {
+		columns[dimension.group] = dimension.column
+	}
+	var grouped []string
+	for _, group := range groupBy {
+		grouped = append(grouped, columns[group])
+	}
+
+	selected := make([]string, 0, len(statsDimensions))
+	for _, dimension := range statsDimensions {
+		if slices.Contains(groupBy, dimension.group) {
+			selected = append(selected, dimension.column)
+		} else {
+			selected = append(selected, dimension.null)
+		}
+	}
+
+	partition := strings.Join(append([]string{"receptionId"}, grouped...), ", ")
+	query := `WITH facts AS (` + statsFacts + `),
+		ranked AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY ` + partition + `) AS nth FROM facts
+		)
+		SELECT ` + strings.Join(selected, ", ") + `,
+			COUNT(productId),
+			COUNT(DISTINCT receptionId),
+			COUNT(DISTINCT receptionId) FILTER (WHERE status = $3),
+			AVG(duration) FILTER (WHERE nth = 1)
+		FROM ranked`
+	if len(grouped) > 0 {
+		query += ` GROUP BY ` + strings.Join(grouped, ", ") + ` ORDER BY ` + strings.Join(grouped, ", ")
+	}
+
+	rows, err := q.QueryContext(ctx, query, startDate, endDate, reception.InProgressStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.StatsRow
+	for rows.Next() {
+		var row models.StatsRow
+		err := rows.Scan(
+			&row.PvzId, &row.City, &row.Type, &row.Day,
+			&row.Products, &row.Receptions, &row.OpenReceptions, &row.AvgDuration,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
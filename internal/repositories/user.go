@@ -1,14 +1,24 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"pvz/internal/models"
+	"pvz/internal/tracing"
+	"time"
 )
 
 type UserRepository interface {
-	GetByEmail(q Querier, email string) (*models.User, error)
-	Create(q Querier, user models.User) (string, error)
+	GetByEmail(ctx context.Context, q Querier, email string) (*models.User, error)
+	GetById(ctx context.Context, q Querier, id string) (*models.User, error)
+	Create(ctx context.Context, q Querier, user models.User) (string, error)
+	List(ctx context.Context, q Querier) ([]models.User, error)
+	UpdatePasswordHash(ctx context.Context, q Querier, userId, passwordHash string) error
+	RevokeSessions(ctx context.Context, q Querier, userId string, validAfter time.Time) error
+	SetLanguage(ctx context.Context, q Querier, userId, language string) error
+	AssignPvzs(ctx context.Context, q Querier, userId string, pvzIds []string) error
+	ListPvzIds(ctx context.Context, q Querier, userId string) ([]string, error)
 }
 
 type userRepositoryPsql struct {
@@ -21,15 +31,17 @@ func NewUserRepository(db *sql.DB) UserRepository {
 	}
 }
 
-func (ur *userRepositoryPsql) GetByEmail(q Querier, email string) (*models.User, error) {
-	query := `SELECT id, email, password_hash, role FROM users WHERE email = $1`
+const userColumns = `id, email, password_hash, role, sessionsValidAfter, language`
 
+func scanUser(row *sql.Row) (*models.User, error) {
 	var user models.User
-	err := q.QueryRow(query, email).Scan(
+	err := row.Scan(
 		&user.Id,
 		&user.Email,
 		&user.PasswordHash,
 		&user.Role,
+		&user.SessionsValidAfter,
+		&user.Language,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -40,12 +52,122 @@ func (ur *userRepositoryPsql) GetByEmail(q Querier, email string) (*models.User,
 	return &user, nil
 }
 
-func (ur *userRepositoryPsql) Create(q Querier, user models.User) (string, error) {
+func (ur *userRepositoryPsql) GetByEmail(ctx context.Context, q Querier, email string) (*models.User, error) {
+	ctx, span := tracing.StartQuery(ctx, "user.GetByEmail")
+	defer span.End()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
+	return scanUser(q.QueryRowContext(ctx, query, email))
+}
+
+func (ur *userRepositoryPsql) GetById(ctx context.Context, q Querier, id string) (*models.User, error) {
+	ctx, span := tracing.StartQuery(ctx, "user.GetById")
+	defer span.End()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	return scanUser(q.QueryRowContext(ctx, query, id))
+}
+
+func (ur *userRepositoryPsql) Create(ctx context.Context, q Querier, user models.User) (string, error) {
+	ctx, span := tracing.StartQuery(ctx, "user.Create")
+	defer span.End()
+
 	query := `INSERT INTO users (email, password_hash, role) VALUES ($1, $2, $3) RETURNING id`
 
-	err := q.QueryRow(query, user.Email, user.PasswordHash, user.Role).Scan(&user.Id)
+	err := q.QueryRowContext(ctx, query, user.Email, user.PasswordHash, user.Role).Scan(&user.Id)
 	if err != nil {
 		return "", err
 	}
 	return user.Id, nil
 }
+
+func (ur *userRepositoryPsql) List(ctx context.Context, q Querier) ([]models.User, error) {
+	ctx, span := tracing.StartQuery(ctx, "user.List")
+	defer span.End()
+
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY email`
+
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.Id, &user.Email, &user.PasswordHash, &user.Role, &user.SessionsValidAfter, &user.Language); err != nil {
+			return nil, err
+		}
+		result = append(result, user)
+	}
+	return result, rows.Err()
+}
+
+func (ur *userRepositoryPsql) UpdatePasswordHash(ctx context.Context, q Querier, userId, passwordHash string) error {
+	ctx, span := tracing.StartQuery(ctx, "user.UpdatePasswordHash")
+	defer span.End()
+
+	query := `UPDATE users SET password_hash = $1 WHERE id = $2`
+
+	_, err := q.ExecContext(ctx, query, passwordHash, userId)
+	return err
+}
+
+// RevokeSessions makes every token issued before validAfter unusable.
+func (ur *userRepositoryPsql) RevokeSessions(ctx context.Context, q Querier, userId string, validAfter time.Time) error {
+	ctx, span := tracing.StartQuery(ctx, "user.RevokeSessions")
+	defer span.End()
+
+	query := `UPDATE users SET sessionsValidAfter = $1 WHERE id = $2`
+
+	_, err := q.ExecContext(ctx, query, validAfter, userId)
+	return err
+}
+
+func (ur *userRepositoryPsql) SetLanguage(ctx context.Context, q Querier, userId, language string) error {
+	ctx, span := tracing.StartQuery(ctx, "user.SetLanguage")
+	defer span.End()
+
+	query := `UPDATE users SET language = $1 WHERE id = $2`
+
+	_, err := q.ExecContext(ctx, query, language, userId)
+	return err
+}
+
+func (ur *userRepositoryPsql) AssignPvzs(ctx context.Context, q Querier, userId string, pvzIds []string) error {
+	ctx, span := tracing.StartQuery(ctx, "user.AssignPvzs")
+	defer span.End()
+
+	query := `INSERT INTO user_pvzs (userId, pvzId) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	for _, pvzId := range pvzIds {
+		if _, err := q.ExecContext(ctx, query, userId, pvzId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ur *userRepositoryPsql) ListPvzIds(ctx context.Context, q Querier, userId string) ([]string, error) {
+	ctx, span := tracing.StartQuery(ctx, "user.ListPvzIds")
+	defer span.End()
+
+	query := `SELECT pvzId FROM user_pvzs WHERE userId = $1 ORDER BY pvzId`
+
+	rows, err := q.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var pvzId string
+		if err := rows.Scan(&pvzId); err != nil {
+			return nil, err
+		}
+		result = append(result, pvzId)
+	}
+	return result, rows.Err()
+}
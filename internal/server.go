@@ -6,6 +6,7 @@ import (
 	"github.com/labstack/echo"
 	"pvz/configs"
 	"pvz/internal/bootstrap"
+	"pvz/internal/middleware"
 )
 
 type Server struct {
@@ -15,6 +16,7 @@ type Server struct {
 
 func NewServer(cfg *configs.AppConfig, deps bootstrap.Deps) *Server {
 	e := echo.New()
+	e.Use(middleware.Trace, middleware.RequestId)
 
 	createApi(e, deps)
 	return &Server{
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"pvz/internal/logger"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/repositories"
+	"pvz/internal/tracing"
+	"pvz/pkg/errors"
+	"time"
+)
+
+type AuditService interface {
+	List(ctx context.Context, req audit.ListRequest) ([]audit.Record, error)
+}
+
+type auditServiceImpl struct {
+	auditRepo repositories.AuditRepository
+	conn      *sql.DB
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository, conn *sql.DB) AuditService {
+	return &auditServiceImpl{
+		auditRepo: auditRepo,
+		conn:      conn,
+	}
+}
+
+func (as *auditServiceImpl) List(ctx context.Context, req audit.ListRequest) ([]audit.Record, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.List")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("limit", req.Limit, "page", req.Page)
+	log.Info("starting List audit records")
+
+	if req.StartDate != nil && req.EndDate != nil && req.StartDate.After(*req.EndDate) {
+		log.Warn("start date is after end date")
+		return nil, errors.NewStartDateAfterEndDate()
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	req.StartDate, req.EndDate = utcTimePtr(req.StartDate), utcTimePtr(req.EndDate)
+
+	entries, err := as.auditRepo.List(ctx, as.conn, req, offset)
+	if err != nil {
+		log.Error("failed to fetch audit records", "err", err)
+		return nil, errors.NewInternalError()
+	}
+
+	result := make([]audit.Record, 0, len(entries))
+	for _, entry := range entries {
+		record := audit.Record{
+			Id:        entry.Id,
+			DateTime:  entry.DateTime,
+			ActorId:   nullStringPtr(entry.ActorId),
+			ActorRole: nullStringPtr(entry.ActorRole),
+			Action:    audit.Action(entry.Action),
+			PvzId:     nullStringPtr(entry.PvzId),
+			RequestId: nullStringPtr(entry.RequestId),
+			Ip:        nullStringPtr(entry.Ip),
+			Before:    entry.Before,
+			After:     entry.After,
+		}
+		if err := json.Unmarshal(entry.TargetIds, &record.TargetIds); err != nil {
+			log.Error("failed to decode audit target ids", "auditId", entry.Id, "err", err)
+			return nil, errors.NewInternalError()
+		}
+		result = append(result, record)
+	}
+
+	log.Info("successfully fetched audit records", "count", len(result))
+	return result, nil
+}
+
+// recordAudit writes the entry through q so it commits or rolls back together with the audited change.
+func recordAudit(ctx context.Context, q repositories.Querier, auditRepo repositories.AuditRepository, meta audit.Meta, action audit.Action, pvzId string, targetIds map[string]string, before, after any) error {
+	if targetIds == nil {
+		targetIds = map[string]string{}
+	}
+	targets, err := json.Marshal(targetIds)
+	if err != nil {
+		return err
+	}
+	beforeJson, err := marshalState(before)
+	if err != nil {
+		return err
+	}
+	afterJson, err := marshalState(after)
+	if err != nil {
+		return err
+	}
+
+	return auditRepo.Create(ctx, q, models.AuditEntry{
+		ActorId:   toNullString(meta.Actor.UserId),
+		ActorRole: toNullString(string(meta.Actor.Role)),
+		Action:    string(action),
+		PvzId:     toNullString(pvzId),
+		TargetIds: targets,
+		RequestId: toNullString(meta.RequestId),
+		Ip:        toNullString(meta.Ip),
+		Before:    beforeJson,
+		After:     afterJson,
+	})
+}
+
+func marshalState(state any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func nullFloatPtr(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// utcTimePtr turns a bound sent by the caller into the UTC wall time TIMESTAMP columns hold; Postgres
+// would drop its offset instead of converting it.
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
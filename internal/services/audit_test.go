@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/events"
+	"pvz/internal/logger"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
+	"pvz/internal/models/reception"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+)
+
+func TestAuditService_List(t *testing.T) {
+	logger.Init("debug")
+
+	t.Run("successful list", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		auditRepo := new(MockAuditRepo)
+		service := services.NewAuditService(auditRepo, db)
+
+		actorID := "550e8400-e29b-41d4-a716-446655440000"
+		req := audit.ListRequest{ActorId: &actorID, Page: 2, Limit: 10}
+
+		auditRepo.On("List", db, req, 10).Return([]models.AuditEntry{
+			{
+				Id:        1,
+				ActorId:   sql.NullString{String: actorID, Valid: true},
+				ActorRole: sql.NullString{String: "employee", Valid: true},
+				Action:    string(audit.ProductAdd),
+				TargetIds: []byte(`{"productId":"prod1"}`),
+				After:     []byte(`{"id":"prod1"}`),
+			},
+		}, nil).Once()
+
+		records, err := service.List(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, actorID, *records[0].ActorId)
+		require.Nil(t, records[0].PvzId)
+		require.Equal(t, "prod1", records[0].TargetIds["productId"])
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("filter with an offset is passed in UTC", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		auditRepo := new(MockAuditRepo)
+		service := services.NewAuditService(auditRepo, db)
+
+		moscow := time.FixedZone("MSK", 3*60*60)
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, moscow)
+		end := time.Date(2025, 1, 1, 12, 0, 0, 0, moscow)
+		wantStart := time.Date(2024, 12, 31, 21, 0, 0, 0, time.UTC)
+		wantEnd := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+		auditRepo.On("List", db, audit.ListRequest{StartDate: &wantStart, EndDate: &wantEnd, Page: 1, Limit: 10}, 0).
+			Return([]models.AuditEntry{}, nil).Once()
+
+		_, err := service.List(context.Background(), audit.ListRequest{StartDate: &start, EndDate: &end, Page: 1, Limit: 10})
+
+		require.NoError(t, err)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("start date after end date", func(t *testing.T) {
+		service := services.NewAuditService(nil, nil)
+
+		start := time.Now()
+		end := start.Add(-time.Hour)
+		_, err := service.List(context.Background(), audit.ListRequest{StartDate: &start, EndDate: &end, Page: 1, Limit: 10})
+
+		require.IsType(t, errors.StartDateAfterEndDate{}, err)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		auditRepo := new(MockAuditRepo)
+		service := services.NewAuditService(auditRepo, db)
+
+		auditRepo.On("List", db, mock.Anything, 0).
+			Return([]models.AuditEntry(nil), errors.NewInternalError()).Once()
+
+		_, err := service.List(context.Background(), audit.ListRequest{Page: 1, Limit: 10})
+
+		require.IsType(t, errors.InternalError{}, err)
+	})
+}
+
+func TestAuditRecordedWithMutation(t *testing.T) {
+	logger.Init("debug")
+
+	db, mockDB, _ := sqlmock.New()
+	defer db.Close()
+
+	pvzRepo := new(MockPvzRepo)
+	recRepo := new(MockReceptionRepo)
+	auditRepo := new(MockAuditRepo)
+	service := services.NewPvzService(pvzRepo, nil, recRepo, auditRepo, events.NewBus(1), db)
+
+	pvzID := "pvz123"
+	meta := audit.Meta{
+		Actor:     auth.Principal{UserId: "user1", Role: auth.Employee},
+		RequestId: "req1",
+		Ip:        "10.0.0.1",
+	}
+
+	mockDB.ExpectBegin()
+	pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), pvzID).Return(&models.Pvz{Id: pvzID}, nil).Once()
+	recRepo.On("GetByPvzId", mock.AnythingOfType("*sql.Tx"), pvzID).
+		Return(&models.Reception{Id: "rec1", PvzId: pvzID, Status: reception.InProgressStatus}, nil).Once()
+	recRepo.On("Close", mock.AnythingOfType("*sql.Tx"), pvzID, "user1").
+		Return(&models.Reception{Id: "rec1", PvzId: pvzID, Status: reception.CloseStatus}, nil).Once()
+	auditRepo.On("Create", mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(e models.AuditEntry) bool {
+		return e.ActorId.String == "user1" && e.Action == string(audit.ReceptionClose) &&
+			e.PvzId.String == pvzID && e.RequestId.String == "req1" && e.Ip.String == "10.0.0.1" &&
+			e.Before != nil && e.After != nil
+	})).Return(nil).Once()
+	mockDB.ExpectCommit()
+
+	_, err := service.CLoseLastReception(context.Background(), meta, pvzID)
+
+	require.NoError(t, err)
+	auditRepo.AssertExpectations(t)
+	require.NoError(t, mockDB.ExpectationsWereMet())
+}
@@ -1,53 +1,99 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"golang.org/x/crypto/bcrypt"
+	"math"
+	"pvz/configs"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/auth"
 	"pvz/internal/repositories"
 	"pvz/internal/tokens"
+	"pvz/internal/tracing"
 	"pvz/pkg/errors"
+	"strings"
+	"time"
 )
 
 type AuthService interface {
-	Login(req auth.LoginRequest) (string, error)
-	Register(req auth.RegisterRequest) (auth.RegisterResponse, error)
-	DummyLogin(req auth.DummyLoginRequest) (string, error)
+	Login(ctx context.Context, meta audit.Meta, req auth.LoginRequest) (string, error)
+	Register(ctx context.Context, meta audit.Meta, req auth.RegisterRequest) (auth.RegisterResponse, error)
+	CreateUser(ctx context.Context, meta audit.Meta, req auth.RegisterRequest) (auth.RegisterResponse, error)
+	DummyLogin(ctx context.Context, req auth.DummyLoginRequest) (string, error)
+	SetLanguage(ctx context.Context, meta audit.Meta, req auth.SetLanguageRequest) (string, error)
 }
+
+// dummyPasswordHash is checked for an unknown email, so that it takes as long to reject as a wrong password
+// and the timing does not tell which accounts exist. Its cost is the bcrypt.DefaultCost passwords are stored with.
+const dummyPasswordHash = "$2a$10$aRa9gZ36wE6DQbYWxx5KSOibwvMBc9ocTn78bLz6fpLFacC8J6u.K"
+
 type authServiceImpl struct {
-	userRepo repositories.UserRepository
-	conn     *sql.DB
+	userRepo    repositories.UserRepository
+	attemptRepo repositories.LoginAttemptRepository
+	auditRepo   repositories.AuditRepository
+	cfg         configs.AuthConfig
+	conn        *sql.DB
 }
 
-func NewAuthService(userRepo repositories.UserRepository, conn *sql.DB) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, attemptRepo repositories.LoginAttemptRepository, auditRepo repositories.AuditRepository, cfg configs.AuthConfig, conn *sql.DB) AuthService {
 	return &authServiceImpl{
-		userRepo: userRepo,
-		conn:     conn,
+		userRepo:    userRepo,
+		attemptRepo: attemptRepo,
+		auditRepo:   auditRepo,
+		cfg:         cfg,
+		conn:        conn,
 	}
 }
 
-func (as *authServiceImpl) Login(req auth.LoginRequest) (string, error) {
-	log := logger.Log.With("email", req.Email)
+func (as *authServiceImpl) Login(ctx context.Context, meta audit.Meta, req auth.LoginRequest) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", req.Email)
 	log.Info("attempting user login")
 
-	user, err := as.userRepo.GetByEmail(as.conn, req.Email)
+	now := repositories.Now()
+	subjects := as.loginSubjects(meta, req.Email)
+	if err := as.checkLoginBlocked(ctx, subjects, now); err != nil {
+		if blocked, ok := err.(errors.TooManyLoginAttempts); ok {
+			log.Warn("login attempts blocked", "retryAfter", blocked.RetryAfter)
+			return "", blocked
+		}
+		log.Error("failed to check login attempts", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	user, err := as.userRepo.GetByEmail(ctx, as.conn, req.Email)
 	if err != nil {
 		log.Error("failed to fetch user", "err", err)
 		return "", errors.NewInternalError()
 	}
 	if user == nil {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(req.Password))
 		log.Warn("user not found")
-		return "", errors.NewInvalidCredentials()
+		return "", as.loginFailed(ctx, subjects, now)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
 		log.Warn("invalid password")
-		return "", errors.NewInvalidCredentials()
+		return "", as.loginFailed(ctx, subjects, now)
+	}
+
+	if err := as.resetLoginFailures(ctx, subjects); err != nil {
+		log.Error("failed to reset login attempts", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	pvzIds, err := as.userRepo.ListPvzIds(ctx, as.conn, user.Id)
+	if err != nil {
+		log.Error("failed to fetch user pvzs", "err", err)
+		return "", errors.NewInternalError()
 	}
 
-	token, err := tokens.GenerateJwt(user.Id, user.Role)
+	token, err := tokens.GenerateJwt(user.Id, user.Role, user.Language.String, pvzIds...)
 	if err != nil {
 		log.Error("failed to generate jwt", "err", err)
 		return "", err
@@ -57,18 +103,51 @@ func (as *authServiceImpl) Login(req auth.LoginRequest) (string, error) {
 	return token, nil
 }
 
-func (as *authServiceImpl) Register(req auth.RegisterRequest) (auth.RegisterResponse, error) {
-	log := logger.Log.With("email", req.Email)
+// Register is the public sign-up: it only ever creates employees, and can be switched off entirely.
+func (as *authServiceImpl) Register(ctx context.Context, meta audit.Meta, req auth.RegisterRequest) (auth.RegisterResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", req.Email)
 	log.Info("attempting user registration")
 
-	tx, err := as.conn.Begin()
+	if as.cfg.Registration == configs.RegistrationDisabled {
+		log.Warn("public registration is disabled")
+		return auth.RegisterResponse{}, errors.NewFeatureDisabled("registration")
+	}
+	if req.Role != "" && auth.Role(req.Role) != auth.Employee {
+		log.Warn("public registration with a privileged role", "role", req.Role)
+		return auth.RegisterResponse{}, errors.NewAccessForbidden()
+	}
+	req.Role = string(auth.Employee)
+
+	return as.createUser(ctx, meta, req, audit.UserRegister)
+}
+
+// CreateUser creates a user with any role on behalf of an authenticated moderator.
+func (as *authServiceImpl) CreateUser(ctx context.Context, meta audit.Meta, req auth.RegisterRequest) (auth.RegisterResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.CreateUser")
+	defer span.End()
+
+	logger.FromContext(ctx).Info("attempting user creation", "email", req.Email, "role", req.Role)
+
+	if req.Role == "" {
+		req.Role = string(auth.Employee)
+	}
+	return as.createUser(ctx, meta, req, audit.UserCreate)
+}
+
+func (as *authServiceImpl) createUser(ctx context.Context, meta audit.Meta, req auth.RegisterRequest, action audit.Action) (auth.RegisterResponse, error) {
+	log := logger.FromContext(ctx).With("email", req.Email)
+
+	tx, err := as.conn.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error("failed to begin transaction", "err", err)
 		return auth.RegisterResponse{}, errors.NewInternalError()
 	}
 	defer tx.Rollback()
 
-	user, err := as.userRepo.GetByEmail(tx, req.Email)
+	user, err := as.userRepo.GetByEmail(ctx, tx, req.Email)
 	if err != nil {
 		log.Error("failed to check existing user", "err", err)
 		return auth.RegisterResponse{}, errors.NewInternalError()
@@ -89,12 +168,24 @@ func (as *authServiceImpl) Register(req auth.RegisterRequest) (auth.RegisterResp
 		PasswordHash: string(passwordHash),
 		Role:         auth.Role(req.Role),
 	}
-	userID, err := as.userRepo.Create(tx, newUser)
+	userID, err := as.userRepo.Create(ctx, tx, newUser)
 	if err != nil {
 		log.Error("failed to create user", "err", err)
 		return auth.RegisterResponse{}, errors.NewInternalError()
 	}
 
+	resp := auth.RegisterResponse{
+		Id:    userID,
+		Email: newUser.Email,
+		Role:  req.Role,
+	}
+
+	err = recordAudit(ctx, tx, as.auditRepo, meta, action, "", map[string]string{"userId": userID}, nil, resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return auth.RegisterResponse{}, errors.NewInternalError()
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Error("failed to commit transaction", "err", err)
 		return auth.RegisterResponse{}, errors.NewInternalError()
@@ -102,17 +193,21 @@ func (as *authServiceImpl) Register(req auth.RegisterRequest) (auth.RegisterResp
 
 	log.Info("user registered successfully", "user_id", userID, "role", req.Role)
 
-	return auth.RegisterResponse{
-		Id:    userID,
-		Email: newUser.Email,
-		Role:  req.Role,
-	}, nil
+	return resp, nil
 }
 
-func (as *authServiceImpl) DummyLogin(req auth.DummyLoginRequest) (string, error) {
-	log := logger.Log.With("role", req.Role)
+func (as *authServiceImpl) DummyLogin(ctx context.Context, req auth.DummyLoginRequest) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.DummyLogin")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("role", req.Role)
 	log.Info("starting dummy login")
 
+	if !as.cfg.DevMode {
+		log.Warn("dummy login is only available in dev mode")
+		return "", errors.NewFeatureDisabled("dummy login")
+	}
+
 	token, err := tokens.GenerateDummyJwt(auth.Role(req.Role))
 	if err != nil {
 		log.Error("failed to generate dummy jwt", "err", err)
@@ -122,3 +217,157 @@ func (as *authServiceImpl) DummyLogin(req auth.DummyLoginRequest) (string, error
 	log.Info("dummy login successful")
 	return token, nil
 }
+
+// SetLanguage stores the language errors are reported in for the caller. The preference travels
+// in the token, so a fresh one is returned; older tokens keep the previous language until they expire.
+func (as *authServiceImpl) SetLanguage(ctx context.Context, meta audit.Meta, req auth.SetLanguageRequest) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.SetLanguage")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("language", req.Language)
+	log.Info("starting SetLanguage")
+
+	tx, err := as.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return "", errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	user, err := as.userRepo.GetById(ctx, tx, meta.Actor.UserId)
+	if err != nil {
+		log.Error("failed to fetch user", "err", err)
+		return "", errors.NewInternalError()
+	}
+	if user == nil {
+		log.Warn("user not found")
+		return "", errors.NewObjectNotFound("user")
+	}
+
+	if err = as.userRepo.SetLanguage(ctx, tx, user.Id, req.Language); err != nil {
+		log.Error("failed to set language", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	pvzIds, err := as.userRepo.ListPvzIds(ctx, tx, user.Id)
+	if err != nil {
+		log.Error("failed to fetch user pvzs", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	before := map[string]string{"language": user.Language.String}
+	after := map[string]string{"language": req.Language}
+	err = recordAudit(ctx, tx, as.auditRepo, meta, audit.UserLanguageSet, "", map[string]string{"userId": user.Id}, before, after)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	token, err := tokens.GenerateJwt(user.Id, user.Role, req.Language, pvzIds...)
+	if err != nil {
+		log.Error("failed to generate jwt", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	log.Info("language set", "userId", user.Id)
+	return token, nil
+}
+
+// loginSubject is one of the keys failed logins are counted by.
+type loginSubject struct {
+	kind  string
+	value string
+	limit int
+}
+
+func (as *authServiceImpl) loginSubjects(meta audit.Meta, email string) []loginSubject {
+	subjects := []loginSubject{{kind: repositories.LoginAttemptEmail, value: strings.ToLower(email), limit: as.cfg.Login.MaxEmailFailures}}
+	if meta.Ip != "" {
+		subjects = append(subjects, loginSubject{kind: repositories.LoginAttemptIp, value: meta.Ip, limit: as.cfg.Login.MaxIpFailures})
+	}
+	return subjects
+}
+
+// checkLoginBlocked is run before the password is looked at, so a blocked caller learns nothing
+// about the credentials no matter what it sends.
+func (as *authServiceImpl) checkLoginBlocked(ctx context.Context, subjects []loginSubject, now time.Time) error {
+	var blockedUntil time.Time
+	for _, subject := range subjects {
+		attempt, err := as.attemptRepo.Get(ctx, as.conn, subject.kind, subject.value)
+		if err != nil {
+			return err
+		}
+		if attempt != nil && attempt.BlockedUntil.Valid && attempt.BlockedUntil.Time.After(blockedUntil) {
+			blockedUntil = attempt.BlockedUntil.Time
+		}
+	}
+	if !blockedUntil.After(now) {
+		return nil
+	}
+	retryAfter := int(math.Ceil(blockedUntil.Sub(now).Seconds()))
+	return errors.NewTooManyLoginAttempts(retryAfter)
+}
+
+func (as *authServiceImpl) registerLoginFailure(ctx context.Context, subjects []loginSubject, now time.Time) error {
+	log := logger.FromContext(ctx)
+	for _, subject := range subjects {
+		failures, err := as.attemptRepo.RegisterFailure(ctx, as.conn, subject.kind, subject.value, now, now.Add(-as.cfg.Login.FailureWindow))
+		if err != nil {
+			return err
+		}
+		block := as.loginBlockFor(subject, failures)
+		if block <= 0 {
+			continue
+		}
+		log.Warn("blocking login attempts", "kind", subject.kind, "failures", failures, "for", block)
+		if err = as.attemptRepo.Block(ctx, as.conn, subject.kind, subject.value, now.Add(block)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loginBlockFor locks the subject out once it reaches its limit. Before that, emails get a delay
+// doubling with every failure past DelayAfter; addresses are only locked out, since one address
+// may stand for a whole office behind NAT.
+func (as *authServiceImpl) loginBlockFor(subject loginSubject, failures int) time.Duration {
+	lockout := as.cfg.Login.Lockout
+	if subject.limit > 0 && failures >= subject.limit {
+		return lockout
+	}
+	if subject.kind != repositories.LoginAttemptEmail || as.cfg.Login.DelayBase <= 0 || failures < as.cfg.Login.DelayAfter {
+		return 0
+	}
+	delay := as.cfg.Login.DelayBase << min(failures-as.cfg.Login.DelayAfter, 16)
+	if lockout > 0 && delay > lockout {
+		return lockout
+	}
+	return delay
+}
+
+// resetLoginFailures clears only the email counter: a caller holding any valid account could otherwise wipe
+// the failures of its address between guesses. Address failures expire with FailureWindow instead.
+func (as *authServiceImpl) resetLoginFailures(ctx context.Context, subjects []loginSubject) error {
+	for _, subject := range subjects {
+		if subject.kind != repositories.LoginAttemptEmail {
+			continue
+		}
+		if err := as.attemptRepo.Reset(ctx, as.conn, subject.kind, subject.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (as *authServiceImpl) loginFailed(ctx context.Context, subjects []loginSubject, now time.Time) error {
+	if err := as.registerLoginFailure(ctx, subjects, now); err != nil {
+		logger.FromContext(ctx).Error("failed to register login failure", "err", err)
+		return errors.NewInternalError()
+	}
+	return errors.NewInvalidCredentials()
+}
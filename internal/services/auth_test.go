@@ -1,11 +1,13 @@
 package services_test
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"pvz/configs"
 	"pvz/internal/logger"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/mock"
@@ -13,9 +15,11 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/auth"
 	"pvz/internal/repositories"
 	"pvz/internal/services"
+	"pvz/internal/tokens"
 	"pvz/pkg/errors"
 )
 
@@ -23,16 +27,88 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) GetByEmail(q repositories.Querier, email string) (*models.User, error) {
+func (m *MockUserRepository) GetByEmail(ctx context.Context, q repositories.Querier, email string) (*models.User, error) {
 	args := m.Called(q, email)
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) Create(q repositories.Querier, user models.User) (string, error) {
+func (m *MockUserRepository) Create(ctx context.Context, q repositories.Querier, user models.User) (string, error) {
 	args := m.Called(q, user)
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockUserRepository) List(ctx context.Context, q repositories.Querier) ([]models.User, error) {
+	args := m.Called(q)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetById(ctx context.Context, q repositories.Querier, id string) (*models.User, error) {
+	args := m.Called(q, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeSessions(ctx context.Context, q repositories.Querier, userId string, validAfter time.Time) error {
+	args := m.Called(q, userId, validAfter)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetLanguage(ctx context.Context, q repositories.Querier, userId, language string) error {
+	args := m.Called(q, userId, language)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePasswordHash(ctx context.Context, q repositories.Querier, userId, passwordHash string) error {
+	args := m.Called(q, userId, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) AssignPvzs(ctx context.Context, q repositories.Querier, userId string, pvzIds []string) error {
+	args := m.Called(q, userId, pvzIds)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListPvzIds(ctx context.Context, q repositories.Querier, userId string) ([]string, error) {
+	args := m.Called(q, userId)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockLoginAttemptRepo struct{ mock.Mock }
+
+// newMockLoginAttemptRepo never blocks and accepts any bookkeeping, for tests that don't care about throttling.
+func newMockLoginAttemptRepo() *MockLoginAttemptRepo {
+	m := new(MockLoginAttemptRepo)
+	m.On("Get", mock.Anything, mock.Anything, mock.Anything).Return((*models.LoginAttempt)(nil), nil).Maybe()
+	m.On("RegisterFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+	m.On("Block", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("Reset", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *MockLoginAttemptRepo) Get(ctx context.Context, q repositories.Querier, kind, subject string) (*models.LoginAttempt, error) {
+	args := m.Called(q, kind, subject)
+	return args.Get(0).(*models.LoginAttempt), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepo) RegisterFailure(ctx context.Context, q repositories.Querier, kind, subject string, now, windowStart time.Time) (int, error) {
+	args := m.Called(q, kind, subject, now, windowStart)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepo) Block(ctx context.Context, q repositories.Querier, kind, subject string, until time.Time) error {
+	args := m.Called(q, kind, subject, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepo) Reset(ctx context.Context, q repositories.Querier, kind, subject string) error {
+	args := m.Called(q, kind, subject)
+	return args.Error(0)
+}
+
+var testAuthConfig = configs.AuthConfig{Registration: configs.RegistrationEmployees, DevMode: true}
+
 func LoadTestEnv() {
 	os.Setenv("DB_HOST", "test_host")
 	os.Setenv("DB_NAME", "test_db")
@@ -47,7 +123,7 @@ func TestAuthService_Login(t *testing.T) {
 	_, _ = configs.LoadConfig()
 	mockRepo := new(MockUserRepository)
 	db, _ := sql.Open("postgres", "") // dummy connection
-	service := services.NewAuthService(mockRepo, db)
+	service := services.NewAuthService(mockRepo, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, db)
 
 	t.Run("success login", func(t *testing.T) {
 		email := "user@avito.com"
@@ -60,14 +136,18 @@ func TestAuthService_Login(t *testing.T) {
 			PasswordHash: string(hashedPassword),
 			Role:         "employee",
 		}, nil)
+		mockRepo.On("ListPvzIds", db, "123").Return([]string{"pvz1"}, nil).Once()
 
-		token, err := service.Login(auth.LoginRequest{
+		token, err := service.Login(context.Background(), audit.Meta{}, auth.LoginRequest{
 			Email:    email,
 			Password: password,
 		})
 
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
+		claims, err := tokens.ParseJwt(token)
+		require.NoError(t, err)
+		require.Equal(t, []string{"pvz1"}, claims.PvzIds)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -77,7 +157,7 @@ func TestAuthService_Login(t *testing.T) {
 			PasswordHash: "invalid_hash",
 		}, nil)
 
-		_, err := service.Login(auth.LoginRequest{
+		_, err := service.Login(context.Background(), audit.Meta{}, auth.LoginRequest{
 			Email:    email,
 			Password: "wrong_password",
 		})
@@ -89,21 +169,130 @@ func TestAuthService_Login(t *testing.T) {
 		email := "notfound@avito.com"
 		mockRepo.On("GetByEmail", db, email).Return((*models.User)(nil), nil)
 
-		_, err := service.Login(auth.LoginRequest{Email: email})
+		hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+		started := time.Now()
+		_ = bcrypt.CompareHashAndPassword(hash, []byte("wrong_password"))
+		wrongPassword := time.Since(started)
+
+		started = time.Now()
+		_, err := service.Login(context.Background(), audit.Meta{}, auth.LoginRequest{Email: email, Password: "wrong_password"})
+		unknownEmail := time.Since(started)
 
 		require.ErrorIs(t, err, errors.NewInvalidCredentials())
+		require.Greater(t, unknownEmail, wrongPassword/2, "an unknown email is checked against a hash too")
 	})
 
 	t.Run("database error", func(t *testing.T) {
 		email := "error@avito.com"
 		mockRepo.On("GetByEmail", db, email).Return((*models.User)(nil), errors.NewInternalError())
 
-		_, err := service.Login(auth.LoginRequest{Email: email})
+		_, err := service.Login(context.Background(), audit.Meta{}, auth.LoginRequest{Email: email})
 
 		require.ErrorIs(t, err, errors.NewInternalError())
 	})
 }
 
+func TestAuthService_LoginThrottling(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+	db, _ := sql.Open("postgres", "") // dummy connection
+
+	cfg := testAuthConfig
+	cfg.Login = configs.LoginProtectionConfig{
+		MaxEmailFailures: 5,
+		MaxIpFailures:    20,
+		DelayAfter:       3,
+		DelayBase:        time.Second,
+		FailureWindow:    15 * time.Minute,
+		Lockout:          15 * time.Minute,
+	}
+	meta := audit.Meta{Ip: "10.0.0.1"}
+	email := "User@avito.com"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	stored := &models.User{Id: "123", Email: email, PasswordHash: string(hashedPassword), Role: auth.Employee}
+
+	t.Run("blocked caller is rejected before the password is checked", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		attemptRepo := new(MockLoginAttemptRepo)
+		service := services.NewAuthService(userRepo, attemptRepo, newMockAuditRepo(), cfg, db)
+
+		attemptRepo.On("Get", db, repositories.LoginAttemptEmail, "user@avito.com").Return(&models.LoginAttempt{
+			BlockedUntil: sql.NullTime{Time: time.Now().UTC().Add(90 * time.Second), Valid: true},
+		}, nil).Once()
+		attemptRepo.On("Get", db, repositories.LoginAttemptIp, meta.Ip).Return((*models.LoginAttempt)(nil), nil).Once()
+
+		_, err := service.Login(context.Background(), meta, auth.LoginRequest{Email: email, Password: "password123"})
+
+		var blocked errors.TooManyLoginAttempts
+		require.ErrorAs(t, err, &blocked)
+		require.InDelta(t, 90, blocked.RetryAfter, 1)
+		userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired block lets the caller in and resets the email counter only", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		attemptRepo := new(MockLoginAttemptRepo)
+		service := services.NewAuthService(userRepo, attemptRepo, newMockAuditRepo(), cfg, db)
+
+		attemptRepo.On("Get", db, repositories.LoginAttemptEmail, "user@avito.com").Return(&models.LoginAttempt{
+			Failures:     4,
+			BlockedUntil: sql.NullTime{Time: time.Now().UTC().Add(-time.Second), Valid: true},
+		}, nil).Once()
+		attemptRepo.On("Get", db, repositories.LoginAttemptIp, meta.Ip).Return((*models.LoginAttempt)(nil), nil).Once()
+		userRepo.On("GetByEmail", db, email).Return(stored, nil).Once()
+		attemptRepo.On("Reset", db, repositories.LoginAttemptEmail, "user@avito.com").Return(nil).Once()
+		userRepo.On("ListPvzIds", db, "123").Return([]string{}, nil).Once()
+
+		token, err := service.Login(context.Background(), meta, auth.LoginRequest{Email: email, Password: "password123"})
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+		attemptRepo.AssertExpectations(t)
+		attemptRepo.AssertNotCalled(t, "Reset", db, repositories.LoginAttemptIp, meta.Ip)
+	})
+
+	tests := []struct {
+		name          string
+		emailFailures int
+		ipFailures    int
+		wantEmailFor  time.Duration
+		wantIpFor     time.Duration
+	}{
+		{name: "first failures are free", emailFailures: 2, ipFailures: 2},
+		{name: "delay starts after threshold", emailFailures: 3, ipFailures: 3, wantEmailFor: time.Second},
+		{name: "delay doubles", emailFailures: 4, ipFailures: 4, wantEmailFor: 2 * time.Second},
+		{name: "email lockout", emailFailures: 5, ipFailures: 5, wantEmailFor: 15 * time.Minute},
+		{name: "ip lockout", emailFailures: 1, ipFailures: 20, wantIpFor: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			attemptRepo := new(MockLoginAttemptRepo)
+			service := services.NewAuthService(userRepo, attemptRepo, newMockAuditRepo(), cfg, db)
+
+			attemptRepo.On("Get", db, mock.Anything, mock.Anything).Return((*models.LoginAttempt)(nil), nil)
+			userRepo.On("GetByEmail", db, email).Return(stored, nil).Once()
+			attemptRepo.On("RegisterFailure", db, repositories.LoginAttemptEmail, "user@avito.com", mock.Anything, mock.MatchedBy(func(windowStart time.Time) bool {
+				return time.Now().UTC().Sub(windowStart) >= cfg.Login.FailureWindow
+			})).Return(tt.emailFailures, nil).Once()
+			attemptRepo.On("RegisterFailure", db, repositories.LoginAttemptIp, meta.Ip, mock.Anything, mock.Anything).Return(tt.ipFailures, nil).Once()
+
+			blockedFor := map[string]time.Duration{}
+			attemptRepo.On("Block", db, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				blockedFor[args.String(1)] = time.Until(args.Get(3).(time.Time))
+			}).Return(nil).Maybe()
+
+			_, err := service.Login(context.Background(), meta, auth.LoginRequest{Email: email, Password: "wrong_password"})
+			require.ErrorIs(t, err, errors.NewInvalidCredentials())
+
+			require.InDelta(t, tt.wantEmailFor.Seconds(), blockedFor[repositories.LoginAttemptEmail].Seconds(), 1)
+			require.InDelta(t, tt.wantIpFor.Seconds(), blockedFor[repositories.LoginAttemptIp].Seconds(), 1)
+			attemptRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func NewTestDB() (*sql.DB, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -119,14 +308,21 @@ func TestAuthService_DummyLogin(t *testing.T) {
 	LoadTestEnv()
 	_, _ = configs.LoadConfig()
 	mockRepo := new(MockUserRepository)
-	service := services.NewAuthService(mockRepo, db)
+	service := services.NewAuthService(mockRepo, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, db)
 
 	t.Run("success moderator login", func(t *testing.T) {
-		token, err := service.DummyLogin(auth.DummyLoginRequest{Role: "moderator"})
+		token, err := service.DummyLogin(context.Background(), auth.DummyLoginRequest{Role: "moderator"})
 		require.NoError(t, err)
 		require.Contains(t, token, "ey")
 	})
 
+	t.Run("disabled outside dev mode", func(t *testing.T) {
+		service := services.NewAuthService(mockRepo, newMockLoginAttemptRepo(), newMockAuditRepo(), configs.AuthConfig{}, db)
+
+		_, err := service.DummyLogin(context.Background(), auth.DummyLoginRequest{Role: "moderator"})
+
+		require.IsType(t, errors.FeatureDisabled{}, err)
+	})
 }
 
 func TestAuthService_Register(t *testing.T) {
@@ -139,7 +335,7 @@ func TestAuthService_Register(t *testing.T) {
 		defer db.Close()
 
 		mockRepo := new(MockUserRepository)
-		service := services.NewAuthService(mockRepo, db)
+		service := services.NewAuthService(mockRepo, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, db)
 
 		req := auth.RegisterRequest{
 			Email:    "test@avito.com",
@@ -159,7 +355,7 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockDB.ExpectCommit()
 
-		resp, err := service.Register(req)
+		resp, err := service.Register(context.Background(), audit.Meta{}, req)
 
 		require.NoError(t, err)
 		require.Equal(t, "user123", resp.Id)
@@ -167,19 +363,119 @@ func TestAuthService_Register(t *testing.T) {
 		require.NoError(t, mockDB.ExpectationsWereMet())
 	})
 
+	t.Run("moderator self-registration forbidden", func(t *testing.T) {
+		service := services.NewAuthService(nil, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, nil)
+
+		_, err := service.Register(context.Background(), audit.Meta{}, auth.RegisterRequest{
+			Email: "mod@avito.com", Password: "validpassword123", Role: "moderator",
+		})
+
+		require.IsType(t, errors.AccessForbidden{}, err)
+	})
+
+	t.Run("registration disabled", func(t *testing.T) {
+		service := services.NewAuthService(nil, newMockLoginAttemptRepo(), newMockAuditRepo(), configs.AuthConfig{Registration: configs.RegistrationDisabled}, nil)
+
+		_, err := service.Register(context.Background(), audit.Meta{}, auth.RegisterRequest{
+			Email: "new@avito.com", Password: "validpassword123",
+		})
+
+		require.IsType(t, errors.FeatureDisabled{}, err)
+	})
+
 	t.Run("transaction begin error", func(t *testing.T) {
 		db, mockDB := NewTestDB()
 		defer db.Close()
 
-		service := services.NewAuthService(nil, db)
+		service := services.NewAuthService(nil, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, db)
 		req := auth.RegisterRequest{Email: "error@example.com"}
 
 		mockDB.ExpectBegin().WillReturnError(errors.NewInternalError())
 
-		_, err := service.Register(req)
+		_, err := service.Register(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "internal error")
 		require.NoError(t, mockDB.ExpectationsWereMet())
 	})
 }
+
+func TestAuthService_CreateUser(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	db, mockDB := NewTestDB()
+	defer db.Close()
+
+	mockRepo := new(MockUserRepository)
+	auditRepo := new(MockAuditRepo)
+	service := services.NewAuthService(mockRepo, newMockLoginAttemptRepo(), auditRepo, configs.AuthConfig{Registration: configs.RegistrationDisabled}, db)
+
+	mockDB.ExpectBegin()
+	mockRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "mod@avito.com").Return((*models.User)(nil), nil).Once()
+	mockRepo.On("Create", mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(user models.User) bool {
+		return user.Role == auth.Moderator
+	})).Return("user123", nil).Once()
+	auditRepo.On("Create", mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(e models.AuditEntry) bool {
+		return e.Action == string(audit.UserCreate) && e.ActorId.String == "mod1"
+	})).Return(nil).Once()
+	mockDB.ExpectCommit()
+
+	resp, err := service.CreateUser(context.Background(), audit.Meta{Actor: auth.Principal{UserId: "mod1", Role: auth.Moderator}},
+		auth.RegisterRequest{Email: "mod@avito.com", Password: "validpassword123", Role: "moderator"})
+
+	require.NoError(t, err)
+	require.Equal(t, "moderator", resp.Role)
+	auditRepo.AssertExpectations(t)
+	require.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+func TestAuthService_SetLanguage(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	meta := audit.Meta{Actor: auth.Principal{UserId: "user1", Role: auth.Employee}}
+
+	t.Run("stores the language and issues a token carrying it", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		service := services.NewAuthService(userRepo, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "user1").
+			Return(&models.User{Id: "user1", Role: auth.Employee}, nil).Once()
+		userRepo.On("SetLanguage", mock.AnythingOfType("*sql.Tx"), "user1", "ru").Return(nil).Once()
+		userRepo.On("ListPvzIds", mock.AnythingOfType("*sql.Tx"), "user1").Return([]string{"pvz1"}, nil).Once()
+		mockDB.ExpectCommit()
+
+		token, err := service.SetLanguage(context.Background(), meta, auth.SetLanguageRequest{Language: "ru"})
+		require.NoError(t, err)
+
+		claims, err := tokens.ParseJwt(token)
+		require.NoError(t, err)
+		require.Equal(t, "ru", claims.Principal().Language)
+		require.Equal(t, []string{"pvz1"}, claims.PvzIds)
+		userRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("dummy login user has no stored preference", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		service := services.NewAuthService(userRepo, newMockLoginAttemptRepo(), newMockAuditRepo(), testAuthConfig, db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "user1").Return((*models.User)(nil), nil).Once()
+		mockDB.ExpectRollback()
+
+		_, err := service.SetLanguage(context.Background(), meta, auth.SetLanguageRequest{Language: "en"})
+		require.Equal(t, errors.NewObjectNotFound("user"), err)
+		userRepo.AssertExpectations(t)
+	})
+}
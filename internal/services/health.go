@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/models/health"
+	"pvz/internal/repositories"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+type HealthService interface {
+	Live(ctx context.Context) health.Report
+	Ready(ctx context.Context) health.Report
+	Details(ctx context.Context) health.DetailedReport
+	MarkShuttingDown()
+}
+
+type healthServiceImpl struct {
+	migrationRepo   repositories.MigrationRepository
+	conn            *sql.DB
+	expectedVersion uint
+	dbTimeout       time.Duration
+	startedAt       time.Time
+	shuttingDown    atomic.Bool
+}
+
+func NewHealthService(migrationRepo repositories.MigrationRepository, expectedVersion uint, dbTimeout time.Duration, conn *sql.DB) HealthService {
+	return &healthServiceImpl{
+		migrationRepo:   migrationRepo,
+		conn:            conn,
+		expectedVersion: expectedVersion,
+		dbTimeout:       dbTimeout,
+		startedAt:       time.Now(),
+	}
+}
+
+func (hs *healthServiceImpl) Live(ctx context.Context) health.Report {
+	return health.Report{
+		Status: health.StatusUp,
+		Checks: map[string]health.Check{"process": {Status: health.StatusUp}},
+	}
+}
+
+func (hs *healthServiceImpl) Ready(ctx context.Context) health.Report {
+	log := logger.FromContext(ctx)
+
+	checks := map[string]health.Check{
+		"shutdown":   hs.checkShutdown(),
+		"database":   hs.checkDatabase(ctx),
+		"migrations": hs.checkMigrations(ctx),
+	}
+
+	report := health.Report{Status: health.StatusUp, Checks: checks}
+	for name, check := range checks {
+		if check.Status != health.StatusUp {
+			log.Warn("readiness check failed", "check", name, "err", check.Error)
+			report.Status = health.StatusDown
+		}
+	}
+	return report
+}
+
+func (hs *healthServiceImpl) Details(ctx context.Context) health.DetailedReport {
+	stats := hs.conn.Stats()
+	return health.DetailedReport{
+		Report: hs.Ready(ctx),
+		Build:  hs.buildInfo(),
+		Pool: health.DBStats{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDuration:       stats.WaitDuration.String(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		},
+	}
+}
+
+func (hs *healthServiceImpl) MarkShuttingDown() {
+	hs.shuttingDown.Store(true)
+}
+
+func (hs *healthServiceImpl) checkShutdown() health.Check {
+	if hs.shuttingDown.Load() {
+		return health.Check{Status: health.StatusDown, Error: "shutting down"}
+	}
+	return health.Check{Status: health.StatusUp}
+}
+
+func (hs *healthServiceImpl) checkDatabase(ctx context.Context) health.Check {
+	ctx, cancel := context.WithTimeout(ctx, hs.dbTimeout)
+	defer cancel()
+
+	if err := hs.conn.PingContext(ctx); err != nil {
+		logger.FromContext(ctx).Warn("database check failed", "err", err)
+		return health.Check{Status: health.StatusDown, Error: health.ErrorUnavailable}
+	}
+	return health.Check{Status: health.StatusUp}
+}
+
+func (hs *healthServiceImpl) checkMigrations(ctx context.Context) health.Check {
+	ctx, cancel := context.WithTimeout(ctx, hs.dbTimeout)
+	defer cancel()
+
+	log := logger.FromContext(ctx)
+	version, dirty, err := hs.migrationRepo.GetVersion(ctx, hs.conn)
+	if err != nil {
+		log.Warn("migrations check failed", "err", err)
+		return health.Check{Status: health.StatusDown, Error: health.ErrorUnavailable}
+	}
+	if dirty {
+		log.Warn("migrations check failed: dirty migration", "version", version)
+		return health.Check{Status: health.StatusDown, Error: health.ErrorUnavailable}
+	}
+	if version != hs.expectedVersion {
+		log.Warn("migrations check failed: unexpected schema version", "version", version, "expected", hs.expectedVersion)
+		return health.Check{Status: health.StatusDown, Error: health.ErrorUnavailable}
+	}
+	return health.Check{Status: health.StatusUp}
+}
+
+func (hs *healthServiceImpl) buildInfo() health.Build {
+	build := health.Build{
+		Version:   configs.Version,
+		GoVersion: runtime.Version(),
+		StartedAt: hs.startedAt,
+		Uptime:    time.Since(hs.startedAt).Round(time.Second).String(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				build.Revision = setting.Value
+			}
+		}
+	}
+	return build
+}
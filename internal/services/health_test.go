@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/logger"
+	"pvz/internal/mocks"
+	"pvz/internal/models/health"
+	"pvz/internal/services"
+)
+
+func TestHealthService_Ready(t *testing.T) {
+	logger.Init("debug")
+
+	tests := []struct {
+		name         string
+		pingErr      error
+		version      uint
+		dirty        bool
+		versionErr   error
+		shuttingDown bool
+		wantStatus   string
+		wantFailed   string
+		wantError    string
+	}{
+		{name: "ready", version: 3, wantStatus: health.StatusUp},
+		{name: "database unavailable", pingErr: errors.New("connection refused"), version: 3, wantStatus: health.StatusDown, wantFailed: "database", wantError: health.ErrorUnavailable},
+		{name: "schema behind", version: 2, wantStatus: health.StatusDown, wantFailed: "migrations", wantError: health.ErrorUnavailable},
+		{name: "dirty migration", version: 3, dirty: true, wantStatus: health.StatusDown, wantFailed: "migrations", wantError: health.ErrorUnavailable},
+		{name: "version query failed", versionErr: errors.New("no table"), wantStatus: health.StatusDown, wantFailed: "migrations", wantError: health.ErrorUnavailable},
+		{name: "shutting down", version: 3, shuttingDown: true, wantStatus: health.StatusDown, wantFailed: "shutdown", wantError: "shutting down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mockDB, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			defer db.Close()
+
+			migrationRepo := mocks.NewMigrationRepository(t)
+			service := services.NewHealthService(migrationRepo, 3, time.Second, db)
+			if tt.shuttingDown {
+				service.MarkShuttingDown()
+			}
+
+			mockDB.ExpectPing().WillReturnError(tt.pingErr)
+			migrationRepo.On("GetVersion", mock.Anything, db).Return(tt.version, tt.dirty, tt.versionErr).Once()
+
+			report := service.Ready(context.Background())
+
+			require.Equal(t, tt.wantStatus, report.Status)
+			for name, check := range report.Checks {
+				if name == tt.wantFailed {
+					require.Equal(t, health.StatusDown, check.Status)
+					require.Equal(t, tt.wantError, check.Error, "the cause is logged, not reported")
+				} else {
+					require.Equal(t, health.StatusUp, check.Status, name)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthService_Details(t *testing.T) {
+	logger.Init("debug")
+
+	db, mockDB, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	defer db.Close()
+
+	migrationRepo := mocks.NewMigrationRepository(t)
+	service := services.NewHealthService(migrationRepo, 3, time.Second, db)
+
+	mockDB.ExpectPing()
+	migrationRepo.On("GetVersion", mock.Anything, db).Return(uint(3), false, nil).Once()
+
+	report := service.Details(context.Background())
+
+	require.Equal(t, health.StatusUp, report.Status)
+	require.NotEmpty(t, report.Build.Version)
+	require.NotEmpty(t, report.Build.GoVersion)
+	require.False(t, report.Build.StartedAt.IsZero())
+}
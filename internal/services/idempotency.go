@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/models"
+	"pvz/internal/models/idempotency"
+	"pvz/internal/repositories"
+	"pvz/internal/tracing"
+	"pvz/pkg/errors"
+	"sync"
+	"time"
+)
+
+const (
+	// running requests renew their keys, so a key in progress that was not renewed for a few
+	// intervals belongs to a request that died before completing or releasing it
+	idempotencyStaleAfter = 3 * configs.DefaultIdempotencyRenewInterval
+	idempotencySweepEvery = time.Minute
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, caller, key, requestHash string) (*idempotency.Response, error)
+	Renew(ctx context.Context, caller, key string) error
+	Complete(ctx context.Context, caller, key string, resp idempotency.Response) error
+	Release(ctx context.Context, caller, key string) error
+}
+
+type idempotencyServiceImpl struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	ttl             time.Duration
+	conn            *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository, ttl time.Duration, conn *sql.DB) IdempotencyService {
+	return &idempotencyServiceImpl{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+		conn:            conn,
+		lastSweep:       time.Now(),
+	}
+}
+
+// Begin claims the key for the request. It returns nil when the request should be executed, or
+// the stored response when it is a repeat of a completed one.
+func (is *idempotencyServiceImpl) Begin(ctx context.Context, caller, key, requestHash string) (*idempotency.Response, error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Begin")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("idempotencyKey", key)
+
+	now := repositories.Now()
+	is.sweep(ctx, now)
+
+	reserved, err := is.idempotencyRepo.Reserve(ctx, is.conn, models.IdempotencyKey{
+		Caller:      caller,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(is.ttl),
+	}, now.Add(-idempotencyStaleAfter))
+	if err != nil {
+		log.Error("failed to reserve idempotency key", "err", err)
+		return nil, errors.NewInternalError()
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	stored, err := is.idempotencyRepo.Get(ctx, is.conn, caller, key)
+	if err != nil {
+		log.Error("failed to get idempotency key", "err", err)
+		return nil, errors.NewInternalError()
+	}
+	if stored == nil {
+		// released by its owner between our reserve and get: the client can simply retry
+		log.Warn("idempotency key released concurrently")
+		return nil, errors.NewIdempotencyKeyInProgress()
+	}
+	if stored.RequestHash != requestHash {
+		log.Warn("idempotency key reused with a different request")
+		return nil, errors.NewIdempotencyKeyReused()
+	}
+	if !stored.Status.Valid {
+		log.Warn("idempotency key is in progress")
+		return nil, errors.NewIdempotencyKeyInProgress()
+	}
+
+	log.Info("replaying stored response", "status", stored.Status.Int32)
+	return &idempotency.Response{
+		Status:      int(stored.Status.Int32),
+		ContentType: stored.ContentType.String,
+		Body:        stored.ResponseBody,
+	}, nil
+}
+
+// Renew keeps the key claimed by a request that is still running.
+func (is *idempotencyServiceImpl) Renew(ctx context.Context, caller, key string) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Renew")
+	defer span.End()
+
+	if err := is.idempotencyRepo.Renew(ctx, is.conn, caller, key, repositories.Now()); err != nil {
+		logger.FromContext(ctx).Error("failed to renew idempotency key", "idempotencyKey", key, "err", err)
+		return errors.NewInternalError()
+	}
+	return nil
+}
+
+func (is *idempotencyServiceImpl) Complete(ctx context.Context, caller, key string, resp idempotency.Response) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Complete")
+	defer span.End()
+
+	err := is.idempotencyRepo.Complete(ctx, is.conn, caller, key, resp.Status, resp.ContentType, resp.Body)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to store idempotent response", "idempotencyKey", key, "err", err)
+		return errors.NewInternalError()
+	}
+	return nil
+}
+
+// Release gives the key up after a failed request, so a retry executes it again.
+func (is *idempotencyServiceImpl) Release(ctx context.Context, caller, key string) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Release")
+	defer span.End()
+
+	if err := is.idempotencyRepo.Delete(ctx, is.conn, caller, key); err != nil {
+		logger.FromContext(ctx).Error("failed to release idempotency key", "idempotencyKey", key, "err", err)
+		return errors.NewInternalError()
+	}
+	return nil
+}
+
+func (is *idempotencyServiceImpl) sweep(ctx context.Context, now time.Time) {
+	is.mu.Lock()
+	if now.Sub(is.lastSweep) < idempotencySweepEvery {
+		is.mu.Unlock()
+		return
+	}
+	is.lastSweep = now
+	is.mu.Unlock()
+
+	if err := is.idempotencyRepo.DeleteExpired(ctx, is.conn, now); err != nil {
+		logger.FromContext(ctx).Warn("failed to delete expired idempotency keys", "err", err)
+	}
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/logger"
+	"pvz/internal/mocks"
+	"pvz/internal/models"
+	"pvz/internal/models/idempotency"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	logger.Init("debug")
+
+	completed := &models.IdempotencyKey{
+		Caller:       "user:1",
+		Key:          "key1",
+		RequestHash:  "hash1",
+		Status:       sql.NullInt32{Int32: 201, Valid: true},
+		ContentType:  sql.NullString{String: "application/json", Valid: true},
+		ResponseBody: []byte(`{"id":"p1"}`),
+	}
+
+	tests := []struct {
+		name        string
+		requestHash string
+		reserved    bool
+		stored      *models.IdempotencyKey
+		want        *idempotency.Response
+		wantErr     error
+	}{
+		{
+			name:        "new key runs the request",
+			requestHash: "hash1",
+			reserved:    true,
+		},
+		{
+			name:        "repeat replays the stored response",
+			requestHash: "hash1",
+			stored:      completed,
+			want:        &idempotency.Response{Status: 201, ContentType: "application/json", Body: []byte(`{"id":"p1"}`)},
+		},
+		{
+			name:        "different body is rejected",
+			requestHash: "hash2",
+			stored:      completed,
+			wantErr:     errors.NewIdempotencyKeyReused(),
+		},
+		{
+			name:        "request still in progress",
+			requestHash: "hash1",
+			stored:      &models.IdempotencyKey{Caller: "user:1", Key: "key1", RequestHash: "hash1"},
+			wantErr:     errors.NewIdempotencyKeyInProgress(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewIdempotencyRepository(t)
+			service := services.NewIdempotencyService(repo, time.Hour, nil)
+
+			repo.On("Reserve", mock.Anything, mock.Anything, mock.MatchedBy(func(k models.IdempotencyKey) bool {
+				return k.Caller == "user:1" && k.Key == "key1" && k.RequestHash == tt.requestHash &&
+					k.ExpiresAt.Sub(k.CreatedAt) == time.Hour
+			}), mock.AnythingOfType("time.Time")).Return(tt.reserved, nil).Once()
+			if !tt.reserved {
+				repo.On("Get", mock.Anything, mock.Anything, "user:1", "key1").Return(tt.stored, nil).Once()
+			}
+
+			got, err := service.Begin(context.Background(), "user:1", "key1", tt.requestHash)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdempotencyService_Renew(t *testing.T) {
+	logger.Init("debug")
+
+	repo := mocks.NewIdempotencyRepository(t)
+	service := services.NewIdempotencyService(repo, time.Hour, nil)
+
+	repo.On("Renew", mock.Anything, mock.Anything, "user:1", "key1", mock.MatchedBy(func(now time.Time) bool {
+		return time.Since(now) < time.Minute
+	})).Return(nil).Once()
+	require.NoError(t, service.Renew(context.Background(), "user:1", "key1"))
+
+	repo.On("Renew", mock.Anything, mock.Anything, "user:1", "key2", mock.Anything).Return(sql.ErrConnDone).Once()
+	require.Equal(t, errors.NewInternalError(), service.Renew(context.Background(), "user:1", "key2"))
+}
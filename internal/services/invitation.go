@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"pvz/internal/logger"
+	"pvz/internal/mailer"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
+	"pvz/internal/models/invitation"
+	"pvz/internal/models/user"
+	"pvz/internal/repositories"
+	"pvz/internal/tokens"
+	"pvz/internal/tracing"
+	"pvz/pkg/errors"
+	"time"
+)
+
+type InvitationService interface {
+	Create(ctx context.Context, meta audit.Meta, req invitation.CreateRequest) (invitation.CreateResponse, error)
+	Accept(ctx context.Context, meta audit.Meta, token string, req invitation.AcceptRequest) (user.Response, error)
+}
+
+type invitationServiceImpl struct {
+	invitationRepo repositories.InvitationRepository
+	userRepo       repositories.UserRepository
+	pvzRepo        repositories.PvzRepository
+	auditRepo      repositories.AuditRepository
+	mailer         mailer.Mailer
+	ttl            time.Duration
+	publicUrl      string
+	conn           *sql.DB
+}
+
+func NewInvitationService(invitationRepo repositories.InvitationRepository, userRepo repositories.UserRepository, pvzRepo repositories.PvzRepository, auditRepo repositories.AuditRepository, mailer mailer.Mailer, ttl time.Duration, publicUrl string, conn *sql.DB) InvitationService {
+	return &invitationServiceImpl{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		pvzRepo:        pvzRepo,
+		auditRepo:      auditRepo,
+		mailer:         mailer,
+		ttl:            ttl,
+		publicUrl:      publicUrl,
+		conn:           conn,
+	}
+}
+
+func (is *invitationServiceImpl) Create(ctx context.Context, meta audit.Meta, req invitation.CreateRequest) (invitation.CreateResponse, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Create")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", req.Email, "role", req.Role)
+	log.Info("starting Create invitation")
+
+	tx, err := is.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return invitation.CreateResponse{}, errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	existing, err := is.userRepo.GetByEmail(ctx, tx, req.Email)
+	if err != nil {
+		log.Error("failed to check existing user", "err", err)
+		return invitation.CreateResponse{}, errors.NewInternalError()
+	}
+	if existing != nil {
+		log.Warn("user already exists")
+		return invitation.CreateResponse{}, errors.NewObjectAlreadyExists("user", "email", req.Email)
+	}
+
+	for _, pvzId := range req.PvzIds {
+		pvz, err := is.pvzRepo.GetById(ctx, tx, pvzId)
+		if err != nil {
+			log.Error("failed to get pvz by id", "pvzId", pvzId, "err", err)
+			return invitation.CreateResponse{}, errors.NewInternalError()
+		}
+		if pvz == nil {
+			log.Warn("pvz not found", "pvzId", pvzId)
+			return invitation.CreateResponse{}, errors.NewObjectNotFound("pvz")
+		}
+	}
+
+	token, tokenHash, err := tokens.NewOpaque()
+	if err != nil {
+		log.Error("failed to generate invitation token", "err", err)
+		return invitation.CreateResponse{}, errors.NewInternalError()
+	}
+
+	inv, err := is.invitationRepo.Create(ctx, tx, models.Invitation{
+		Email:     req.Email,
+		Role:      auth.Role(req.Role),
+		PvzIds:    req.PvzIds,
+		TokenHash: tokenHash,
+		CreatedBy: toNullString(meta.Actor.UserId),
+		ExpiresAt: repositories.Now().Add(is.ttl),
+	})
+	if err != nil {
+		log.Error("failed to create invitation", "err", err)
+		return invitation.CreateResponse{}, errors.NewInternalError()
+	}
+
+	resp := invitation.CreateResponse{
+		Id:        inv.Id,
+		Email:     inv.Email,
+		Role:      string(inv.Role),
+		PvzIds:    inv.PvzIds,
+		ExpiresAt: inv.ExpiresAt,
+	}
+	if resp.PvzIds == nil {
+		resp.PvzIds = []string{}
+	}
+
+	err = recordAudit(ctx, tx, is.auditRepo, meta, audit.InvitationCreate, "", map[string]string{"invitationId": inv.Id}, nil, resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return invitation.CreateResponse{}, errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return invitation.CreateResponse{}, errors.NewInternalError()
+	}
+
+	// Delivery happens after commit and outside the request deadline: a slow SMTP server must not
+	// roll the invitation back. The invitation exists either way, so a failed delivery is reported
+	// with it rather than as an error a client would retry into a second invitation.
+	if err = is.mailer.Send(context.WithoutCancel(ctx), is.invitationMessage(resp, token)); err != nil {
+		log.Error("failed to deliver invitation", "invitationId", inv.Id, "err", err)
+		resp.DeliveryFailed = true
+	}
+
+	log.Info("invitation created", "invitationId", inv.Id, "expiresAt", inv.ExpiresAt)
+	return resp, nil
+}
+
+func (is *invitationServiceImpl) Accept(ctx context.Context, meta audit.Meta, token string, req invitation.AcceptRequest) (user.Response, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Accept")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+	log.Info("starting Accept invitation")
+
+	tx, err := is.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	inv, err := is.invitationRepo.GetByTokenHashForUpdate(ctx, tx, tokens.HashOpaque(token))
+	if err != nil {
+		log.Error("failed to get invitation", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	if inv == nil || inv.AcceptedAt.Valid || repositories.Now().After(inv.ExpiresAt) {
+		log.Warn("invitation token rejected", "found", inv != nil)
+		return user.Response{}, errors.NewInvalidToken("invitation")
+	}
+	log = log.With("invitationId", inv.Id, "email", inv.Email)
+
+	existing, err := is.userRepo.GetByEmail(ctx, tx, inv.Email)
+	if err != nil {
+		log.Error("failed to check existing user", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	if existing != nil {
+		log.Warn("user already exists")
+		return user.Response{}, errors.NewObjectAlreadyExists("user", "email", inv.Email)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error("failed to hash password", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	newUser := models.User{
+		Email:        inv.Email,
+		PasswordHash: string(passwordHash),
+		Role:         inv.Role,
+	}
+	newUser.Id, err = is.userRepo.Create(ctx, tx, newUser)
+	if err != nil {
+		log.Error("failed to create user", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	if err = is.userRepo.AssignPvzs(ctx, tx, newUser.Id, inv.PvzIds); err != nil {
+		log.Error("failed to assign pvzs", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	if err = is.invitationRepo.MarkAccepted(ctx, tx, inv.Id, newUser.Id); err != nil {
+		log.Error("failed to mark invitation accepted", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	resp := toUserResponse(newUser, inv.PvzIds)
+
+	// the new user is the actor: the request itself is anonymous
+	meta.Actor = auth.Principal{UserId: newUser.Id, Role: newUser.Role}
+	err = recordAudit(ctx, tx, is.auditRepo, meta, audit.InvitationAccept, "",
+		map[string]string{"invitationId": inv.Id, "userId": newUser.Id}, nil, resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	log.Info("invitation accepted", "userId", newUser.Id)
+	return resp, nil
+}
+
+func (is *invitationServiceImpl) invitationMessage(inv invitation.CreateResponse, token string) mailer.Message {
+	return mailer.Message{
+		To:      inv.Email,
+		Subject: "Приглашение в сервис ПВЗ",
+		Secret:  token,
+		Body: fmt.Sprintf(
+			"Вас пригласили в сервис ПВЗ с ролью %s.\n\n"+
+				"Чтобы завершить регистрацию, задайте пароль запросом\n"+
+				"POST %s/api/v1/invitations/%s/accept\n"+
+				"с телом {\"password\": \"...\"}.\n\n"+
+				"Приглашение действует до %s.\n",
+			inv.Role, is.publicUrl, token, inv.ExpiresAt.Format(time.RFC3339),
+		),
+	}
+}
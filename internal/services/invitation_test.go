@@ -0,0 +1,184 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/logger"
+	"pvz/internal/mailer"
+	"pvz/internal/mocks"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
+	"pvz/internal/models/invitation"
+	"pvz/internal/services"
+	"pvz/internal/tokens"
+	"pvz/pkg/errors"
+)
+
+type fakeMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (fm *fakeMailer) Send(ctx context.Context, msg mailer.Message) error {
+	if fm.err != nil {
+		return fm.err
+	}
+	fm.sent = append(fm.sent, msg)
+	return nil
+}
+
+var acceptLink = regexp.MustCompile(`/api/v1/invitations/([A-Za-z0-9_-]+)/accept`)
+
+func TestInvitationService_Create(t *testing.T) {
+	logger.Init("debug")
+
+	db, mockDB, _ := sqlmock.New()
+	defer db.Close()
+
+	invRepo := mocks.NewInvitationRepository(t)
+	userRepo := new(MockUserRepository)
+	pvzRepo := new(MockPvzRepo)
+	mail := &fakeMailer{}
+	service := services.NewInvitationService(invRepo, userRepo, pvzRepo, newMockAuditRepo(), mail, time.Hour, "http://pvz.test", db)
+
+	req := invitation.CreateRequest{Email: "new@avito.ru", Role: "employee", PvzIds: []string{"pvz1"}}
+
+	var storedHash string
+	mockDB.ExpectBegin()
+	userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), req.Email).Return((*models.User)(nil), nil).Once()
+	pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "pvz1").Return(&models.Pvz{Id: "pvz1"}, nil).Once()
+	invRepo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(inv models.Invitation) bool {
+		storedHash = inv.TokenHash
+		return inv.Email == req.Email && inv.CreatedBy.String == "mod1" && inv.ExpiresAt.After(time.Now().UTC())
+	})).Return(&models.Invitation{
+		Id: "inv1", Email: req.Email, Role: auth.Employee, PvzIds: req.PvzIds, ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}, nil).Once()
+	mockDB.ExpectCommit()
+
+	resp, err := service.Create(context.Background(), audit.Meta{Actor: auth.Principal{UserId: "mod1", Role: auth.Moderator}}, req)
+
+	require.NoError(t, err)
+	require.Equal(t, "inv1", resp.Id)
+	require.Len(t, mail.sent, 1)
+	require.Equal(t, req.Email, mail.sent[0].To)
+
+	match := acceptLink.FindStringSubmatch(mail.sent[0].Body)
+	require.Len(t, match, 2, "mail must contain the accept link")
+	require.Equal(t, storedHash, tokens.HashOpaque(match[1]), "only the token hash may be stored")
+	require.False(t, resp.DeliveryFailed)
+	require.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+func TestInvitationService_CreateDeliveryFailed(t *testing.T) {
+	logger.Init("debug")
+
+	db, mockDB, _ := sqlmock.New()
+	defer db.Close()
+
+	invRepo := mocks.NewInvitationRepository(t)
+	userRepo := new(MockUserRepository)
+	mail := &fakeMailer{err: fmt.Errorf("smtp unavailable")}
+	service := services.NewInvitationService(invRepo, userRepo, nil, newMockAuditRepo(), mail, time.Hour, "http://pvz.test", db)
+
+	req := invitation.CreateRequest{Email: "new@avito.ru", Role: "employee"}
+
+	mockDB.ExpectBegin()
+	userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), req.Email).Return((*models.User)(nil), nil).Once()
+	invRepo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.Anything).Return(&models.Invitation{
+		Id: "inv1", Email: req.Email, Role: auth.Employee, ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}, nil).Once()
+	mockDB.ExpectCommit()
+
+	// the invitation is committed, so it is returned instead of an error the client would retry
+	resp, err := service.Create(context.Background(), audit.Meta{}, req)
+
+	require.NoError(t, err)
+	require.Equal(t, "inv1", resp.Id)
+	require.True(t, resp.DeliveryFailed)
+	require.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+func TestInvitationService_Accept(t *testing.T) {
+	logger.Init("debug")
+
+	token, hash, err := tokens.NewOpaque()
+	require.NoError(t, err)
+
+	pending := func() *models.Invitation {
+		return &models.Invitation{
+			Id:        "inv1",
+			Email:     "new@avito.ru",
+			Role:      auth.Employee,
+			PvzIds:    []string{"pvz1"},
+			TokenHash: hash,
+			ExpiresAt: time.Now().UTC().Add(time.Hour),
+		}
+	}
+
+	t.Run("successful accept", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		invRepo := mocks.NewInvitationRepository(t)
+		userRepo := new(MockUserRepository)
+		service := services.NewInvitationService(invRepo, userRepo, nil, newMockAuditRepo(), &fakeMailer{}, time.Hour, "", db)
+
+		mockDB.ExpectBegin()
+		invRepo.On("GetByTokenHashForUpdate", mock.Anything, mock.AnythingOfType("*sql.Tx"), hash).Return(pending(), nil).Once()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "new@avito.ru").Return((*models.User)(nil), nil).Once()
+		userRepo.On("Create", mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(u models.User) bool {
+			return u.Role == auth.Employee && u.PasswordHash != ""
+		})).Return("user1", nil).Once()
+		userRepo.On("AssignPvzs", mock.AnythingOfType("*sql.Tx"), "user1", []string{"pvz1"}).Return(nil).Once()
+		invRepo.On("MarkAccepted", mock.Anything, mock.AnythingOfType("*sql.Tx"), "inv1", "user1").Return(nil).Once()
+		mockDB.ExpectCommit()
+
+		resp, err := service.Accept(context.Background(), audit.Meta{}, token, invitation.AcceptRequest{Password: "password123"})
+
+		require.NoError(t, err)
+		require.Equal(t, "user1", resp.Id)
+		require.Equal(t, []string{"pvz1"}, resp.PvzIds)
+		userRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	rejected := map[string]func() *models.Invitation{
+		"unknown token": func() *models.Invitation { return nil },
+		"expired": func() *models.Invitation {
+			inv := pending()
+			inv.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+			return inv
+		},
+		"already used": func() *models.Invitation {
+			inv := pending()
+			inv.AcceptedAt.Valid = true
+			return inv
+		},
+	}
+	for name, stored := range rejected {
+		t.Run(name, func(t *testing.T) {
+			db, mockDB, _ := sqlmock.New()
+			defer db.Close()
+
+			invRepo := mocks.NewInvitationRepository(t)
+			service := services.NewInvitationService(invRepo, nil, nil, newMockAuditRepo(), &fakeMailer{}, time.Hour, "", db)
+
+			mockDB.ExpectBegin()
+			invRepo.On("GetByTokenHashForUpdate", mock.Anything, mock.AnythingOfType("*sql.Tx"), hash).Return(stored(), nil).Once()
+			mockDB.ExpectRollback()
+
+			_, err := service.Accept(context.Background(), audit.Meta{}, token, invitation.AcceptRequest{Password: "password123"})
+
+			require.IsType(t, errors.InvalidToken{}, err)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"pvz/internal/logger"
+	"pvz/internal/mailer"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
+	"pvz/internal/repositories"
+	"pvz/internal/tokens"
+	"pvz/internal/tracing"
+	"pvz/pkg/errors"
+	"time"
+)
+
+type PasswordService interface {
+	ChangePassword(ctx context.Context, meta audit.Meta, req auth.ChangePasswordRequest) (string, error)
+	RequestReset(ctx context.Context, meta audit.Meta, req auth.ResetPasswordRequest) error
+	ConfirmReset(ctx context.Context, meta audit.Meta, req auth.ConfirmResetPasswordRequest) error
+	ValidateSession(ctx context.Context, principal auth.Principal) error
+}
+
+type passwordServiceImpl struct {
+	userRepo  repositories.UserRepository
+	resetRepo repositories.PasswordResetRepository
+	auditRepo repositories.AuditRepository
+	mailer    mailer.Mailer
+	resetTtl  time.Duration
+	publicUrl string
+	conn      *sql.DB
+}
+
+func NewPasswordService(userRepo repositories.UserRepository, resetRepo repositories.PasswordResetRepository, auditRepo repositories.AuditRepository, mailer mailer.Mailer, resetTtl time.Duration, publicUrl string, conn *sql.DB) PasswordService {
+	return &passwordServiceImpl{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		auditRepo: auditRepo,
+		mailer:    mailer,
+		resetTtl:  resetTtl,
+		publicUrl: publicUrl,
+		conn:      conn,
+	}
+}
+
+// ChangePassword revokes every session of the caller, including the current one, and returns
+// a fresh token so the client that changed the password stays logged in.
+func (ps *passwordServiceImpl) ChangePassword(ctx context.Context, meta audit.Meta, req auth.ChangePasswordRequest) (string, error) {
+	ctx, span := tracing.Start(ctx, "PasswordService.ChangePassword")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+	log.Info("starting ChangePassword")
+
+	tx, err := ps.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return "", errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	u, err := ps.userRepo.GetById(ctx, tx, meta.Actor.UserId)
+	if err != nil {
+		log.Error("failed to fetch user", "err", err)
+		return "", errors.NewInternalError()
+	}
+	if u == nil {
+		log.Warn("user not found")
+		return "", errors.NewInvalidCredentials()
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(req.OldPassword)); err != nil {
+		log.Warn("invalid old password")
+		return "", errors.NewInvalidCredentials()
+	}
+
+	if err = ps.setPassword(ctx, tx, u.Id, req.NewPassword); err != nil {
+		log.Error("failed to set password", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	pvzIds, err := ps.userRepo.ListPvzIds(ctx, tx, u.Id)
+	if err != nil {
+		log.Error("failed to fetch user pvzs", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	err = recordAudit(ctx, tx, ps.auditRepo, meta, audit.UserPasswordChange, "", map[string]string{"userId": u.Id}, nil, nil)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	token, err := tokens.GenerateJwt(u.Id, u.Role, u.Language.String, pvzIds...)
+	if err != nil {
+		log.Error("failed to generate jwt", "err", err)
+		return "", errors.NewInternalError()
+	}
+
+	log.Info("password changed", "userId", u.Id)
+	return token, nil
+}
+
+// RequestReset never reveals whether the email is registered: unknown addresses and delivery
+// failures look exactly like a successfully sent reset link to the caller.
+func (ps *passwordServiceImpl) RequestReset(ctx context.Context, meta audit.Meta, req auth.ResetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.RequestReset")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", req.Email)
+	log.Info("starting RequestReset")
+
+	tx, err := ps.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	u, err := ps.userRepo.GetByEmail(ctx, tx, req.Email)
+	if err != nil {
+		log.Error("failed to fetch user", "err", err)
+		return errors.NewInternalError()
+	}
+	if u == nil {
+		log.Warn("password reset requested for unknown email")
+		return nil
+	}
+
+	token, tokenHash, err := tokens.NewOpaque()
+	if err != nil {
+		log.Error("failed to generate reset token", "err", err)
+		return errors.NewInternalError()
+	}
+
+	expiresAt := repositories.Now().Add(ps.resetTtl)
+	err = ps.resetRepo.Create(ctx, tx, models.PasswordReset{
+		UserId:    u.Id,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		log.Error("failed to create password reset", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = ps.mailer.Send(context.WithoutCancel(ctx), ps.resetMessage(u.Email, token, expiresAt)); err != nil {
+		log.Error("failed to deliver password reset", "userId", u.Id, "err", err)
+		return nil
+	}
+
+	log.Info("password reset requested", "userId", u.Id, "expiresAt", expiresAt)
+	return nil
+}
+
+func (ps *passwordServiceImpl) ConfirmReset(ctx context.Context, meta audit.Meta, req auth.ConfirmResetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.ConfirmReset")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+	log.Info("starting ConfirmReset")
+
+	tx, err := ps.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	reset, err := ps.resetRepo.GetByTokenHashForUpdate(ctx, tx, tokens.HashOpaque(req.Token))
+	if err != nil {
+		log.Error("failed to get password reset", "err", err)
+		return errors.NewInternalError()
+	}
+	if reset == nil || reset.UsedAt.Valid || repositories.Now().After(reset.ExpiresAt) {
+		log.Warn("password reset token rejected", "found", reset != nil)
+		return errors.NewInvalidToken("password reset")
+	}
+	log = log.With("userId", reset.UserId)
+
+	if err = ps.setPassword(ctx, tx, reset.UserId, req.NewPassword); err != nil {
+		log.Error("failed to set password", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = ps.resetRepo.ConsumeAll(ctx, tx, reset.UserId); err != nil {
+		log.Error("failed to consume reset tokens", "err", err)
+		return errors.NewInternalError()
+	}
+
+	u, err := ps.userRepo.GetById(ctx, tx, reset.UserId)
+	if err != nil || u == nil {
+		log.Error("failed to fetch user", "err", err)
+		return errors.NewInternalError()
+	}
+
+	// the request is anonymous, the token holder acts as the user
+	meta.Actor = auth.Principal{UserId: u.Id, Role: u.Role}
+	err = recordAudit(ctx, tx, ps.auditRepo, meta, audit.UserPasswordReset, "", map[string]string{"userId": u.Id}, nil, nil)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return errors.NewInternalError()
+	}
+
+	log.Info("password reset confirmed")
+	return nil
+}
+
+// ValidateSession rejects tokens issued before the user's last password change or reset.
+// Principals without a stored user (dummy login tokens) have no sessions to revoke.
+func (ps *passwordServiceImpl) ValidateSession(ctx context.Context, principal auth.Principal) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.ValidateSession")
+	defer span.End()
+
+	if principal.UserId == "" {
+		return nil
+	}
+
+	u, err := ps.userRepo.GetById(ctx, ps.conn, principal.UserId)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to fetch user", "err", err)
+		return errors.NewInternalError()
+	}
+	if u == nil || !u.SessionsValidAfter.Valid {
+		return nil
+	}
+
+	if principal.IssuedAt.Before(u.SessionsValidAfter.Time) {
+		logger.FromContext(ctx).Warn("token issued before session revocation", "tokenId", principal.TokenId)
+		return errors.NewSessionExpired()
+	}
+	return nil
+}
+
+func (ps *passwordServiceImpl) setPassword(ctx context.Context, tx *sql.Tx, userId, password string) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err = ps.userRepo.UpdatePasswordHash(ctx, tx, userId, string(passwordHash)); err != nil {
+		return err
+	}
+	return ps.userRepo.RevokeSessions(ctx, tx, userId, sessionsCutoff())
+}
+
+// sessionsCutoff is truncated to whole seconds because jwt iat has second precision:
+// a token issued right after the revocation must not look older than it.
+func sessionsCutoff() time.Time {
+	return repositories.Now().Truncate(time.Second)
+}
+
+func (ps *passwordServiceImpl) resetMessage(email, token string, expiresAt time.Time) mailer.Message {
+	return mailer.Message{
+		To:      email,
+		Subject: "Сброс пароля в сервисе ПВЗ",
+		Secret:  token,
+		Body: fmt.Sprintf(
+			"Кто-то запросил сброс пароля для вашей учётной записи.\n\n"+
+				"Чтобы задать новый пароль, отправьте запрос\n"+
+				"POST %s/api/v1/password/reset/confirm\n"+
+				"с телом {\"token\": \"%s\", \"newPassword\": \"...\"}.\n\n"+
+				"Ссылка действует до %s. Если вы не запрашивали сброс, просто проигнорируйте это письмо.\n",
+			ps.publicUrl, token, expiresAt.Format(time.RFC3339),
+		),
+	}
+}
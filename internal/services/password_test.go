@@ -0,0 +1,214 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"pvz/configs"
+	"pvz/internal/logger"
+	"pvz/internal/mocks"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
+	"pvz/internal/services"
+	"pvz/internal/tokens"
+	"pvz/pkg/errors"
+)
+
+var resetToken = regexp.MustCompile(`"token": "([A-Za-z0-9_-]+)"`)
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	logger.Init("debug")
+	LoadTestEnv()
+	_, _ = configs.LoadConfig()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.DefaultCost)
+	stored := &models.User{Id: "user1", Email: "user@avito.ru", PasswordHash: string(hash), Role: auth.Employee}
+	meta := audit.Meta{Actor: auth.Principal{UserId: "user1", Role: auth.Employee}}
+
+	t.Run("wrong old password", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		service := services.NewPasswordService(userRepo, mocks.NewPasswordResetRepository(t), newMockAuditRepo(), &fakeMailer{}, time.Hour, "http://pvz.test", db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "user1").Return(stored, nil).Once()
+		mockDB.ExpectRollback()
+
+		_, err := service.ChangePassword(context.Background(), meta, auth.ChangePasswordRequest{OldPassword: "wrong", NewPassword: "newpassword"})
+		require.Equal(t, errors.NewInvalidCredentials(), err)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("success revokes sessions and issues a new token", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		service := services.NewPasswordService(userRepo, mocks.NewPasswordResetRepository(t), newMockAuditRepo(), &fakeMailer{}, time.Hour, "http://pvz.test", db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "user1").Return(stored, nil).Once()
+		userRepo.On("UpdatePasswordHash", mock.AnythingOfType("*sql.Tx"), "user1", mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("newpassword")) == nil
+		})).Return(nil).Once()
+		userRepo.On("RevokeSessions", mock.AnythingOfType("*sql.Tx"), "user1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		userRepo.On("ListPvzIds", mock.AnythingOfType("*sql.Tx"), "user1").Return([]string{"pvz1"}, nil).Once()
+		mockDB.ExpectCommit()
+
+		token, err := service.ChangePassword(context.Background(), meta, auth.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "newpassword"})
+		require.NoError(t, err)
+
+		claims, err := tokens.ParseJwt(token)
+		require.NoError(t, err)
+		require.Equal(t, "user1", claims.UserId)
+		require.Equal(t, []string{"pvz1"}, claims.PvzIds)
+		userRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
+
+func TestPasswordService_Reset(t *testing.T) {
+	logger.Init("debug")
+
+	t.Run("unknown email is not revealed", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		mail := &fakeMailer{}
+		service := services.NewPasswordService(userRepo, mocks.NewPasswordResetRepository(t), newMockAuditRepo(), mail, time.Hour, "http://pvz.test", db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "ghost@avito.ru").Return((*models.User)(nil), nil).Once()
+		mockDB.ExpectRollback()
+
+		err := service.RequestReset(context.Background(), audit.Meta{}, auth.ResetPasswordRequest{Email: "ghost@avito.ru"})
+		require.NoError(t, err)
+		require.Empty(t, mail.sent)
+	})
+
+	t.Run("mailed token resets the password", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		resetRepo := mocks.NewPasswordResetRepository(t)
+		mail := &fakeMailer{}
+		service := services.NewPasswordService(userRepo, resetRepo, newMockAuditRepo(), mail, time.Hour, "http://pvz.test", db)
+		stored := &models.User{Id: "user1", Email: "user@avito.ru", Role: auth.Employee}
+
+		var storedHash string
+		mockDB.ExpectBegin()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), stored.Email).Return(stored, nil).Once()
+		resetRepo.On("Create", mock.Anything, mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(r models.PasswordReset) bool {
+			storedHash = r.TokenHash
+			return r.UserId == "user1" && r.ExpiresAt.After(time.Now().UTC())
+		})).Return(nil).Once()
+		mockDB.ExpectCommit()
+
+		err := service.RequestReset(context.Background(), audit.Meta{}, auth.ResetPasswordRequest{Email: stored.Email})
+		require.NoError(t, err)
+		require.Len(t, mail.sent, 1)
+		require.Equal(t, stored.Email, mail.sent[0].To)
+
+		match := resetToken.FindStringSubmatch(mail.sent[0].Body)
+		require.Len(t, match, 2)
+		require.Equal(t, storedHash, tokens.HashOpaque(match[1]))
+
+		mockDB.ExpectBegin()
+		resetRepo.On("GetByTokenHashForUpdate", mock.Anything, mock.AnythingOfType("*sql.Tx"), storedHash).Return(&models.PasswordReset{
+			Id: "reset1", UserId: "user1", TokenHash: storedHash, ExpiresAt: time.Now().UTC().Add(time.Hour),
+		}, nil).Once()
+		userRepo.On("UpdatePasswordHash", mock.AnythingOfType("*sql.Tx"), "user1", mock.AnythingOfType("string")).Return(nil).Once()
+		userRepo.On("RevokeSessions", mock.AnythingOfType("*sql.Tx"), "user1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		resetRepo.On("ConsumeAll", mock.Anything, mock.AnythingOfType("*sql.Tx"), "user1").Return(nil).Once()
+		userRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "user1").Return(stored, nil).Once()
+		mockDB.ExpectCommit()
+
+		err = service.ConfirmReset(context.Background(), audit.Meta{}, auth.ConfirmResetPasswordRequest{Token: match[1], NewPassword: "newpassword"})
+		require.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("used or expired token is rejected", func(t *testing.T) {
+		for name, reset := range map[string]*models.PasswordReset{
+			"missing": nil,
+			"used":    {UserId: "user1", ExpiresAt: time.Now().UTC().Add(time.Hour), UsedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+			"expired": {UserId: "user1", ExpiresAt: time.Now().UTC().Add(-time.Minute)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				db, mockDB, _ := sqlmock.New()
+				defer db.Close()
+
+				resetRepo := mocks.NewPasswordResetRepository(t)
+				service := services.NewPasswordService(new(MockUserRepository), resetRepo, newMockAuditRepo(), &fakeMailer{}, time.Hour, "http://pvz.test", db)
+
+				mockDB.ExpectBegin()
+				resetRepo.On("GetByTokenHashForUpdate", mock.Anything, mock.AnythingOfType("*sql.Tx"), tokens.HashOpaque("tok")).Return(reset, nil).Once()
+				mockDB.ExpectRollback()
+
+				err := service.ConfirmReset(context.Background(), audit.Meta{}, auth.ConfirmResetPasswordRequest{Token: "tok", NewPassword: "newpassword"})
+				require.Equal(t, errors.NewInvalidToken("password reset"), err)
+			})
+		}
+	})
+}
+
+func TestPasswordService_ValidateSession(t *testing.T) {
+	logger.Init("debug")
+
+	revokedAt := time.Now().UTC().Truncate(time.Second)
+	tests := []struct {
+		name      string
+		stored    *models.User
+		issuedAt  time.Time
+		wantError error
+	}{
+		{
+			name:     "never revoked",
+			stored:   &models.User{Id: "user1"},
+			issuedAt: revokedAt.Add(-time.Hour),
+		},
+		{
+			name:     "unknown user",
+			issuedAt: revokedAt.Add(-time.Hour),
+		},
+		{
+			name:     "issued after revocation",
+			stored:   &models.User{Id: "user1", SessionsValidAfter: sql.NullTime{Time: revokedAt, Valid: true}},
+			issuedAt: revokedAt,
+		},
+		{
+			name:      "issued before revocation",
+			stored:    &models.User{Id: "user1", SessionsValidAfter: sql.NullTime{Time: revokedAt, Valid: true}},
+			issuedAt:  revokedAt.Add(-time.Second),
+			wantError: errors.NewSessionExpired(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _, _ := sqlmock.New()
+			defer db.Close()
+
+			userRepo := new(MockUserRepository)
+			service := services.NewPasswordService(userRepo, mocks.NewPasswordResetRepository(t), newMockAuditRepo(), &fakeMailer{}, time.Hour, "http://pvz.test", db)
+			userRepo.On("GetById", db, "user1").Return(tt.stored, nil).Once()
+
+			err := service.ValidateSession(context.Background(), auth.Principal{UserId: "user1", IssuedAt: tt.issuedAt})
+			require.Equal(t, tt.wantError, err)
+		})
+	}
+}
@@ -1,46 +1,58 @@
 package services
 
 import (
+	"context"
 	"database/sql"
+	"go.opentelemetry.io/otel/attribute"
+	"pvz/internal/events"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/product"
 	"pvz/internal/models/reception"
 	"pvz/internal/repositories"
+	"pvz/internal/tracing"
 	"pvz/pkg/errors"
 )
 
 type ProductService interface {
-	AddInReception(req product.AddInReceptionRequest) (product.AddInReceptionResponse, error)
+	AddInReception(ctx context.Context, meta audit.Meta, req product.AddInReceptionRequest) (product.AddInReceptionResponse, error)
 }
 
 type productServiceImpl struct {
 	productRepo   repositories.ProductRepository
 	pvzRepo       repositories.PvzRepository
 	receptionRepo repositories.ReceptionRepository
+	auditRepo     repositories.AuditRepository
+	eventBus      events.Publisher
 	conn          *sql.DB
 }
 
-func NewProductService(productRepo repositories.ProductRepository, pvzRepo repositories.PvzRepository, recRepo repositories.ReceptionRepository, conn *sql.DB) ProductService {
+func NewProductService(productRepo repositories.ProductRepository, pvzRepo repositories.PvzRepository, recRepo repositories.ReceptionRepository, auditRepo repositories.AuditRepository, eventBus events.Publisher, conn *sql.DB) ProductService {
 	return &productServiceImpl{
 		productRepo:   productRepo,
 		pvzRepo:       pvzRepo,
 		receptionRepo: recRepo,
+		auditRepo:     auditRepo,
+		eventBus:      eventBus,
 		conn:          conn,
 	}
 }
-func (ps *productServiceImpl) AddInReception(req product.AddInReceptionRequest) (product.AddInReceptionResponse, error) {
-	log := logger.Log.With("pvz_id", req.PvzId, "product_type", req.Type)
+func (ps *productServiceImpl) AddInReception(ctx context.Context, meta audit.Meta, req product.AddInReceptionRequest) (product.AddInReceptionResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.AddInReception", attribute.String("pvz.id", req.PvzId))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("pvz_id", req.PvzId, "product_type", req.Type)
 	log.Info("starting AddInReception")
 
-	tx, err := ps.conn.Begin()
+	tx, err := ps.conn.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error("failed to begin transaction", "err", err)
 		return product.AddInReceptionResponse{}, errors.NewInternalError()
 	}
 	defer tx.Rollback()
 
-	pvz, err := ps.pvzRepo.GetById(tx, req.PvzId)
+	pvz, err := ps.pvzRepo.GetById(ctx, tx, req.PvzId)
 	if err != nil {
 		log.Error("failed to fetch pvz", "err", err)
 		return product.AddInReceptionResponse{}, errors.NewInternalError()
@@ -50,7 +62,7 @@ func (ps *productServiceImpl) AddInReception(req product.AddInReceptionRequest)
 		return product.AddInReceptionResponse{}, errors.NewObjectNotFound("pvz")
 	}
 
-	rec, err := ps.receptionRepo.GetByPvzId(tx, req.PvzId)
+	rec, err := ps.receptionRepo.GetByPvzId(ctx, tx, req.PvzId)
 	if err != nil {
 		log.Error("failed to fetch reception", "err", err)
 		return product.AddInReceptionResponse{}, errors.NewInternalError()
@@ -64,28 +76,50 @@ func (ps *productServiceImpl) AddInReception(req product.AddInReceptionRequest)
 		return product.AddInReceptionResponse{}, errors.NewReceptionIsNotInProgress(rec.Id)
 	}
 
+	span.SetAttributes(attribute.String("reception.id", rec.Id))
+
 	reqProduct := models.Product{
 		Type:        req.Type,
 		ReceptionId: rec.Id,
+		AcceptedBy:  toNullString(meta.Actor.UserId),
 	}
 
-	productResp, err := ps.productRepo.AddInReception(tx, reqProduct)
+	productResp, err := ps.productRepo.AddInReception(ctx, tx, reqProduct)
 	if err != nil {
 		log.Error("failed to add product to reception", "err", err)
 		return product.AddInReceptionResponse{}, errors.NewInternalError()
 	}
 
+	resp := product.AddInReceptionResponse{
+		Id:          productResp.Id,
+		DateTime:    productResp.DateTime,
+		Type:        productResp.Type,
+		ReceptionId: productResp.ReceptionId,
+		AcceptedBy:  nullStringPtr(productResp.AcceptedBy),
+	}
+
+	err = recordAudit(ctx, tx, ps.auditRepo, meta, audit.ProductAdd, req.PvzId,
+		map[string]string{"receptionId": productResp.ReceptionId, "productId": productResp.Id}, nil, resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return product.AddInReceptionResponse{}, errors.NewInternalError()
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Error("failed to commit transaction", "err", err)
 		return product.AddInReceptionResponse{}, errors.NewInternalError()
 	}
 
+	ps.eventBus.Publish(events.Event{
+		Type:        events.ProductAdded,
+		PvzId:       req.PvzId,
+		ReceptionId: productResp.ReceptionId,
+		ProductId:   productResp.Id,
+		ProductType: productResp.Type,
+		DateTime:    productResp.DateTime,
+	})
+
 	log.Info("product successfully added to reception", "product_id", productResp.Id, "reception_id", productResp.ReceptionId)
 
-	return product.AddInReceptionResponse{
-		Id:          productResp.Id,
-		DateTime:    productResp.DateTime,
-		Type:        productResp.Type,
-		ReceptionId: productResp.ReceptionId,
-	}, nil
+	return resp, nil
 }
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"context"
 	"pvz/internal/models/pvz"
 	"testing"
 	"time"
@@ -9,8 +10,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"pvz/internal/events"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/product"
 	"pvz/internal/models/reception"
 	"pvz/internal/repositories"
@@ -23,46 +26,99 @@ type MockPvzRepo struct{ mock.Mock }
 
 type MockReceptionRepo struct{ mock.Mock }
 
-func (m *MockReceptionRepo) Create(q repositories.Querier, req models.Reception) (*models.Reception, error) {
+type MockAuditRepo struct{ mock.Mock }
+
+func newMockAuditRepo() *MockAuditRepo {
+	m := new(MockAuditRepo)
+	m.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *MockAuditRepo) Create(ctx context.Context, q repositories.Querier, entry models.AuditEntry) error {
+	args := m.Called(q, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepo) List(ctx context.Context, q repositories.Querier, req audit.ListRequest, offset int) ([]models.AuditEntry, error) {
+	args := m.Called(q, req, offset)
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
+
+func (m *MockReceptionRepo) Create(ctx context.Context, q repositories.Querier, req models.Reception) (*models.Reception, error) {
 	args := m.Called(q, req)
 	return args.Get(0).(*models.Reception), args.Error(1)
 }
 
-func (m *MockPvzRepo) GetById(q repositories.Querier, id string) (*models.Pvz, error) {
+func (m *MockPvzRepo) GetById(ctx context.Context, q repositories.Querier, id string) (*models.Pvz, error) {
 	args := m.Called(q, id)
 	return args.Get(0).(*models.Pvz), args.Error(1)
 }
 
-func (m *MockPvzRepo) Create(q repositories.Querier, req pvz.CreateRequest) (*models.Pvz, error) {
+func (m *MockPvzRepo) Create(ctx context.Context, q repositories.Querier, req pvz.CreateRequest) (*models.Pvz, error) {
 	args := m.Called(q, req)
 	return args.Get(0).(*models.Pvz), args.Error(1)
 }
 
-func (m *MockPvzRepo) ListWithFilterDate(q repositories.Querier, req pvz.ListRequest, offset int) ([]pvz.RawList, error) {
+func (m *MockPvzRepo) Update(ctx context.Context, q repositories.Querier, id string, req pvz.UpdateRequest) (*models.Pvz, error) {
+	args := m.Called(q, id, req)
+	return args.Get(0).(*models.Pvz), args.Error(1)
+}
+
+func (m *MockPvzRepo) Nearby(ctx context.Context, q repositories.Querier, latitude, longitude, radiusMeters float64, limit int) ([]models.PvzDistance, error) {
+	args := m.Called(q, latitude, longitude, radiusMeters, limit)
+	return args.Get(0).([]models.PvzDistance), args.Error(1)
+}
+
+func (m *MockPvzRepo) ListWithFilterDate(ctx context.Context, q repositories.Querier, req pvz.ListRequest, offset int) ([]pvz.RawList, error) {
 	args := m.Called(q, req, offset)
 	return args.Get(0).([]pvz.RawList), args.Error(1)
 }
 
-func (m *MockReceptionRepo) SetStatus(q repositories.Querier, status string, pvzId string) (*models.Reception, error) {
-	args := m.Called(q, status, pvzId)
+func (m *MockPvzRepo) List(ctx context.Context, q repositories.Querier) ([]models.Pvz, error) {
+	args := m.Called(q)
+	return args.Get(0).([]models.Pvz), args.Error(1)
+}
+
+func (m *MockPvzRepo) Export(ctx context.Context, q repositories.Querier, startDate, endDate time.Time, batchSize int, fn func(pvz.RawList) error) error {
+	args := m.Called(q, startDate, endDate, batchSize)
+	for _, row := range args.Get(0).([]pvz.RawList) {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockReceptionRepo) Close(ctx context.Context, q repositories.Querier, pvzId string, closedBy string) (*models.Reception, error) {
+	args := m.Called(q, pvzId, closedBy)
 	return args.Get(0).(*models.Reception), args.Error(1)
 }
 
-func (m *MockProductRepo) AddInReception(q repositories.Querier, p models.Product) (*models.Product, error) {
+func (m *MockProductRepo) AddInReception(ctx context.Context, q repositories.Querier, p models.Product) (*models.Product, error) {
 	args := m.Called(q, p)
 	return args.Get(0).(*models.Product), args.Error(1)
 }
 
-func (m *MockProductRepo) DeleteLast(q repositories.Querier, recId string) (*models.Product, error) {
+func (m *MockProductRepo) ListByReception(ctx context.Context, q repositories.Querier, recId string) ([]models.Product, error) {
+	args := m.Called(q, recId)
+	return args.Get(0).([]models.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) DeleteLast(ctx context.Context, q repositories.Querier, recId string) (*models.Product, error) {
 	args := m.Called(q, recId)
 	return args.Get(0).(*models.Product), args.Error(1)
 }
 
-func (m *MockReceptionRepo) GetByPvzId(q repositories.Querier, pvzId string) (*models.Reception, error) {
+func (m *MockReceptionRepo) GetByPvzId(ctx context.Context, q repositories.Querier, pvzId string) (*models.Reception, error) {
 	args := m.Called(q, pvzId)
 	return args.Get(0).(*models.Reception), args.Error(1)
 }
 
+func (m *MockReceptionRepo) ListInProgressBefore(ctx context.Context, q repositories.Querier, before time.Time) ([]models.Reception, error) {
+	args := m.Called(q, before)
+	return args.Get(0).([]models.Reception), args.Error(1)
+}
+
 func TestProductService_AddInReception(t *testing.T) {
 	logger.Init("debug")
 
@@ -73,7 +129,7 @@ func TestProductService_AddInReception(t *testing.T) {
 		productRepo := new(MockProductRepo)
 		pvzRepo := new(MockPvzRepo)
 		recRepo := new(MockReceptionRepo)
-		service := services.NewProductService(productRepo, pvzRepo, recRepo, db)
+		service := services.NewProductService(productRepo, pvzRepo, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := product.AddInReceptionRequest{
 			PvzId: "550e8400-e29b-41d4-a716-446655440000",
@@ -106,7 +162,7 @@ func TestProductService_AddInReception(t *testing.T) {
 
 		mockDB.ExpectCommit()
 
-		resp, err := service.AddInReception(req)
+		resp, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.NoError(t, err)
 		require.Equal(t, expectedProduct.Id, resp.Id)
@@ -122,7 +178,7 @@ func TestProductService_AddInReception(t *testing.T) {
 		defer db.Close()
 
 		pvzRepo := new(MockPvzRepo)
-		service := services.NewProductService(nil, pvzRepo, nil, db)
+		service := services.NewProductService(nil, pvzRepo, nil, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := product.AddInReceptionRequest{
 			PvzId: "invalid-pvz",
@@ -135,7 +191,7 @@ func TestProductService_AddInReception(t *testing.T) {
 			Once()
 		mockDB.ExpectRollback()
 
-		_, err := service.AddInReception(req)
+		_, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.ObjectNotFound{}, err)
@@ -148,7 +204,7 @@ func TestProductService_AddInReception(t *testing.T) {
 		defer db.Close()
 
 		pvzRepo := new(MockPvzRepo)
-		service := services.NewProductService(nil, pvzRepo, nil, db)
+		service := services.NewProductService(nil, pvzRepo, nil, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := product.AddInReceptionRequest{
 			PvzId: "550e8400-e29b-41d4-a716-446655440000",
@@ -161,7 +217,7 @@ func TestProductService_AddInReception(t *testing.T) {
 			Once()
 		mockDB.ExpectRollback()
 
-		_, err := service.AddInReception(req)
+		_, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -175,7 +231,7 @@ func TestProductService_AddInReception(t *testing.T) {
 		pvzRepo := new(MockPvzRepo)
 		recRepo := new(MockReceptionRepo)
 		productRepo := new(MockProductRepo)
-		service := services.NewProductService(productRepo, pvzRepo, recRepo, db)
+		service := services.NewProductService(productRepo, pvzRepo, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := product.AddInReceptionRequest{
 			PvzId: "550e8400-e29b-41d4-a716-446655440000",
@@ -191,7 +247,7 @@ func TestProductService_AddInReception(t *testing.T) {
 			Return(&models.Product{Id: "prod123"}, nil)
 		mockDB.ExpectCommit().WillReturnError(errors.NewInternalError())
 
-		_, err := service.AddInReception(req)
+		_, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -204,7 +260,7 @@ func TestProductService_AddInReception(t *testing.T) {
 
 		pvzRepo := new(MockPvzRepo)
 		recRepo := new(MockReceptionRepo)
-		service := services.NewProductService(nil, pvzRepo, recRepo, db)
+		service := services.NewProductService(nil, pvzRepo, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := product.AddInReceptionRequest{
 			PvzId: "550e8400-e29b-41d4-a716-446655440000",
@@ -218,7 +274,7 @@ func TestProductService_AddInReception(t *testing.T) {
 			Return((*models.Reception)(nil), nil)
 		mockDB.ExpectRollback()
 
-		_, err := service.AddInReception(req)
+		_, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.ObjectNotFound{}, err)
@@ -232,7 +288,7 @@ func TestProductService_AddInReception(t *testing.T) {
 		pvzRepo := new(MockPvzRepo)
 		recRepo := new(MockReceptionRepo)
 		productRepo := new(MockProductRepo)
-		service := services.NewProductService(productRepo, pvzRepo, recRepo, db)
+		service := services.NewProductService(productRepo, pvzRepo, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := product.AddInReceptionRequest{
 			PvzId: "550e8400-e29b-41d4-a716-446655440000",
@@ -248,7 +304,7 @@ func TestProductService_AddInReception(t *testing.T) {
 			Return((*models.Product)(nil), errors.NewInternalError())
 		mockDB.ExpectRollback()
 
-		_, err := service.AddInReception(req)
+		_, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -259,12 +315,12 @@ func TestProductService_AddInReception(t *testing.T) {
 		db, mockDB, _ := sqlmock.New()
 		defer db.Close()
 
-		service := services.NewProductService(nil, nil, nil, db)
+		service := services.NewProductService(nil, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 		req := product.AddInReceptionRequest{}
 
 		mockDB.ExpectBegin().WillReturnError(errors.NewInternalError())
 
-		_, err := service.AddInReception(req)
+		_, err := service.AddInReception(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -1,72 +1,86 @@
 package services
 
 import (
+	"context"
 	"database/sql"
-	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel/attribute"
+	"pvz/configs"
+	"pvz/internal/events"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/export"
 	"pvz/internal/models/pvz"
 	"pvz/internal/models/reception"
 	"pvz/internal/repositories"
+	"pvz/internal/tracing"
 	"pvz/pkg/errors"
 )
 
 type PvzService interface {
-	Create(req pvz.CreateRequest) (pvz.CreateResponse, error)
-	DeleteLastProduct(pvzId string) (pvz.DeleteLastProductResponse, error)
-	CLoseLastReception(pvzId string) (pvz.CloseLastProductResponse, error)
-	ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListResponse, error)
+	Create(ctx context.Context, meta audit.Meta, req pvz.CreateRequest) (pvz.CreateResponse, error)
+	DeleteLastProduct(ctx context.Context, meta audit.Meta, pvzId string) (pvz.DeleteLastProductResponse, error)
+	CLoseLastReception(ctx context.Context, meta audit.Meta, pvzId string) (pvz.CloseLastProductResponse, error)
+	ListWithFilterDate(ctx context.Context, req pvz.ListRequest) ([]pvz.ListResponse, error)
+	List(ctx context.Context) ([]pvz.Pvz, error)
+	Export(ctx context.Context, req export.Request, fn func(export.Row) error) error
+	Import(ctx context.Context, meta audit.Meta, req pvz.ImportRequest) (pvz.ImportResponse, error)
+	Update(ctx context.Context, meta audit.Meta, pvzId string, req pvz.UpdateRequest) (pvz.Pvz, error)
+	Nearby(ctx context.Context, req pvz.NearbyRequest) ([]pvz.NearbyResponse, error)
 }
 
 type pvzServiceImpl struct {
 	pvzRepo       repositories.PvzRepository
 	productRepo   repositories.ProductRepository
 	receptionRepo repositories.ReceptionRepository
+	auditRepo     repositories.AuditRepository
+	eventBus      events.Publisher
 	conn          *sql.DB
 }
 
-func NewPvzService(pvzRepo repositories.PvzRepository, productRepo repositories.ProductRepository, receptionRepo repositories.ReceptionRepository, conn *sql.DB) PvzService {
+func NewPvzService(pvzRepo repositories.PvzRepository, productRepo repositories.ProductRepository, receptionRepo repositories.ReceptionRepository, auditRepo repositories.AuditRepository, eventBus events.Publisher, conn *sql.DB) PvzService {
 	return &pvzServiceImpl{
 		pvzRepo:       pvzRepo,
 		productRepo:   productRepo,
 		receptionRepo: receptionRepo,
+		auditRepo:     auditRepo,
+		eventBus:      eventBus,
 		conn:          conn,
 	}
 }
-func (ps *pvzServiceImpl) Create(req pvz.CreateRequest) (pvz.CreateResponse, error) {
-	log := logger.Log.With("city", req.City)
+func (ps *pvzServiceImpl) Create(ctx context.Context, meta audit.Meta, req pvz.CreateRequest) (pvz.CreateResponse, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.Create")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("city", req.City)
 	if req.Id != nil {
 		log = log.With("pvzId", *req.Id)
 	}
 	log.Info("starting Create Pvz")
 
-	tx, err := ps.conn.Begin()
+	tx, err := ps.conn.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error("failed to begin transaction", "err", err)
 		return pvz.CreateResponse{}, errors.NewInternalError()
 	}
 	defer tx.Rollback()
 
-	if req.Id == nil {
-		reqId := ""
-		req.Id = &reqId
-		log.Info("no id provided, using autogenerated id")
-	} else {
-		pvzWithId, err := ps.pvzRepo.GetById(tx, *req.Id)
-		if err != nil {
-			log.Error("failed to check existing pvz", "err", err)
-			return pvz.CreateResponse{}, errors.NewInternalError()
-		}
-		if pvzWithId != nil {
-			log.Warn("pvz with given id already exists")
-			return pvz.CreateResponse{}, errors.NewObjectAlreadyExists("pvz", "id", *req.Id)
-		}
-	}
-
-	newPvz, err := ps.pvzRepo.Create(tx, req)
+	// a duplicate id is caught by the primary key, so two concurrent creates with the same id cannot both pass
+	newPvz, err := ps.pvzRepo.Create(ctx, tx, req)
 	if err != nil {
 		log.Error("failed to create pvz", "err", err)
-		return pvz.CreateResponse{}, err
+		return pvz.CreateResponse{}, errors.NewInternalError()
+	}
+	if newPvz == nil {
+		log.Warn("pvz with given id already exists")
+		return pvz.CreateResponse{}, errors.NewObjectAlreadyExists("pvz", "id", *req.Id)
+	}
+
+	resp := pvz.CreateResponse(pvzDto(*newPvz))
+
+	if err := recordAudit(ctx, tx, ps.auditRepo, meta, audit.PvzCreate, newPvz.Id, nil, nil, resp); err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return pvz.CreateResponse{}, errors.NewInternalError()
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -76,36 +90,36 @@ func (ps *pvzServiceImpl) Create(req pvz.CreateRequest) (pvz.CreateResponse, err
 
 	log.Info("pvz successfully created", "pvzId", newPvz.Id)
 
-	return pvz.CreateResponse{
-		Id:               newPvz.Id,
-		RegistrationDate: newPvz.RegistrationDate,
-		City:             req.City,
-	}, nil
+	return resp, nil
 }
 
-func (ps *pvzServiceImpl) DeleteLastProduct(pvzId string) (pvz.DeleteLastProductResponse, error) {
-	log := logger.Log.With("pvzId", pvzId)
+func (ps *pvzServiceImpl) DeleteLastProduct(ctx context.Context, meta audit.Meta, pvzId string) (pvz.DeleteLastProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.DeleteLastProduct", attribute.String("pvz.id", pvzId))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("pvzId", pvzId)
 	log.Info("starting DeleteLastProduct")
 
-	tx, err := ps.conn.Begin()
+	tx, err := ps.conn.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error("failed to begin transaction", "err", err)
 		return pvz.DeleteLastProductResponse{}, err
 	}
 	defer tx.Rollback()
 
-	if _, err = ps.getPvzOrErr(tx, pvzId); err != nil {
+	if _, err = ps.getPvzOrErr(ctx, tx, pvzId); err != nil {
 		log.Error("failed to get pvz", "err", err)
 		return pvz.DeleteLastProductResponse{}, err
 	}
 
-	rec, err := ps.getReceptionOrErr(tx, pvzId)
+	rec, err := ps.getReceptionOrErr(ctx, tx, pvzId)
 	if err != nil {
 		log.Error("failed to get reception", "err", err)
 		return pvz.DeleteLastProductResponse{}, err
 	}
+	span.SetAttributes(attribute.String("reception.id", rec.Id))
 
-	pr, err := ps.productRepo.DeleteLast(tx, rec.Id)
+	pr, err := ps.productRepo.DeleteLast(ctx, tx, rec.Id)
 	if err != nil {
 		log.Error("failed to delete last product", "err", err)
 		return pvz.DeleteLastProductResponse{}, errors.NewInternalError()
@@ -115,11 +129,33 @@ func (ps *pvzServiceImpl) DeleteLastProduct(pvzId string) (pvz.DeleteLastProduct
 		return pvz.DeleteLastProductResponse{}, errors.NewObjectHasNotSubObjects("reception", "product")
 	}
 
+	deleted := pvz.Product{
+		Id:          pr.Id,
+		DateTime:    pr.DateTime,
+		Type:        pr.Type,
+		ReceptionId: pr.ReceptionId,
+		AcceptedBy:  nullStringPtr(pr.AcceptedBy),
+	}
+	err = recordAudit(ctx, tx, ps.auditRepo, meta, audit.ProductDeleteOne, pvzId,
+		map[string]string{"receptionId": rec.Id, "productId": pr.Id}, deleted, nil)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return pvz.DeleteLastProductResponse{}, errors.NewInternalError()
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Error("failed to commit transaction", "err", err)
 		return pvz.DeleteLastProductResponse{}, errors.NewInternalError()
 	}
 
+	ps.eventBus.Publish(events.Event{
+		Type:        events.ProductDeleted,
+		PvzId:       pvzId,
+		ReceptionId: rec.Id,
+		ProductId:   pr.Id,
+		ProductType: pr.Type,
+	})
+
 	log.Info("product successfully deleted", "productId", pr.Id)
 
 	return pvz.DeleteLastProductResponse{
@@ -127,29 +163,33 @@ func (ps *pvzServiceImpl) DeleteLastProduct(pvzId string) (pvz.DeleteLastProduct
 	}, nil
 }
 
-func (ps *pvzServiceImpl) CLoseLastReception(pvzId string) (pvz.CloseLastProductResponse, error) {
-	log := logger.Log.With("pvzId", pvzId)
+func (ps *pvzServiceImpl) CLoseLastReception(ctx context.Context, meta audit.Meta, pvzId string) (pvz.CloseLastProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.CLoseLastReception", attribute.String("pvz.id", pvzId))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("pvzId", pvzId)
 	log.Info("starting CloseLastReception")
 
-	tx, err := ps.conn.Begin()
+	tx, err := ps.conn.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error("failed to begin transaction", "err", err)
 		return pvz.CloseLastProductResponse{}, err
 	}
 	defer tx.Rollback()
 
-	if _, err = ps.getPvzOrErr(tx, pvzId); err != nil {
+	if _, err = ps.getPvzOrErr(ctx, tx, pvzId); err != nil {
 		log.Error("failed to get pvz", "err", err)
 		return pvz.CloseLastProductResponse{}, err
 	}
 
-	_, err = ps.getReceptionOrErr(tx, pvzId)
+	openRec, err := ps.getReceptionOrErr(ctx, tx, pvzId)
 	if err != nil {
 		log.Error("failed to get in-progress reception", "err", err)
 		return pvz.CloseLastProductResponse{}, err
 	}
+	span.SetAttributes(attribute.String("reception.id", openRec.Id))
 
-	closeRec, err := ps.receptionRepo.SetStatus(tx, reception.CloseStatus, pvzId)
+	closeRec, err := ps.receptionRepo.Close(ctx, tx, pvzId, meta.Actor.UserId)
 	if err != nil {
 		log.Error("failed to close reception", "err", err)
 		return pvz.CloseLastProductResponse{}, errors.NewInternalError()
@@ -159,23 +199,50 @@ func (ps *pvzServiceImpl) CLoseLastReception(pvzId string) (pvz.CloseLastProduct
 		return pvz.CloseLastProductResponse{}, errors.NewObjectHasNotSubObjects("pvz", "reception")
 	}
 
+	resp := pvz.CloseLastProductResponse{
+		Id:       closeRec.Id,
+		DateTime: closeRec.DateTime,
+		PvzId:    pvzId,
+		Status:   closeRec.Status,
+		OpenedBy: nullStringPtr(closeRec.OpenedBy),
+		ClosedBy: nullStringPtr(closeRec.ClosedBy),
+		ClosedAt: nullTimePtr(closeRec.ClosedAt),
+	}
+	before := pvz.Reception{
+		Id:       openRec.Id,
+		DateTime: openRec.DateTime,
+		PvzId:    openRec.PvzId,
+		Status:   openRec.Status,
+		OpenedBy: nullStringPtr(openRec.OpenedBy),
+	}
+	err = recordAudit(ctx, tx, ps.auditRepo, meta, audit.ReceptionClose, pvzId,
+		map[string]string{"receptionId": closeRec.Id}, before, resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return pvz.CloseLastProductResponse{}, errors.NewInternalError()
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Error("failed to commit transaction", "err", err)
 		return pvz.CloseLastProductResponse{}, errors.NewInternalError()
 	}
 
+	ps.eventBus.Publish(events.Event{
+		Type:        events.ReceptionClosed,
+		PvzId:       pvzId,
+		ReceptionId: closeRec.Id,
+	})
+
 	log.Info("reception successfully closed", "receptionId", closeRec.Id)
 
-	return pvz.CloseLastProductResponse{
-		Id:       closeRec.Id,
-		DateTime: closeRec.DateTime,
-		PvzId:    pvzId,
-		Status:   closeRec.Status,
-	}, nil
+	return resp, nil
 }
 
-func (ps *pvzServiceImpl) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListResponse, error) {
-	log := logger.Log.With(
+func (ps *pvzServiceImpl) ListWithFilterDate(ctx context.Context, req pvz.ListRequest) ([]pvz.ListResponse, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.ListWithFilterDate")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With(
 		"startDate", req.StartDate,
 		"endDate", *req.EndDate,
 		"limit", req.Limit,
@@ -190,7 +257,7 @@ func (ps *pvzServiceImpl) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListRes
 
 	offset := (req.Page - 1) * req.Limit
 
-	respRows, err := ps.pvzRepo.ListWithFilterDate(ps.conn, req, offset)
+	respRows, err := ps.pvzRepo.ListWithFilterDate(ctx, ps.conn, req, offset)
 	if err != nil {
 		log.Error("failed to fetch pvz list", "err", err)
 		return nil, errors.NewInternalError()
@@ -213,6 +280,11 @@ func (ps *pvzServiceImpl) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListRes
 					Id:               row.PvzId,
 					RegistrationDate: row.PvzRegDate,
 					City:             row.PvzCity,
+					Address:          nullStringPtr(row.PvzAddress),
+					Latitude:         nullFloatPtr(row.PvzLatitude),
+					Longitude:        nullFloatPtr(row.PvzLongitude),
+					WorkingHours:     nullStringPtr(row.PvzWorkingHours),
+					Phone:            nullStringPtr(row.PvzPhone),
 				},
 				Receptions: []pvz.ReceptionProducts{
 					{
@@ -221,6 +293,9 @@ func (ps *pvzServiceImpl) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListRes
 							DateTime: row.ReceptionDate,
 							PvzId:    row.PvzId,
 							Status:   row.ReceptionStatus,
+							OpenedBy: nullStringPtr(row.OpenedBy),
+							ClosedBy: nullStringPtr(row.ClosedBy),
+							ClosedAt: nullTimePtr(row.ClosedAt),
 						},
 						Products: []pvz.Product{},
 					},
@@ -238,6 +313,7 @@ func (ps *pvzServiceImpl) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListRes
 					DateTime:    row.ProductDate.Time,
 					Type:        row.ProductType.String,
 					ReceptionId: row.ReceptionId,
+					AcceptedBy:  nullStringPtr(row.AcceptedBy),
 				},
 			)
 		}
@@ -250,10 +326,258 @@ func (ps *pvzServiceImpl) ListWithFilterDate(req pvz.ListRequest) ([]pvz.ListRes
 	return result, nil
 }
 
-func (ps *pvzServiceImpl) getPvzOrErr(tx *sql.Tx, id string) (*models.Pvz, error) {
+// Import inserts the valid rows in one transaction and reports every row. Rows repeating an id of an earlier row or
+// of an existing pvz are reported as conflicts. A dry run does the same work and rolls it back.
+func (ps *pvzServiceImpl) Import(ctx context.Context, meta audit.Meta, req pvz.ImportRequest) (pvz.ImportResponse, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.Import", attribute.Int("pvz.import.rows", len(req.Rows)))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("rows", len(req.Rows), "dryRun", req.DryRun)
+	log.Info("starting Import pvzs")
+
+	resp := pvz.ImportResponse{
+		DryRun: req.DryRun,
+		Total:  len(req.Rows),
+		Rows:   make([]pvz.ImportResult, 0, len(req.Rows)),
+	}
+
+	tx, err := ps.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return pvz.ImportResponse{}, errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	seenIds := map[string]bool{}
+	for _, row := range req.Rows {
+		result := pvz.ImportResult{Row: row.Row, Errors: row.Errors}
+		if len(result.Errors) == 0 && row.Pvz.Id != nil && seenIds[*row.Pvz.Id] {
+			result.Errors = []errors.FieldError{importConflict(*row.Pvz.Id)}
+		}
+		if len(result.Errors) > 0 {
+			resp.Failed++
+			resp.Rows = append(resp.Rows, result)
+			continue
+		}
+
+		newPvz, err := ps.pvzRepo.Create(ctx, tx, row.Pvz)
+		if err != nil {
+			log.Error("failed to import pvz", "row", row.Row, "err", err)
+			return pvz.ImportResponse{}, errors.NewInternalError()
+		}
+		if newPvz == nil {
+			log.Warn("pvz with given id already exists", "row", row.Row, "pvzId", *row.Pvz.Id)
+			result.Errors = []errors.FieldError{importConflict(*row.Pvz.Id)}
+			resp.Failed++
+			resp.Rows = append(resp.Rows, result)
+			continue
+		}
+		seenIds[newPvz.Id] = true
+
+		created := pvz.CreateResponse(pvzDto(*newPvz))
+		if err := recordAudit(ctx, tx, ps.auditRepo, meta, audit.PvzImport, newPvz.Id, nil, nil, created); err != nil {
+			log.Error("failed to record audit entry", "err", err)
+			return pvz.ImportResponse{}, errors.NewInternalError()
+		}
+		result.Pvz = &created
+		resp.Imported++
+		resp.Rows = append(resp.Rows, result)
+	}
+
+	if req.DryRun {
+		log.Info("dry run of pvz import finished", "valid", resp.Imported, "failed", resp.Failed)
+		return resp, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return pvz.ImportResponse{}, errors.NewInternalError()
+	}
+
+	log.Info("pvzs successfully imported", "imported", resp.Imported, "failed", resp.Failed)
+	return resp, nil
+}
+
+func importConflict(id string) errors.FieldError {
+	return errors.NewFieldError(errors.NewObjectAlreadyExists("pvz", "id", id), "unique")
+}
+
+// Export hands fn the history of the window row by row as it is read from the cursor. An error returned by fn
+// stops the export and is returned as is.
+func (ps *pvzServiceImpl) Export(ctx context.Context, req export.Request, fn func(export.Row) error) error {
+	ctx, span := tracing.Start(ctx, "PvzService.Export")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With(
+		"startDate", *req.StartDate,
+		"endDate", *req.EndDate,
+		"format", req.Format,
+		"layout", req.Layout,
+	)
+	log.Info("starting Export")
+
+	if req.StartDate.After(*req.EndDate) {
+		log.Warn("start date is after end date")
+		return errors.NewStartDateAfterEndDate()
+	}
+
+	tx, err := ps.conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	rows := 0
+	var writeErr error
+	err = ps.pvzRepo.Export(ctx, tx, req.StartDate.UTC(), req.EndDate.UTC(), configs.DefaultExportBatchSize, func(raw pvz.RawList) error {
+		if writeErr = fn(exportRow(raw)); writeErr != nil {
+			return writeErr
+		}
+		rows++
+		return nil
+	})
+	if writeErr != nil {
+		log.Warn("export interrupted", "rows", rows, "err", writeErr)
+		return writeErr
+	}
+	if err != nil {
+		log.Error("failed to export pvz history", "rows", rows, "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return errors.NewInternalError()
+	}
+
+	log.Info("successfully exported pvz history", "rows", rows)
+	return nil
+}
+
+func exportRow(raw pvz.RawList) export.Row {
+	row := export.Row{
+		Pvz: pvz.Pvz{
+			Id:               raw.PvzId,
+			RegistrationDate: raw.PvzRegDate,
+			City:             raw.PvzCity,
+			Address:          nullStringPtr(raw.PvzAddress),
+			Latitude:         nullFloatPtr(raw.PvzLatitude),
+			Longitude:        nullFloatPtr(raw.PvzLongitude),
+			WorkingHours:     nullStringPtr(raw.PvzWorkingHours),
+			Phone:            nullStringPtr(raw.PvzPhone),
+		},
+		Reception: pvz.Reception{
+			Id:       raw.ReceptionId,
+			DateTime: raw.ReceptionDate,
+			PvzId:    raw.PvzId,
+			Status:   raw.ReceptionStatus,
+			OpenedBy: nullStringPtr(raw.OpenedBy),
+			ClosedBy: nullStringPtr(raw.ClosedBy),
+			ClosedAt: nullTimePtr(raw.ClosedAt),
+		},
+	}
+	if raw.ProductId.Valid {
+		row.Product = &pvz.Product{
+			Id:          raw.ProductId.String,
+			DateTime:    raw.ProductDate.Time,
+			Type:        raw.ProductType.String,
+			ReceptionId: raw.ReceptionId,
+			AcceptedBy:  nullStringPtr(raw.AcceptedBy),
+		}
+	}
+	return row
+}
+
+func (ps *pvzServiceImpl) Update(ctx context.Context, meta audit.Meta, pvzId string, req pvz.UpdateRequest) (pvz.Pvz, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.Update", attribute.String("pvz.id", pvzId))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("pvzId", pvzId)
+	log.Info("starting Update Pvz")
+
+	tx, err := ps.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return pvz.Pvz{}, errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	before, err := ps.getPvzOrErr(ctx, tx, pvzId)
+	if err != nil {
+		return pvz.Pvz{}, err
+	}
+
+	updated, err := ps.pvzRepo.Update(ctx, tx, pvzId, req)
+	if err != nil {
+		log.Error("failed to update pvz", "err", err)
+		return pvz.Pvz{}, errors.NewInternalError()
+	}
+	if updated == nil {
+		log.Warn("pvz disappeared before update")
+		return pvz.Pvz{}, errors.NewObjectNotFound("pvz")
+	}
+
+	resp := pvzDto(*updated)
+	if err := recordAudit(ctx, tx, ps.auditRepo, meta, audit.PvzUpdate, pvzId, nil, pvzDto(*before), resp); err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return pvz.Pvz{}, errors.NewInternalError()
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return pvz.Pvz{}, errors.NewInternalError()
+	}
+
+	log.Info("pvz successfully updated")
+	return resp, nil
+}
+
+func (ps *pvzServiceImpl) Nearby(ctx context.Context, req pvz.NearbyRequest) ([]pvz.NearbyResponse, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.Nearby")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With(
+		"lat", *req.Latitude,
+		"lon", *req.Longitude,
+		"radius", req.RadiusMeters,
+		"limit", req.Limit,
+	)
+	log.Info("starting Nearby pvzs")
+
+	found, err := ps.pvzRepo.Nearby(ctx, ps.conn, *req.Latitude, *req.Longitude, req.RadiusMeters, req.Limit)
+	if err != nil {
+		log.Error("failed to search nearby pvzs", "err", err)
+		return nil, errors.NewInternalError()
+	}
+
+	result := make([]pvz.NearbyResponse, 0, len(found))
+	for _, p := range found {
+		result = append(result, pvz.NearbyResponse{Pvz: pvzDto(p.Pvz), DistanceMeters: p.DistanceMeters})
+	}
+
+	log.Info("successfully found nearby pvzs", "count", len(result))
+	return result, nil
+}
+
+func pvzDto(p models.Pvz) pvz.Pvz {
+	return pvz.Pvz{
+		Id:               p.Id,
+		RegistrationDate: p.RegistrationDate,
+		City:             p.City,
+		Address:          nullStringPtr(p.Address),
+		Latitude:         nullFloatPtr(p.Latitude),
+		Longitude:        nullFloatPtr(p.Longitude),
+		WorkingHours:     nullStringPtr(p.WorkingHours),
+		Phone:            nullStringPtr(p.Phone),
+	}
+}
+
+func (ps *pvzServiceImpl) getPvzOrErr(ctx context.Context, tx *sql.Tx, id string) (*models.Pvz, error) {
+	log := logger.FromContext(ctx).With("pvzId", id)
 	log.Info("starting getPvzOrErr")
 
-	p, err := ps.pvzRepo.GetById(tx, id)
+	p, err := ps.pvzRepo.GetById(ctx, tx, id)
 	if err != nil {
 		log.Error("failed to fetch pvz", "err", err)
 		return nil, errors.NewInternalError()
@@ -267,9 +591,10 @@ func (ps *pvzServiceImpl) getPvzOrErr(tx *sql.Tx, id string) (*models.Pvz, error
 	return p, nil
 }
 
-func (ps *pvzServiceImpl) getReceptionOrErr(tx *sql.Tx, pvzId string) (*models.Reception, error) {
+func (ps *pvzServiceImpl) getReceptionOrErr(ctx context.Context, tx *sql.Tx, pvzId string) (*models.Reception, error) {
+	log := logger.FromContext(ctx).With("pvzId", pvzId)
 	log.Info("starting getReceptionOrErr")
-	rec, err := ps.receptionRepo.GetByPvzId(tx, pvzId)
+	rec, err := ps.receptionRepo.GetByPvzId(ctx, tx, pvzId)
 	if err != nil {
 		log.Error("failed to fetch reception", "err", err)
 		return nil, errors.NewInternalError()
@@ -286,3 +611,25 @@ func (ps *pvzServiceImpl) getReceptionOrErr(tx *sql.Tx, pvzId string) (*models.R
 	log.Info("reception found", "receptionId", rec.Id)
 	return rec, nil
 }
+
+func (ps *pvzServiceImpl) List(ctx context.Context) ([]pvz.Pvz, error) {
+	ctx, span := tracing.Start(ctx, "PvzService.List")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+	log.Info("starting List pvzs")
+
+	pvzs, err := ps.pvzRepo.List(ctx, ps.conn)
+	if err != nil {
+		log.Error("failed to list pvzs", "err", err)
+		return nil, errors.NewInternalError()
+	}
+
+	result := make([]pvz.Pvz, 0, len(pvzs))
+	for _, p := range pvzs {
+		result = append(result, pvzDto(p))
+	}
+
+	log.Info("successfully listed pvzs", "count", len(result))
+	return result, nil
+}
@@ -1,7 +1,10 @@
 package services_test
 
 import (
+	"context"
 	"database/sql"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,8 +12,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"pvz/internal/events"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/export"
 	"pvz/internal/models/pvz"
 	"pvz/internal/models/reception"
 	"pvz/internal/services"
@@ -25,7 +31,7 @@ func TestPvzService_Create(t *testing.T) {
 		defer db.Close()
 
 		pvzRepo := new(MockPvzRepo)
-		service := services.NewPvzService(pvzRepo, nil, nil, db)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 
 		pvzID := "existing-id"
 		req := pvz.CreateRequest{
@@ -34,26 +40,52 @@ func TestPvzService_Create(t *testing.T) {
 		}
 
 		mockDB.ExpectBegin()
-		pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), pvzID).
-			Return(&models.Pvz{Id: pvzID}, nil).
+		pvzRepo.On("Create", mock.AnythingOfType("*sql.Tx"), req).
+			Return((*models.Pvz)(nil), nil).
 			Once()
 		mockDB.ExpectRollback()
 
-		_, err := service.Create(req)
+		_, err := service.Create(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.ObjectAlreadyExists{}, err)
 		pvzRepo.AssertExpectations(t)
 	})
 
+	t.Run("given id and registration date are kept", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		pvzID := "b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70"
+		registered := time.Date(2019, 5, 20, 12, 0, 0, 0, time.UTC)
+		req := pvz.CreateRequest{Id: &pvzID, RegistrationDate: &registered, City: "Казань"}
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("Create", mock.AnythingOfType("*sql.Tx"), req).
+			Return(&models.Pvz{Id: pvzID, RegistrationDate: registered, City: "Казань"}, nil).
+			Once()
+		mockDB.ExpectCommit()
+
+		resp, err := service.Create(context.Background(), audit.Meta{}, req)
+
+		require.NoError(t, err)
+		require.Equal(t, pvzID, resp.Id)
+		require.Equal(t, registered, resp.RegistrationDate)
+		pvzRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
 	t.Run("transaction begin error", func(t *testing.T) {
 		db, mockDB, _ := sqlmock.New()
 		defer db.Close()
 
-		service := services.NewPvzService(nil, nil, nil, db)
+		service := services.NewPvzService(nil, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 		mockDB.ExpectBegin().WillReturnError(errors.NewInternalError())
 
-		_, err := service.Create(pvz.CreateRequest{City: "Казань"})
+		_, err := service.Create(context.Background(), audit.Meta{}, pvz.CreateRequest{City: "Казань"})
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -70,7 +102,7 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 		pvzRepo := new(MockPvzRepo)
 		productRepo := new(MockProductRepo)
 		recRepo := new(MockReceptionRepo)
-		service := services.NewPvzService(pvzRepo, productRepo, recRepo, db)
+		service := services.NewPvzService(pvzRepo, productRepo, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		pvzID := "pvz123"
 		recID := "rec456"
@@ -85,7 +117,7 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 			Return(product, nil)
 		mockDB.ExpectCommit()
 
-		resp, err := service.DeleteLastProduct(pvzID)
+		resp, err := service.DeleteLastProduct(context.Background(), audit.Meta{}, pvzID)
 
 		require.NoError(t, err)
 		require.Equal(t, product.Id, resp.Id)
@@ -99,7 +131,7 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 		pvzRepo := new(MockPvzRepo)
 		productRepo := new(MockProductRepo)
 		recRepo := new(MockReceptionRepo)
-		service := services.NewPvzService(pvzRepo, productRepo, recRepo, db)
+		service := services.NewPvzService(pvzRepo, productRepo, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		mockDB.ExpectBegin()
 		pvzRepo.On("GetById", mock.Anything, mock.Anything).Return(&models.Pvz{}, nil)
@@ -110,7 +142,7 @@ func TestPvzService_DeleteLastProduct(t *testing.T) {
 		productRepo.On("DeleteLast", mock.Anything, mock.Anything).Return((*models.Product)(nil), nil)
 		mockDB.ExpectRollback()
 
-		_, err := service.DeleteLastProduct("pvz123")
+		_, err := service.DeleteLastProduct(context.Background(), audit.Meta{}, "pvz123")
 
 		require.Error(t, err)
 		require.IsType(t, errors.ObjectHasNotSubObjects{}, err)
@@ -126,7 +158,7 @@ func TestPvzService_CLoseLastReception(t *testing.T) {
 
 		pvzRepo := new(MockPvzRepo)
 		recRepo := new(MockReceptionRepo)
-		service := services.NewPvzService(pvzRepo, nil, recRepo, db)
+		service := services.NewPvzService(pvzRepo, nil, recRepo, newMockAuditRepo(), events.NewBus(1), db)
 
 		pvzID := "pvz123"
 		rec := &models.Reception{
@@ -140,10 +172,10 @@ func TestPvzService_CLoseLastReception(t *testing.T) {
 			Id:     "uuid",
 			Status: reception.InProgressStatus,
 		}, nil)
-		recRepo.On("SetStatus", mock.Anything, reception.CloseStatus, pvzID).Return(rec, nil)
+		recRepo.On("Close", mock.Anything, pvzID, "").Return(rec, nil)
 		mockDB.ExpectCommit()
 
-		resp, err := service.CLoseLastReception(pvzID)
+		resp, err := service.CLoseLastReception(context.Background(), audit.Meta{}, pvzID)
 
 		require.NoError(t, err)
 		require.Equal(t, rec.Status, resp.Status)
@@ -159,7 +191,7 @@ func TestPvzService_ListWithFilterDate(t *testing.T) {
 		defer db.Close()
 
 		pvzRepo := new(MockPvzRepo)
-		service := services.NewPvzService(pvzRepo, nil, nil, db)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 
 		now := time.Now()
 		req := pvz.ListRequest{
@@ -172,6 +204,10 @@ func TestPvzService_ListWithFilterDate(t *testing.T) {
 		rawData := []pvz.RawList{
 			{
 				PvzId:           "pvz1",
+				PvzAddress:      sql.NullString{String: "Тверская, 1", Valid: true},
+				PvzLatitude:     sql.NullFloat64{Float64: 55.7558, Valid: true},
+				PvzLongitude:    sql.NullFloat64{Float64: 37.6173, Valid: true},
+				PvzPhone:        sql.NullString{String: "+74951234567", Valid: true},
 				ReceptionId:     "rec1",
 				ProductId:       sql.NullString{String: "prod1", Valid: true},
 				ProductType:     sql.NullString{String: "электроника", Valid: true},
@@ -181,23 +217,29 @@ func TestPvzService_ListWithFilterDate(t *testing.T) {
 
 		pvzRepo.On("ListWithFilterDate", db, req, 0).Return(rawData, nil)
 
-		result, err := service.ListWithFilterDate(req)
+		result, err := service.ListWithFilterDate(context.Background(), req)
 
 		require.NoError(t, err)
 		require.Len(t, result, 1)
 		require.Len(t, result[0].Receptions, 1)
 		require.Len(t, result[0].Receptions[0].Products, 1)
+		require.NotNil(t, result[0].Pvz.Address)
+		require.Equal(t, "Тверская, 1", *result[0].Pvz.Address)
+		require.NotNil(t, result[0].Pvz.Latitude)
+		require.Equal(t, 55.7558, *result[0].Pvz.Latitude)
+		require.Equal(t, "+74951234567", *result[0].Pvz.Phone)
+		require.Nil(t, result[0].Pvz.WorkingHours)
 	})
 
 	t.Run("invalid date range", func(t *testing.T) {
 		db, _, _ := sqlmock.New()
 		defer db.Close()
 
-		service := services.NewPvzService(nil, nil, nil, db)
+		service := services.NewPvzService(nil, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 		start := time.Now()
 		end := start.Add(-time.Hour)
 
-		_, err := service.ListWithFilterDate(pvz.ListRequest{
+		_, err := service.ListWithFilterDate(context.Background(), pvz.ListRequest{
 			StartDate: &start,
 			EndDate:   &end,
 		})
@@ -207,3 +249,304 @@ func TestPvzService_ListWithFilterDate(t *testing.T) {
 	})
 
 }
+
+func TestPvzService_Export(t *testing.T) {
+	logger.Init("debug")
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	req := export.Request{StartDate: &start, EndDate: &end, Format: export.FormatCsv, Layout: export.LayoutFlat}
+
+	rawData := []pvz.RawList{
+		{
+			PvzId:           "pvz1",
+			PvzWorkingHours: sql.NullString{String: "09:00-21:00", Valid: true},
+			ReceptionId:     "rec1",
+			ReceptionStatus: reception.CloseStatus,
+			ProductId:       sql.NullString{String: "prod1", Valid: true},
+			ProductType:     sql.NullString{String: "электроника", Valid: true},
+		},
+		{PvzId: "pvz1", ReceptionId: "rec2", ReceptionStatus: reception.InProgressStatus},
+	}
+
+	t.Run("rows are handed over in a read-only transaction", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("Export", mock.AnythingOfType("*sql.Tx"), start, end, mock.AnythingOfType("int")).Return(rawData, nil)
+		mockDB.ExpectCommit()
+
+		var rows []export.Row
+		err := service.Export(context.Background(), req, func(row export.Row) error {
+			rows = append(rows, row)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, "prod1", rows[0].Product.Id)
+		require.Equal(t, "rec1", rows[0].Product.ReceptionId)
+		require.Equal(t, "09:00-21:00", *rows[0].Pvz.WorkingHours)
+		require.Nil(t, rows[0].Pvz.Latitude)
+		require.Nil(t, rows[1].Product)
+		require.Equal(t, "pvz1", rows[1].Reception.PvzId)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("window with an offset is read in UTC", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		moscow := time.FixedZone("MSK", 3*60*60)
+		localStart, localEnd := start.In(moscow), end.In(moscow)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("Export", mock.AnythingOfType("*sql.Tx"), start, end, mock.AnythingOfType("int")).Return([]pvz.RawList{}, nil)
+		mockDB.ExpectCommit()
+
+		err := service.Export(context.Background(), export.Request{StartDate: &localStart, EndDate: &localEnd},
+			func(row export.Row) error { return nil })
+
+		require.NoError(t, err)
+		pvzRepo.AssertExpectations(t)
+	})
+
+	t.Run("write error stops the export", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("Export", mock.AnythingOfType("*sql.Tx"), start, end, mock.AnythingOfType("int")).Return(rawData, nil)
+		mockDB.ExpectRollback()
+
+		calls := 0
+		err := service.Export(context.Background(), req, func(row export.Row) error {
+			calls++
+			return io.ErrClosedPipe
+		})
+
+		require.ErrorIs(t, err, io.ErrClosedPipe)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("Export", mock.AnythingOfType("*sql.Tx"), start, end, mock.AnythingOfType("int")).
+			Return([]pvz.RawList{}, sql.ErrConnDone)
+		mockDB.ExpectRollback()
+
+		err := service.Export(context.Background(), req, func(row export.Row) error { return nil })
+
+		require.IsType(t, errors.InternalError{}, err)
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		service := services.NewPvzService(nil, nil, nil, newMockAuditRepo(), events.NewBus(1), nil)
+
+		err := service.Export(context.Background(), export.Request{StartDate: &end, EndDate: &start}, nil)
+
+		require.IsType(t, errors.StartDateAfterEndDate{}, err)
+	})
+}
+
+func TestPvzService_Import(t *testing.T) {
+	logger.Init("debug")
+
+	existingId := "b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70"
+	newId := "c7b4fe63-4d4b-4c3f-8a4b-3c4d5e6f7081"
+	rows := []pvz.ImportRow{
+		{Row: 1, Pvz: pvz.CreateRequest{City: "Москва"}},
+		{Row: 2, Pvz: pvz.CreateRequest{Id: &existingId, City: "Казань"}},
+		{Row: 3, Errors: []errors.FieldError{errors.NewFieldError(errors.NewPropertyMissing("city"), "required")}},
+		{Row: 4, Pvz: pvz.CreateRequest{Id: &newId, City: "Казань"}},
+		{Row: 5, Pvz: pvz.CreateRequest{Id: &newId, City: "Москва"}},
+	}
+
+	setup := func(pvzRepo *MockPvzRepo) {
+		pvzRepo.On("Create", mock.AnythingOfType("*sql.Tx"), rows[0].Pvz).Return(&models.Pvz{Id: "generated", City: "Москва"}, nil).Once()
+		pvzRepo.On("Create", mock.AnythingOfType("*sql.Tx"), rows[1].Pvz).Return((*models.Pvz)(nil), nil).Once()
+		pvzRepo.On("Create", mock.AnythingOfType("*sql.Tx"), rows[3].Pvz).Return(&models.Pvz{Id: newId, City: "Казань"}, nil).Once()
+	}
+
+	t.Run("valid rows are inserted, the rest reported", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		setup(pvzRepo)
+		mockDB.ExpectCommit()
+
+		resp, err := service.Import(context.Background(), audit.Meta{}, pvz.ImportRequest{Rows: rows})
+
+		require.NoError(t, err)
+		require.Equal(t, 5, resp.Total)
+		require.Equal(t, 2, resp.Imported)
+		require.Equal(t, 3, resp.Failed)
+		require.Len(t, resp.Rows, 5)
+		require.Equal(t, "generated", resp.Rows[0].Pvz.Id)
+		require.Equal(t, "pvz_already_exists", resp.Rows[1].Errors[0].Code)
+		require.Equal(t, "property_missing", resp.Rows[2].Errors[0].Code)
+		require.Equal(t, newId, resp.Rows[3].Pvz.Id)
+		require.Equal(t, "pvz_already_exists", resp.Rows[4].Errors[0].Code)
+		require.Nil(t, resp.Rows[4].Pvz)
+		pvzRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("dry run rolls back", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		setup(pvzRepo)
+		mockDB.ExpectRollback()
+
+		resp, err := service.Import(context.Background(), audit.Meta{}, pvz.ImportRequest{Rows: rows, DryRun: true})
+
+		require.NoError(t, err)
+		require.True(t, resp.DryRun)
+		require.Equal(t, 2, resp.Imported)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("insert error fails the whole import", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("Create", mock.AnythingOfType("*sql.Tx"), rows[0].Pvz).Return((*models.Pvz)(nil), sql.ErrConnDone).Once()
+		mockDB.ExpectRollback()
+
+		_, err := service.Import(context.Background(), audit.Meta{}, pvz.ImportRequest{Rows: rows})
+
+		require.IsType(t, errors.InternalError{}, err)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
+
+func TestPvzService_Update(t *testing.T) {
+	logger.Init("debug")
+
+	pvzID := "b6a3ed52-3c3a-4b2e-9f3a-2b3c4d5e6f70"
+	lat, lon := 55.7887, 49.1221
+	req := pvz.UpdateRequest{City: "Казань", Latitude: &lat, Longitude: &lon}
+
+	t.Run("successful update", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		auditRepo := new(MockAuditRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, auditRepo, events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), pvzID).Return(&models.Pvz{Id: pvzID, City: "Москва"}, nil).Once()
+		pvzRepo.On("Update", mock.AnythingOfType("*sql.Tx"), pvzID, req).Return(&models.Pvz{
+			Id:        pvzID,
+			City:      "Казань",
+			Latitude:  sql.NullFloat64{Float64: lat, Valid: true},
+			Longitude: sql.NullFloat64{Float64: lon, Valid: true},
+		}, nil).Once()
+		auditRepo.On("Create", mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(entry models.AuditEntry) bool {
+			return entry.Action == string(audit.PvzUpdate) && entry.PvzId.String == pvzID &&
+				strings.Contains(string(entry.Before), "Москва") && strings.Contains(string(entry.After), "Казань")
+		})).Return(nil).Once()
+		mockDB.ExpectCommit()
+
+		resp, err := service.Update(context.Background(), audit.Meta{}, pvzID, req)
+
+		require.NoError(t, err)
+		require.Equal(t, "Казань", resp.City)
+		require.Equal(t, lat, *resp.Latitude)
+		require.Nil(t, resp.Address)
+		pvzRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		mockDB.ExpectBegin()
+		pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), pvzID).Return((*models.Pvz)(nil), nil).Once()
+		mockDB.ExpectRollback()
+
+		_, err := service.Update(context.Background(), audit.Meta{}, pvzID, req)
+
+		require.IsType(t, errors.ObjectNotFound{}, err)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
+
+func TestPvzService_Nearby(t *testing.T) {
+	logger.Init("debug")
+
+	lat, lon := 55.7558, 37.6173
+	req := pvz.NearbyRequest{Latitude: &lat, Longitude: &lon, RadiusMeters: 1000, Limit: 10}
+
+	t.Run("found pvzs keep their distance", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		pvzRepo.On("Nearby", db, lat, lon, 1000.0, 10).Return([]models.PvzDistance{
+			{Pvz: models.Pvz{Id: "pvz1", City: "Москва", Phone: sql.NullString{String: "+74950000000", Valid: true}}, DistanceMeters: 120},
+			{Pvz: models.Pvz{Id: "pvz2", City: "Москва"}, DistanceMeters: 870},
+		}, nil)
+
+		result, err := service.Nearby(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, "pvz1", result[0].Pvz.Id)
+		require.Equal(t, "+74950000000", *result[0].Pvz.Phone)
+		require.Equal(t, 870.0, result[1].DistanceMeters)
+	})
+
+	t.Run("nothing around is an empty list", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewPvzService(pvzRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		pvzRepo.On("Nearby", db, lat, lon, 1000.0, 10).Return([]models.PvzDistance(nil), nil)
+
+		result, err := service.Nearby(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Empty(t, result)
+	})
+}
@@ -1,43 +1,60 @@
 package services
 
 import (
+	"context"
 	"database/sql"
+	"go.opentelemetry.io/otel/attribute"
+	"pvz/internal/events"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/reception"
 	"pvz/internal/repositories"
+	"pvz/internal/tracing"
 	"pvz/pkg/errors"
+	"time"
 )
 
 type ReceptionService interface {
-	Create(req reception.CreateRequest) (reception.CreateResponse, error)
+	Create(ctx context.Context, meta audit.Meta, req reception.CreateRequest) (reception.CreateResponse, error)
+	GetCurrent(ctx context.Context, pvzId string) (reception.CurrentResponse, error)
+	ListStuck(ctx context.Context, olderThan time.Duration) ([]reception.CreateResponse, error)
 }
 
 type receptionServiceImpl struct {
 	receptionRepo repositories.ReceptionRepository
 	pvzRepo       repositories.PvzRepository
+	productRepo   repositories.ProductRepository
+	auditRepo     repositories.AuditRepository
+	eventBus      events.Publisher
 	conn          *sql.DB
 }
 
-func NewReceptionService(receptionRepo repositories.ReceptionRepository, pvzRepo repositories.PvzRepository, conn *sql.DB) ReceptionService {
+func NewReceptionService(receptionRepo repositories.ReceptionRepository, pvzRepo repositories.PvzRepository, productRepo repositories.ProductRepository, auditRepo repositories.AuditRepository, eventBus events.Publisher, conn *sql.DB) ReceptionService {
 	return &receptionServiceImpl{
 		receptionRepo: receptionRepo,
 		pvzRepo:       pvzRepo,
+		productRepo:   productRepo,
+		auditRepo:     auditRepo,
+		eventBus:      eventBus,
 		conn:          conn,
 	}
 }
-func (rs *receptionServiceImpl) Create(req reception.CreateRequest) (reception.CreateResponse, error) {
-	log := logger.Log.With("pvzId", req.PvzId)
+func (rs *receptionServiceImpl) Create(ctx context.Context, meta audit.Meta, req reception.CreateRequest) (reception.CreateResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.Create", attribute.String("pvz.id", req.PvzId))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("pvzId", req.PvzId)
 	log.Info("starting Create reception")
 
-	tx, err := rs.conn.Begin()
+	tx, err := rs.conn.BeginTx(ctx, nil)
 	if err != nil {
 		log.Error("failed to begin transaction", "err", err)
 		return reception.CreateResponse{}, errors.InternalError{}
 	}
 	defer tx.Rollback()
 
-	rec, err := rs.receptionRepo.GetByPvzId(tx, req.PvzId)
+	rec, err := rs.receptionRepo.GetByPvzId(ctx, tx, req.PvzId)
 	if err != nil {
 		log.Error("failed to get reception by pvzId", "err", err)
 		return reception.CreateResponse{}, errors.NewInternalError()
@@ -47,7 +64,7 @@ func (rs *receptionServiceImpl) Create(req reception.CreateRequest) (reception.C
 		return reception.CreateResponse{}, errors.NewReceptionIsNotClosed(req.PvzId)
 	}
 
-	pvz, err := rs.pvzRepo.GetById(tx, req.PvzId)
+	pvz, err := rs.pvzRepo.GetById(ctx, tx, req.PvzId)
 	if err != nil {
 		log.Error("failed to get pvz by id", "err", err)
 		return reception.CreateResponse{}, errors.NewInternalError()
@@ -58,27 +75,132 @@ func (rs *receptionServiceImpl) Create(req reception.CreateRequest) (reception.C
 	}
 
 	newRec := models.Reception{
-		PvzId:  req.PvzId,
-		Status: reception.InProgressStatus,
+		PvzId:    req.PvzId,
+		Status:   reception.InProgressStatus,
+		OpenedBy: toNullString(meta.Actor.UserId),
 	}
 
-	recRep, err := rs.receptionRepo.Create(tx, newRec)
+	recRep, err := rs.receptionRepo.Create(ctx, tx, newRec)
 	if err != nil {
 		log.Error("failed to create reception", "err", err)
 		return reception.CreateResponse{}, err
 	}
 
+	resp := reception.CreateResponse{
+		Id:       recRep.Id,
+		DateTime: recRep.DateTime,
+		PvzId:    newRec.PvzId,
+		Status:   newRec.Status,
+		OpenedBy: nullStringPtr(recRep.OpenedBy),
+	}
+
+	span.SetAttributes(attribute.String("reception.id", recRep.Id))
+
+	err = recordAudit(ctx, tx, rs.auditRepo, meta, audit.ReceptionOpen, req.PvzId,
+		map[string]string{"receptionId": recRep.Id}, nil, resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return reception.CreateResponse{}, errors.NewInternalError()
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Error("failed to commit transaction", "err", err)
 		return reception.CreateResponse{}, errors.NewInternalError()
 	}
 
+	rs.eventBus.Publish(events.Event{
+		Type:        events.ReceptionOpened,
+		PvzId:       newRec.PvzId,
+		ReceptionId: recRep.Id,
+		DateTime:    recRep.DateTime,
+	})
+
 	log.Info("reception created successfully", "receptionId", recRep.Id, "status", recRep.Status)
-	return reception.CreateResponse{
-		Id:       recRep.Id,
-		DateTime: recRep.DateTime,
-		PvzId:    newRec.PvzId,
-		Status:   newRec.Status,
-	}, nil
+	return resp, nil
+}
+
+func (rs *receptionServiceImpl) GetCurrent(ctx context.Context, pvzId string) (reception.CurrentResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.GetCurrent", attribute.String("pvz.id", pvzId))
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("pvzId", pvzId)
+	log.Info("starting GetCurrent reception")
+
+	pvz, err := rs.pvzRepo.GetById(ctx, rs.conn, pvzId)
+	if err != nil {
+		log.Error("failed to get pvz by id", "err", err)
+		return reception.CurrentResponse{}, errors.NewInternalError()
+	}
+	if pvz == nil {
+		log.Warn("pvz not found")
+		return reception.CurrentResponse{}, errors.NewObjectNotFound("pvz")
+	}
+
+	rec, err := rs.receptionRepo.GetByPvzId(ctx, rs.conn, pvzId)
+	if err != nil {
+		log.Error("failed to get reception by pvzId", "err", err)
+		return reception.CurrentResponse{}, errors.NewInternalError()
+	}
+	if rec == nil || rec.Status != reception.InProgressStatus {
+		log.Warn("no in-progress reception")
+		return reception.CurrentResponse{}, errors.NewNoInProgressReception()
+	}
+
+	products, err := rs.productRepo.ListByReception(ctx, rs.conn, rec.Id)
+	if err != nil {
+		log.Error("failed to list reception products", "err", err)
+		return reception.CurrentResponse{}, errors.NewInternalError()
+	}
+
+	resp := reception.CurrentResponse{
+		Id:            rec.Id,
+		DateTime:      rec.DateTime,
+		PvzId:         rec.PvzId,
+		Status:        rec.Status,
+		OpenedBy:      nullStringPtr(rec.OpenedBy),
+		Products:      make([]reception.Product, 0, len(products)),
+		ProductCounts: map[string]int{},
+	}
+	for _, p := range products {
+		resp.Products = append(resp.Products, reception.Product{
+			Id:         p.Id,
+			DateTime:   p.DateTime,
+			Type:       p.Type,
+			AcceptedBy: nullStringPtr(p.AcceptedBy),
+		})
+		resp.ProductCounts[p.Type]++
+	}
+
+	log.Info("current reception found", "receptionId", rec.Id, "products", len(products))
+	return resp, nil
+}
+
+// ListStuck returns receptions that have been in progress for longer than olderThan.
+func (rs *receptionServiceImpl) ListStuck(ctx context.Context, olderThan time.Duration) ([]reception.CreateResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.ListStuck")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("olderThan", olderThan)
+	log.Info("starting ListStuck receptions")
+
+	recs, err := rs.receptionRepo.ListInProgressBefore(ctx, rs.conn, repositories.Now().Add(-olderThan))
+	if err != nil {
+		log.Error("failed to list in-progress receptions", "err", err)
+		return nil, errors.NewInternalError()
+	}
+
+	result := make([]reception.CreateResponse, 0, len(recs))
+	for _, rec := range recs {
+		result = append(result, reception.CreateResponse{
+			Id:       rec.Id,
+			DateTime: rec.DateTime,
+			PvzId:    rec.PvzId,
+			Status:   rec.Status,
+			OpenedBy: nullStringPtr(rec.OpenedBy),
+		})
+	}
+
+	log.Info("stuck receptions found", "count", len(result))
+	return result, nil
 }
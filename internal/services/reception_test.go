@@ -1,13 +1,18 @@
 package services_test
 
 import (
+	"context"
+	"database/sql"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
 
+	"pvz/internal/events"
 	"pvz/internal/logger"
 	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/auth"
 	"pvz/internal/models/reception"
 	"pvz/internal/services"
 	"pvz/pkg/errors"
@@ -22,10 +27,14 @@ func TestReceptionService_Create(t *testing.T) {
 
 		recRepo := new(MockReceptionRepo)
 		pvzRepo := new(MockPvzRepo)
-		service := services.NewReceptionService(recRepo, pvzRepo, db)
+		bus := events.NewBus(1)
+		service := services.NewReceptionService(recRepo, pvzRepo, nil, newMockAuditRepo(), bus, db)
 
 		pvzID := "550e8400-e29b-41d4-a716-446655440000"
+		userID := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
 		req := reception.CreateRequest{PvzId: pvzID}
+		sub := bus.Subscribe(pvzID)
+		defer bus.Unsubscribe(sub)
 
 		mockDB.ExpectBegin()
 		recRepo.On("GetByPvzId", mock.AnythingOfType("*sql.Tx"), pvzID).
@@ -35,15 +44,19 @@ func TestReceptionService_Create(t *testing.T) {
 			Return(&models.Pvz{Id: pvzID}, nil).
 			Once()
 		recRepo.On("Create", mock.AnythingOfType("*sql.Tx"), mock.MatchedBy(func(r models.Reception) bool {
-			return r.PvzId == pvzID && r.Status == reception.InProgressStatus
-		})).Return(&models.Reception{Id: "rec123"}, nil).
+			return r.PvzId == pvzID && r.Status == reception.InProgressStatus && r.OpenedBy.String == userID
+		})).Return(&models.Reception{Id: "rec123", OpenedBy: sql.NullString{String: userID, Valid: true}}, nil).
 			Once()
 		mockDB.ExpectCommit()
 
-		resp, err := service.Create(req)
+		resp, err := service.Create(context.Background(), audit.Meta{Actor: auth.Principal{UserId: userID, Role: auth.Employee}}, req)
 
 		require.NoError(t, err)
 		require.Equal(t, "rec123", resp.Id)
+		require.Equal(t, userID, *resp.OpenedBy)
+		ev := <-sub.Events()
+		require.Equal(t, events.ReceptionOpened, ev.Type)
+		require.Equal(t, "rec123", ev.ReceptionId)
 		recRepo.AssertExpectations(t)
 		pvzRepo.AssertExpectations(t)
 		require.NoError(t, mockDB.ExpectationsWereMet())
@@ -55,10 +68,10 @@ func TestReceptionService_Create(t *testing.T) {
 
 		recRepo := new(MockReceptionRepo)
 		pvzRepo := new(MockPvzRepo)
-		service := services.NewReceptionService(recRepo, pvzRepo, db)
+		service := services.NewReceptionService(recRepo, pvzRepo, nil, newMockAuditRepo(), events.NewBus(1), db)
 		mockDB.ExpectBegin().WillReturnError(errors.NewInternalError())
 
-		_, err := service.Create(reception.CreateRequest{PvzId: "a"})
+		_, err := service.Create(context.Background(), audit.Meta{}, reception.CreateRequest{PvzId: "a"})
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -69,7 +82,7 @@ func TestReceptionService_Create(t *testing.T) {
 		defer db.Close()
 
 		recRepo := new(MockReceptionRepo)
-		service := services.NewReceptionService(recRepo, nil, db)
+		service := services.NewReceptionService(recRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 
 		pvzID := "550e8400-e29b-41d4-a716-446655440000"
 		req := reception.CreateRequest{PvzId: pvzID}
@@ -80,7 +93,7 @@ func TestReceptionService_Create(t *testing.T) {
 			Once()
 		mockDB.ExpectRollback()
 
-		_, err := service.Create(req)
+		_, err := service.Create(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.ReceptionIsNotClosed{}, err)
@@ -92,7 +105,7 @@ func TestReceptionService_Create(t *testing.T) {
 		defer db.Close()
 
 		recRepo := new(MockReceptionRepo)
-		service := services.NewReceptionService(recRepo, nil, db)
+		service := services.NewReceptionService(recRepo, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 
 		req := reception.CreateRequest{PvzId: "pvz123"}
 
@@ -102,7 +115,7 @@ func TestReceptionService_Create(t *testing.T) {
 			Once()
 		mockDB.ExpectRollback()
 
-		_, err := service.Create(req)
+		_, err := service.Create(context.Background(), audit.Meta{}, req)
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
@@ -113,13 +126,76 @@ func TestReceptionService_Create(t *testing.T) {
 		db, mockDB, _ := sqlmock.New()
 		defer db.Close()
 
-		service := services.NewReceptionService(nil, nil, db)
+		service := services.NewReceptionService(nil, nil, nil, newMockAuditRepo(), events.NewBus(1), db)
 		mockDB.ExpectBegin().WillReturnError(errors.NewInternalError())
 
-		_, err := service.Create(reception.CreateRequest{})
+		_, err := service.Create(context.Background(), audit.Meta{}, reception.CreateRequest{})
 
 		require.Error(t, err)
 		require.IsType(t, errors.InternalError{}, err)
 		require.NoError(t, mockDB.ExpectationsWereMet())
 	})
 }
+
+func TestReceptionService_GetCurrent(t *testing.T) {
+	logger.Init("debug")
+
+	pvzID := "550e8400-e29b-41d4-a716-446655440000"
+
+	t.Run("current reception with product counts", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		recRepo := new(MockReceptionRepo)
+		pvzRepo := new(MockPvzRepo)
+		productRepo := new(MockProductRepo)
+		service := services.NewReceptionService(recRepo, pvzRepo, productRepo, newMockAuditRepo(), events.NewBus(1), db)
+
+		pvzRepo.On("GetById", db, pvzID).Return(&models.Pvz{Id: pvzID}, nil).Once()
+		recRepo.On("GetByPvzId", db, pvzID).
+			Return(&models.Reception{Id: "rec1", PvzId: pvzID, Status: reception.InProgressStatus}, nil).Once()
+		productRepo.On("ListByReception", db, "rec1").Return([]models.Product{
+			{Id: "p1", Type: "обувь", ReceptionId: "rec1"},
+			{Id: "p2", Type: "обувь", ReceptionId: "rec1"},
+			{Id: "p3", Type: "одежда", ReceptionId: "rec1"},
+		}, nil).Once()
+
+		resp, err := service.GetCurrent(context.Background(), pvzID)
+
+		require.NoError(t, err)
+		require.Equal(t, "rec1", resp.Id)
+		require.Len(t, resp.Products, 3)
+		require.Equal(t, map[string]int{"обувь": 2, "одежда": 1}, resp.ProductCounts)
+	})
+
+	t.Run("closed reception", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		recRepo := new(MockReceptionRepo)
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewReceptionService(recRepo, pvzRepo, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		pvzRepo.On("GetById", db, pvzID).Return(&models.Pvz{Id: pvzID}, nil).Once()
+		recRepo.On("GetByPvzId", db, pvzID).
+			Return(&models.Reception{Id: "rec1", Status: reception.CloseStatus}, nil).Once()
+
+		_, err := service.GetCurrent(context.Background(), pvzID)
+
+		require.IsType(t, errors.NoInProgressReception{}, err)
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewReceptionService(nil, pvzRepo, nil, newMockAuditRepo(), events.NewBus(1), db)
+
+		pvzRepo.On("GetById", db, pvzID).Return((*models.Pvz)(nil), nil).Once()
+
+		_, err := service.GetCurrent(context.Background(), pvzID)
+
+		require.IsType(t, errors.ObjectNotFound{}, err)
+	})
+}
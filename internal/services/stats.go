@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"pvz/internal/logger"
+	"pvz/internal/models/stats"
+	"pvz/internal/repositories"
+	"pvz/internal/tracing"
+	"pvz/pkg/errors"
+	"slices"
+)
+
+type StatsService interface {
+	Get(ctx context.Context, req stats.Request) (stats.Response, error)
+}
+
+type statsServiceImpl struct {
+	statsRepo repositories.StatsRepository
+	conn      *sql.DB
+}
+
+func NewStatsService(statsRepo repositories.StatsRepository, conn *sql.DB) StatsService {
+	return &statsServiceImpl{
+		statsRepo: statsRepo,
+		conn:      conn,
+	}
+}
+
+func (ss *statsServiceImpl) Get(ctx context.Context, req stats.Request) (stats.Response, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.Get")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("groupBy", req.GroupBy)
+	log.Info("starting Get stats")
+
+	if req.StartDate != nil && req.EndDate != nil && req.StartDate.After(*req.EndDate) {
+		log.Warn("start date is after end date")
+		return stats.Response{}, errors.NewStartDateAfterEndDate()
+	}
+
+	rows, err := ss.statsRepo.Aggregate(ctx, ss.conn, req.GroupBy, utcTimePtr(req.StartDate), utcTimePtr(req.EndDate))
+	if err != nil {
+		log.Error("failed to aggregate stats", "err", err)
+		return stats.Response{}, errors.NewInternalError()
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == nil {
+		groupBy = []string{}
+	}
+	resp := stats.Response{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		GroupBy:   groupBy,
+		Rows:      make([]stats.Row, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result := stats.Row{
+			Products:       row.Products,
+			Receptions:     row.Receptions,
+			OpenReceptions: row.OpenReceptions,
+		}
+		if slices.Contains(groupBy, stats.GroupPvz) {
+			result.PvzId = &row.PvzId.String
+		}
+		if slices.Contains(groupBy, stats.GroupCity) {
+			result.City = &row.City.String
+		}
+		if slices.Contains(groupBy, stats.GroupType) {
+			result.Type = &row.Type.String
+		}
+		if slices.Contains(groupBy, stats.GroupDay) {
+			day := row.Day.Time.Format("2006-01-02")
+			result.Day = &day
+		}
+		if row.AvgDuration.Valid {
+			avg := row.AvgDuration.Float64
+			result.AvgReceptionDurationSeconds = &avg
+		}
+		resp.Rows = append(resp.Rows, result)
+	}
+
+	log.Info("successfully aggregated stats", "rows", len(resp.Rows))
+	return resp, nil
+}
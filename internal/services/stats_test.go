@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/logger"
+	"pvz/internal/mocks"
+	"pvz/internal/models"
+	"pvz/internal/models/stats"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+)
+
+func TestStatsService_Get(t *testing.T) {
+	logger.Init("debug")
+
+	t.Run("grouped rows", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		statsRepo := mocks.NewStatsRepository(t)
+		service := services.NewStatsService(statsRepo, db)
+
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+		req := stats.Request{StartDate: &start, EndDate: &end, GroupBy: []string{stats.GroupCity, stats.GroupType, stats.GroupDay}}
+
+		statsRepo.On("Aggregate", mock.Anything, db, req.GroupBy, &start, &end).Return([]models.StatsRow{
+			{
+				City:        sql.NullString{String: "Москва", Valid: true},
+				Type:        sql.NullString{String: "обувь", Valid: true},
+				Day:         sql.NullTime{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+				Products:    7,
+				Receptions:  2,
+				AvgDuration: sql.NullFloat64{Float64: 3600, Valid: true},
+			},
+			{
+				City:           sql.NullString{String: "Казань", Valid: true},
+				Day:            sql.NullTime{Time: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+				Receptions:     1,
+				OpenReceptions: 1,
+			},
+		}, nil).Once()
+
+		resp, err := service.Get(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+		require.Nil(t, resp.Rows[0].PvzId)
+		require.Equal(t, "Москва", *resp.Rows[0].City)
+		require.Equal(t, "обувь", *resp.Rows[0].Type)
+		require.Equal(t, "2025-01-02", *resp.Rows[0].Day)
+		require.Equal(t, 3600.0, *resp.Rows[0].AvgReceptionDurationSeconds)
+		require.Equal(t, "", *resp.Rows[1].Type)
+		require.Nil(t, resp.Rows[1].AvgReceptionDurationSeconds)
+		require.Equal(t, 1, resp.Rows[1].OpenReceptions)
+	})
+
+	t.Run("window with an offset is passed in UTC", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		statsRepo := mocks.NewStatsRepository(t)
+		service := services.NewStatsService(statsRepo, db)
+
+		moscow := time.FixedZone("MSK", 3*60*60)
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, moscow)
+		end := time.Date(2025, 1, 2, 0, 0, 0, 0, moscow)
+		wantStart := time.Date(2024, 12, 31, 21, 0, 0, 0, time.UTC)
+		wantEnd := time.Date(2025, 1, 1, 21, 0, 0, 0, time.UTC)
+
+		statsRepo.On("Aggregate", mock.Anything, db, []string(nil), &wantStart, &wantEnd).
+			Return([]models.StatsRow{{Products: 1, Receptions: 1}}, nil).Once()
+
+		_, err := service.Get(context.Background(), stats.Request{StartDate: &start, EndDate: &end})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("totals without grouping", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		statsRepo := mocks.NewStatsRepository(t)
+		service := services.NewStatsService(statsRepo, db)
+
+		statsRepo.On("Aggregate", mock.Anything, db, []string(nil), (*time.Time)(nil), (*time.Time)(nil)).
+			Return([]models.StatsRow{{Products: 10, Receptions: 3}}, nil).Once()
+
+		resp, err := service.Get(context.Background(), stats.Request{})
+
+		require.NoError(t, err)
+		require.Equal(t, []string{}, resp.GroupBy)
+		require.Equal(t, []stats.Row{{Products: 10, Receptions: 3}}, resp.Rows)
+	})
+
+	t.Run("start date after end date", func(t *testing.T) {
+		service := services.NewStatsService(nil, nil)
+
+		start := time.Now()
+		end := start.Add(-time.Hour)
+		_, err := service.Get(context.Background(), stats.Request{StartDate: &start, EndDate: &end})
+
+		require.Equal(t, errors.NewStartDateAfterEndDate(), err)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		db, _, _ := sqlmock.New()
+		defer db.Close()
+
+		statsRepo := mocks.NewStatsRepository(t)
+		service := services.NewStatsService(statsRepo, db)
+
+		statsRepo.On("Aggregate", mock.Anything, db, []string{stats.GroupPvz}, (*time.Time)(nil), (*time.Time)(nil)).
+			Return(nil, sql.ErrConnDone).Once()
+
+		_, err := service.Get(context.Background(), stats.Request{GroupBy: []string{stats.GroupPvz}})
+
+		require.Equal(t, errors.NewInternalError(), err)
+	})
+}
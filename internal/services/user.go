@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"golang.org/x/crypto/bcrypt"
+	"pvz/internal/logger"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/user"
+	"pvz/internal/repositories"
+	"pvz/internal/tracing"
+	"pvz/pkg/errors"
+)
+
+type UserService interface {
+	List(ctx context.Context) ([]user.Response, error)
+	GetByEmail(ctx context.Context, email string) (user.Response, error)
+	SetPassword(ctx context.Context, meta audit.Meta, req user.SetPasswordRequest) error
+	AssignPvzs(ctx context.Context, meta audit.Meta, req user.AssignPvzsRequest) (user.Response, error)
+}
+
+type userServiceImpl struct {
+	userRepo  repositories.UserRepository
+	pvzRepo   repositories.PvzRepository
+	auditRepo repositories.AuditRepository
+	conn      *sql.DB
+}
+
+func NewUserService(userRepo repositories.UserRepository, pvzRepo repositories.PvzRepository, auditRepo repositories.AuditRepository, conn *sql.DB) UserService {
+	return &userServiceImpl{
+		userRepo:  userRepo,
+		pvzRepo:   pvzRepo,
+		auditRepo: auditRepo,
+		conn:      conn,
+	}
+}
+
+func (us *userServiceImpl) List(ctx context.Context) ([]user.Response, error) {
+	ctx, span := tracing.Start(ctx, "UserService.List")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+	log.Info("starting List users")
+
+	users, err := us.userRepo.List(ctx, us.conn)
+	if err != nil {
+		log.Error("failed to list users", "err", err)
+		return nil, errors.NewInternalError()
+	}
+
+	result := make([]user.Response, 0, len(users))
+	for _, u := range users {
+		pvzIds, err := us.userRepo.ListPvzIds(ctx, us.conn, u.Id)
+		if err != nil {
+			log.Error("failed to list user pvzs", "userId", u.Id, "err", err)
+			return nil, errors.NewInternalError()
+		}
+		result = append(result, toUserResponse(u, pvzIds))
+	}
+
+	log.Info("successfully listed users", "count", len(result))
+	return result, nil
+}
+
+func (us *userServiceImpl) GetByEmail(ctx context.Context, email string) (user.Response, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByEmail")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", email)
+	log.Info("starting GetByEmail")
+
+	u, err := us.userRepo.GetByEmail(ctx, us.conn, email)
+	if err != nil {
+		log.Error("failed to fetch user", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	if u == nil {
+		log.Warn("user not found")
+		return user.Response{}, errors.NewObjectNotFound("user")
+	}
+
+	pvzIds, err := us.userRepo.ListPvzIds(ctx, us.conn, u.Id)
+	if err != nil {
+		log.Error("failed to list user pvzs", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	return toUserResponse(*u, pvzIds), nil
+}
+
+func (us *userServiceImpl) SetPassword(ctx context.Context, meta audit.Meta, req user.SetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.SetPassword")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", req.Email)
+	log.Info("starting SetPassword")
+
+	tx, err := us.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	u, err := us.userRepo.GetByEmail(ctx, tx, req.Email)
+	if err != nil {
+		log.Error("failed to fetch user", "err", err)
+		return errors.NewInternalError()
+	}
+	if u == nil {
+		log.Warn("user not found")
+		return errors.NewObjectNotFound("user")
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error("failed to hash password", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = us.userRepo.UpdatePasswordHash(ctx, tx, u.Id, string(passwordHash)); err != nil {
+		log.Error("failed to update password", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = us.userRepo.RevokeSessions(ctx, tx, u.Id, sessionsCutoff()); err != nil {
+		log.Error("failed to revoke sessions", "err", err)
+		return errors.NewInternalError()
+	}
+
+	err = recordAudit(ctx, tx, us.auditRepo, meta, audit.UserPasswordSet, "", map[string]string{"userId": u.Id}, nil, nil)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return errors.NewInternalError()
+	}
+
+	log.Info("password set successfully", "userId", u.Id)
+	return nil
+}
+
+func (us *userServiceImpl) AssignPvzs(ctx context.Context, meta audit.Meta, req user.AssignPvzsRequest) (user.Response, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AssignPvzs")
+	defer span.End()
+
+	log := logger.FromContext(ctx).With("email", req.Email, "pvzIds", req.PvzIds)
+	log.Info("starting AssignPvzs")
+
+	tx, err := us.conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("failed to begin transaction", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	defer tx.Rollback()
+
+	u, err := us.userRepo.GetByEmail(ctx, tx, req.Email)
+	if err != nil {
+		log.Error("failed to fetch user", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+	if u == nil {
+		log.Warn("user not found")
+		return user.Response{}, errors.NewObjectNotFound("user")
+	}
+
+	for _, pvzId := range req.PvzIds {
+		pvz, err := us.pvzRepo.GetById(ctx, tx, pvzId)
+		if err != nil {
+			log.Error("failed to get pvz by id", "pvzId", pvzId, "err", err)
+			return user.Response{}, errors.NewInternalError()
+		}
+		if pvz == nil {
+			log.Warn("pvz not found", "pvzId", pvzId)
+			return user.Response{}, errors.NewObjectNotFound("pvz")
+		}
+	}
+
+	before, err := us.userRepo.ListPvzIds(ctx, tx, u.Id)
+	if err != nil {
+		log.Error("failed to list user pvzs", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	if err = us.userRepo.AssignPvzs(ctx, tx, u.Id, req.PvzIds); err != nil {
+		log.Error("failed to assign pvzs", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	after, err := us.userRepo.ListPvzIds(ctx, tx, u.Id)
+	if err != nil {
+		log.Error("failed to list user pvzs", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	resp := toUserResponse(*u, after)
+	err = recordAudit(ctx, tx, us.auditRepo, meta, audit.UserPvzsAssign, "", map[string]string{"userId": u.Id},
+		toUserResponse(*u, before), resp)
+	if err != nil {
+		log.Error("failed to record audit entry", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error("failed to commit transaction", "err", err)
+		return user.Response{}, errors.NewInternalError()
+	}
+
+	log.Info("pvzs assigned successfully", "userId", u.Id, "count", len(after))
+	return resp, nil
+}
+
+func toUserResponse(u models.User, pvzIds []string) user.Response {
+	if pvzIds == nil {
+		pvzIds = []string{}
+	}
+	return user.Response{
+		Id:     u.Id,
+		Email:  u.Email,
+		Role:   string(u.Role),
+		PvzIds: pvzIds,
+	}
+}
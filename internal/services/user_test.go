@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"pvz/internal/logger"
+	"pvz/internal/models"
+	"pvz/internal/models/audit"
+	"pvz/internal/models/user"
+	"pvz/internal/services"
+	"pvz/pkg/errors"
+)
+
+func TestUserService_SetPassword(t *testing.T) {
+	logger.Init("debug")
+
+	t.Run("successful password change", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		service := services.NewUserService(userRepo, nil, newMockAuditRepo(), db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "user@avito.com").
+			Return(&models.User{Id: "user1", Email: "user@avito.com"}, nil).Once()
+		userRepo.On("UpdatePasswordHash", mock.AnythingOfType("*sql.Tx"), "user1", mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("newpassword")) == nil
+		})).Return(nil).Once()
+		userRepo.On("RevokeSessions", mock.AnythingOfType("*sql.Tx"), "user1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockDB.ExpectCommit()
+
+		err := service.SetPassword(context.Background(), audit.Meta{}, user.SetPasswordRequest{
+			Email:    "user@avito.com",
+			Password: "newpassword",
+		})
+
+		require.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		service := services.NewUserService(userRepo, nil, newMockAuditRepo(), db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "ghost@avito.com").
+			Return((*models.User)(nil), nil).Once()
+		mockDB.ExpectRollback()
+
+		err := service.SetPassword(context.Background(), audit.Meta{}, user.SetPasswordRequest{
+			Email:    "ghost@avito.com",
+			Password: "newpassword",
+		})
+
+		require.IsType(t, errors.ObjectNotFound{}, err)
+	})
+}
+
+func TestUserService_GetByEmail(t *testing.T) {
+	logger.Init("debug")
+
+	t.Run("user found", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := services.NewUserService(userRepo, nil, newMockAuditRepo(), nil)
+
+		userRepo.On("GetByEmail", mock.Anything, "mod@avito.com").
+			Return(&models.User{Id: "user1", Email: "mod@avito.com", Role: "moderator"}, nil).Once()
+		userRepo.On("ListPvzIds", mock.Anything, "user1").Return([]string(nil), nil).Once()
+
+		resp, err := service.GetByEmail(context.Background(), "mod@avito.com")
+
+		require.NoError(t, err)
+		require.Equal(t, user.Response{Id: "user1", Email: "mod@avito.com", Role: "moderator", PvzIds: []string{}}, resp)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := services.NewUserService(userRepo, nil, newMockAuditRepo(), nil)
+
+		userRepo.On("GetByEmail", mock.Anything, "ghost@avito.com").Return((*models.User)(nil), nil).Once()
+
+		_, err := service.GetByEmail(context.Background(), "ghost@avito.com")
+
+		require.IsType(t, errors.ObjectNotFound{}, err)
+	})
+}
+
+func TestUserService_AssignPvzs(t *testing.T) {
+	logger.Init("debug")
+
+	t.Run("successful assignment", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewUserService(userRepo, pvzRepo, newMockAuditRepo(), db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "user@avito.com").
+			Return(&models.User{Id: "user1", Email: "user@avito.com", Role: "employee"}, nil).Once()
+		pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "pvz1").Return(&models.Pvz{Id: "pvz1"}, nil).Once()
+		userRepo.On("ListPvzIds", mock.AnythingOfType("*sql.Tx"), "user1").Return([]string(nil), nil).Once()
+		userRepo.On("AssignPvzs", mock.AnythingOfType("*sql.Tx"), "user1", []string{"pvz1"}).Return(nil).Once()
+		userRepo.On("ListPvzIds", mock.AnythingOfType("*sql.Tx"), "user1").Return([]string{"pvz1"}, nil).Once()
+		mockDB.ExpectCommit()
+
+		resp, err := service.AssignPvzs(context.Background(), audit.Meta{}, user.AssignPvzsRequest{
+			Email:  "user@avito.com",
+			PvzIds: []string{"pvz1"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"pvz1"}, resp.PvzIds)
+		userRepo.AssertExpectations(t)
+		require.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		db, mockDB, _ := sqlmock.New()
+		defer db.Close()
+
+		userRepo := new(MockUserRepository)
+		pvzRepo := new(MockPvzRepo)
+		service := services.NewUserService(userRepo, pvzRepo, newMockAuditRepo(), db)
+
+		mockDB.ExpectBegin()
+		userRepo.On("GetByEmail", mock.AnythingOfType("*sql.Tx"), "user@avito.com").
+			Return(&models.User{Id: "user1"}, nil).Once()
+		pvzRepo.On("GetById", mock.AnythingOfType("*sql.Tx"), "pvz1").Return((*models.Pvz)(nil), nil).Once()
+		mockDB.ExpectRollback()
+
+		_, err := service.AssignPvzs(context.Background(), audit.Meta{}, user.AssignPvzsRequest{
+			Email:  "user@avito.com",
+			PvzIds: []string{"pvz1"},
+		})
+
+		require.IsType(t, errors.ObjectNotFound{}, err)
+		userRepo.AssertNotCalled(t, "AssignPvzs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
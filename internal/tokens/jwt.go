@@ -2,22 +2,43 @@ package tokens
 
 import (
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"pvz/configs"
 	"pvz/internal/models/auth"
 	"time"
 )
 
 type Claims struct {
-	UserId string    `json:"user_id"`
-	Role   auth.Role `json:"auth"`
+	UserId   string    `json:"user_id"`
+	Role     auth.Role `json:"auth"`
+	PvzIds   []string  `json:"pvz_ids,omitempty"`
+	Language string    `json:"lang,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJwt(userId string, role auth.Role) (string, error) {
+func (c *Claims) Principal() auth.Principal {
+	principal := auth.Principal{
+		UserId:   c.UserId,
+		Role:     c.Role,
+		TokenId:  c.ID,
+		PvzIds:   c.PvzIds,
+		Language: c.Language,
+	}
+	if c.IssuedAt != nil {
+		principal.IssuedAt = c.IssuedAt.Time
+	}
+	return principal
+}
+
+// GenerateJwt issues a token for a stored user. language is the user's preferred language, if any.
+func GenerateJwt(userId string, role auth.Role, language string, pvzIds ...string) (string, error) {
 	claims := Claims{
-		UserId: userId,
-		Role:   role,
+		UserId:   userId,
+		Role:     role,
+		PvzIds:   pvzIds,
+		Language: language,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(configs.AppConfiguration.Auth.Expiration)),
 		},
@@ -26,10 +47,14 @@ func GenerateJwt(userId string, role auth.Role) (string, error) {
 	return token.SignedString([]byte(configs.AppConfiguration.Auth.JwtSecret))
 }
 
+// GenerateDummyJwt issues a token for a random, unregistered user id so actions
+// performed with it can still be told apart in the audit log.
 func GenerateDummyJwt(role auth.Role) (string, error) {
 	claims := Claims{
-		Role: role,
+		UserId: uuid.NewString(),
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(configs.AppConfiguration.Auth.Expiration)),
 		},
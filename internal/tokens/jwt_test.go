@@ -42,7 +42,7 @@ func TestGenerateJwt(t *testing.T) {
 	userId := "12345"
 	role := "moderator"
 
-	token, err := tokens.GenerateJwt(userId, auth.Role(role))
+	token, err := tokens.GenerateJwt(userId, auth.Role(role), "ru")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -51,6 +51,7 @@ func TestGenerateJwt(t *testing.T) {
 	assert.NoError(t, parseErr)
 	assert.Equal(t, userId, parsedClaims.UserId)
 	assert.Equal(t, role, string(parsedClaims.Role))
+	assert.Equal(t, "ru", parsedClaims.Principal().Language)
 }
 
 func TestGenerateDummyJwt(t *testing.T) {
@@ -67,6 +68,7 @@ func TestGenerateDummyJwt(t *testing.T) {
 	parsedClaims, parseErr := tokens.ParseJwt(token)
 	assert.NoError(t, parseErr)
 	assert.Equal(t, role, string(parsedClaims.Role))
+	assert.NotEmpty(t, parsedClaims.UserId)
 }
 
 func TestParseJwt(t *testing.T) {
@@ -75,7 +77,7 @@ func TestParseJwt(t *testing.T) {
 
 	role := "employee"
 	userId := "12345"
-	token, err := tokens.GenerateJwt(userId, auth.Role(role))
+	token, err := tokens.GenerateJwt(userId, auth.Role(role), "")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 
@@ -101,7 +103,7 @@ func TestGenerateJwtWithMockConfig(t *testing.T) {
 	role := "moderator"
 	userId := "user123"
 
-	token, err := tokens.GenerateJwt(userId, auth.Role(role))
+	token, err := tokens.GenerateJwt(userId, auth.Role(role), "")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -0,0 +1,41 @@
+package errors
+
+// Translator looks messages up in a catalogue. ut.Translator from go-playground/universal-translator satisfies it.
+type Translator interface {
+	T(key interface{}, params ...string) (string, error)
+}
+
+// Localize returns the message of err from the catalogue of trans. Without a translator or a catalogue
+// entry the English message the error was built with is used.
+func Localize(err Coded, trans Translator) string {
+	if validationFailed, ok := err.(ValidationFailed); ok {
+		return joinMessages(LocalizeFieldErrors(validationFailed.Errors, trans))
+	}
+	return translate(trans, err.MessageKey(), err.MessageParams(), err.Error())
+}
+
+func LocalizeFieldErrors(fieldErrors []FieldError, trans Translator) []FieldError {
+	localized := make([]FieldError, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		fieldError.Message = translate(trans, fieldError.key, fieldError.params, fieldError.Message)
+		localized = append(localized, fieldError)
+	}
+	return localized
+}
+
+func translate(trans Translator, key string, params []string, fallback string) (msg string) {
+	if trans == nil || key == "" {
+		return fallback
+	}
+	// universal-translator panics when a message has more placeholders than params
+	defer func() {
+		if recover() != nil {
+			msg = fallback
+		}
+	}()
+	msg, err := trans.T(key, params...)
+	if err != nil || msg == "" {
+		return fallback
+	}
+	return msg
+}
@@ -1,15 +1,72 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 type commonError struct {
 	Message string `json:"message"`
+	code    string
+	field   string
+	key     string
+	params  string
 }
 
 func (e commonError) Error() string {
 	return e.Message
 }
 
+// Code is a stable machine-readable identifier of the error, unlike Message which is meant for people.
+func (e commonError) Code() string {
+	return e.code
+}
+
+// Field names the request property or param the error is about, if any.
+func (e commonError) Field() string {
+	return e.field
+}
+
+// MessageKey identifies the message in the catalogues of internal/i18n. It is the code unless
+// the code is shared by messages that read differently.
+func (e commonError) MessageKey() string {
+	if e.key != "" {
+		return e.key
+	}
+	return e.code
+}
+
+// MessageParams are substituted for {0}, {1}... in the catalogue message, in that order.
+func (e commonError) MessageParams() []string {
+	if e.params == "" {
+		return nil
+	}
+	return strings.Split(e.params[len(paramSeparator):], paramSeparator)
+}
+
+// paramSeparator joins message params into a string, which keeps errors comparable with errors.Is.
+// Every param is prefixed with it, so a single empty param is told apart from none.
+const paramSeparator = "\x1f"
+
+func paramsOf(values ...string) string {
+	return paramSeparator + strings.Join(values, paramSeparator)
+}
+
+// Coded is implemented by every error of this package.
+type Coded interface {
+	error
+	Code() string
+	Field() string
+	MessageKey() string
+	MessageParams() []string
+}
+
+// codeOf joins words into a snake_case code, so codes built from object names stay well-formed.
+func codeOf(words ...string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.Join(words, "_")), " ", "_")
+}
+
 type MalformedBody struct {
 	commonError
 }
@@ -19,6 +76,7 @@ func NewMalformedBody() MalformedBody {
 	return MalformedBody{
 		commonError: commonError{
 			Message: msg,
+			code:    "malformed_body",
 		},
 	}
 }
@@ -32,6 +90,7 @@ func NewInternalError() InternalError {
 	return InternalError{
 		commonError: commonError{
 			Message: msg,
+			code:    "internal_error",
 		},
 	}
 }
@@ -45,6 +104,7 @@ func NewObjectNotFound(objectType string) ObjectNotFound {
 	return ObjectNotFound{
 		commonError: commonError{
 			Message: msg,
+			code:    codeOf(objectType, "not_found"),
 		},
 	}
 }
@@ -58,6 +118,7 @@ func NewInvalidCredentials() InvalidCredentials {
 	return InvalidCredentials{
 		commonError: commonError{
 			Message: msg,
+			code:    "invalid_credentials",
 		},
 	}
 }
@@ -71,6 +132,8 @@ func NewStartDateAfterEndDate() StartDateAfterEndDate {
 	return StartDateAfterEndDate{
 		commonError: commonError{
 			Message: msg,
+			code:    "start_date_after_end_date",
+			field:   "startDate",
 		},
 	}
 }
@@ -84,6 +147,8 @@ func NewReceptionIsNotClosed(pvzId string) ReceptionIsNotClosed {
 	return ReceptionIsNotClosed{
 		commonError: commonError{
 			Message: msg,
+			code:    "reception_not_closed",
+			params:  paramsOf(pvzId),
 		},
 	}
 }
@@ -97,6 +162,8 @@ func NewReceptionIsNotInProgress(recId string) ReceptionIsNotInProgress {
 	return ReceptionIsNotInProgress{
 		commonError: commonError{
 			Message: msg,
+			code:    "reception_not_in_progress",
+			params:  paramsOf(recId),
 		},
 	}
 }
@@ -110,6 +177,7 @@ func NewNoInProgressReception() NoInProgressReception {
 	return NoInProgressReception{
 		commonError: commonError{
 			Message: msg,
+			code:    "no_in_progress_reception",
 		},
 	}
 }
@@ -123,6 +191,7 @@ func NewObjectHasNotSubObjects(object, subObject string) ObjectHasNotSubObjects
 	return ObjectHasNotSubObjects{
 		commonError: commonError{
 			Message: msg,
+			code:    codeOf(object, "has_no", subObject),
 		},
 	}
 }
@@ -136,6 +205,9 @@ func NewObjectAlreadyExists(object, property, value string) ObjectAlreadyExists
 	return ObjectAlreadyExists{
 		commonError: commonError{
 			Message: msg,
+			code:    codeOf(object, "already_exists"),
+			field:   property,
+			params:  paramsOf(property, value),
 		},
 	}
 }
@@ -149,6 +221,9 @@ func NewPropertyMissing(property string) PropertyMissing {
 	return PropertyMissing{
 		commonError: commonError{
 			Message: msg,
+			code:    "property_missing",
+			field:   property,
+			params:  paramsOf(property),
 		},
 	}
 }
@@ -157,11 +232,14 @@ type ParamMissing struct {
 	commonError
 }
 
-func NewParamMissing() ParamMissing {
+func NewParamMissing(param string) ParamMissing {
 	msg := "param is missing"
 	return ParamMissing{
 		commonError: commonError{
 			Message: msg,
+			code:    "param_missing",
+			field:   param,
+			params:  paramsOf(param),
 		},
 	}
 }
@@ -176,6 +254,9 @@ func NewPropertyTooSmall(property string) PropertyTooSmall {
 	return PropertyTooSmall{
 		commonError: commonError{
 			Message: msg,
+			code:    "property_too_small",
+			field:   property,
+			params:  paramsOf(property),
 		},
 		Property: property,
 	}
@@ -191,6 +272,9 @@ func NewPropertyTooBig(property string) PropertyTooBig {
 	return PropertyTooBig{
 		commonError: commonError{
 			Message: msg,
+			code:    "property_too_big",
+			field:   property,
+			params:  paramsOf(property),
 		},
 		Property: property,
 	}
@@ -205,6 +289,9 @@ func NewWrongPropertyValue(property, value string) WrongPropertyValue {
 	return WrongPropertyValue{
 		commonError: commonError{
 			Message: msg,
+			code:    "wrong_property_value",
+			field:   property,
+			params:  paramsOf(property, value),
 		},
 	}
 }
@@ -218,19 +305,106 @@ func NewBadPropertyValue(property, value string) BadPropertyValue {
 	return BadPropertyValue{
 		commonError: commonError{
 			Message: msg,
+			code:    "bad_property_value",
+			field:   property,
+			params:  paramsOf(property, value),
+		},
+	}
+}
+
+type PropertyInvalid struct {
+	commonError
+}
+
+func NewPropertyInvalid(property string) PropertyInvalid {
+	msg := fmt.Sprintf("property %s is invalid", property)
+	return PropertyInvalid{
+		commonError: commonError{
+			Message: msg,
+			code:    "property_invalid",
+			field:   property,
+			params:  paramsOf(property),
 		},
 	}
 }
 
+type PropertyInFuture struct {
+	commonError
+}
+
+func NewPropertyInFuture(property string) PropertyInFuture {
+	msg := fmt.Sprintf("property %s is in the future", property)
+	return PropertyInFuture{
+		commonError: commonError{
+			Message: msg,
+			code:    "property_in_future",
+			field:   property,
+			params:  paramsOf(property),
+		},
+	}
+}
+
+// FieldError describes one failed check of a request body property.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	key     string
+	params  []string
+}
+
+func NewFieldError(err Coded, tag string) FieldError {
+	return FieldError{
+		Field:   err.Field(),
+		Tag:     tag,
+		Code:    err.Code(),
+		Message: err.Error(),
+		key:     err.MessageKey(),
+		params:  err.MessageParams(),
+	}
+}
+
+// ValidationFailed reports every invalid property of a request at once.
+type ValidationFailed struct {
+	commonError
+	Errors []FieldError `json:"errors"`
+}
+
+func NewValidationFailed(fieldErrors []FieldError) ValidationFailed {
+	err := ValidationFailed{
+		commonError: commonError{
+			Message: joinMessages(fieldErrors),
+			code:    "validation_failed",
+		},
+		Errors: fieldErrors,
+	}
+	if len(fieldErrors) == 1 {
+		err.field = fieldErrors[0].Field
+	}
+	return err
+}
+
+func joinMessages(fieldErrors []FieldError) string {
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
 type BadParamValue struct {
 	commonError
 }
 
-func NewBadParamValue(value string) BadParamValue {
+func NewBadParamValue(param, value string) BadParamValue {
 	msg := fmt.Sprintf("bad param value %s", value)
 	return BadParamValue{
 		commonError: commonError{
 			Message: msg,
+			code:    "bad_param_value",
+			field:   param,
+			params:  paramsOf(value),
 		},
 	}
 }
@@ -244,6 +418,115 @@ func NewAccessForbidden() AccessForbidden {
 	return AccessForbidden{
 		commonError: commonError{
 			Message: msg,
+			code:    "access_forbidden",
+		},
+	}
+}
+
+type FeatureDisabled struct {
+	commonError
+}
+
+func NewFeatureDisabled(feature string) FeatureDisabled {
+	msg := fmt.Sprintf("%s is disabled", feature)
+	return FeatureDisabled{
+		commonError: commonError{
+			Message: msg,
+			code:    "feature_disabled",
+			key:     codeOf(feature, "disabled"),
+		},
+	}
+}
+
+type InvalidToken struct {
+	commonError
+}
+
+func NewInvalidToken(kind string) InvalidToken {
+	msg := fmt.Sprintf("%s token is invalid or expired", kind)
+	return InvalidToken{
+		commonError: commonError{
+			Message: msg,
+			code:    "invalid_token",
+			key:     codeOf(kind, "token_invalid"),
+		},
+	}
+}
+
+type SessionExpired struct {
+	commonError
+}
+
+func NewSessionExpired() SessionExpired {
+	msg := "session expired, please log in again"
+	return SessionExpired{
+		commonError: commonError{
+			Message: msg,
+			code:    "session_expired",
+		},
+	}
+}
+
+type TooManyLoginAttempts struct {
+	commonError
+	RetryAfter int `json:"retryAfter"`
+}
+
+func NewTooManyLoginAttempts(retryAfterSeconds int) TooManyLoginAttempts {
+	msg := fmt.Sprintf("too many failed login attempts, retry in %d seconds", retryAfterSeconds)
+	return TooManyLoginAttempts{
+		commonError: commonError{
+			Message: msg,
+			code:    "too_many_login_attempts",
+			params:  paramsOf(strconv.Itoa(retryAfterSeconds)),
+		},
+		RetryAfter: retryAfterSeconds,
+	}
+}
+
+type RateLimited struct {
+	commonError
+	RetryAfter int `json:"retryAfter"`
+}
+
+func NewRateLimited(retryAfterSeconds int) RateLimited {
+	msg := fmt.Sprintf("rate limit exceeded, retry in %d seconds", retryAfterSeconds)
+	return RateLimited{
+		commonError: commonError{
+			Message: msg,
+			code:    "rate_limited",
+			params:  paramsOf(strconv.Itoa(retryAfterSeconds)),
+		},
+		RetryAfter: retryAfterSeconds,
+	}
+}
+
+type IdempotencyKeyReused struct {
+	commonError
+}
+
+func NewIdempotencyKeyReused() IdempotencyKeyReused {
+	msg := "idempotency key was already used with a different request"
+	return IdempotencyKeyReused{
+		commonError: commonError{
+			Message: msg,
+			code:    "idempotency_key_reused",
+			field:   "Idempotency-Key",
+		},
+	}
+}
+
+type IdempotencyKeyInProgress struct {
+	commonError
+}
+
+func NewIdempotencyKeyInProgress() IdempotencyKeyInProgress {
+	msg := "request with this idempotency key is still in progress"
+	return IdempotencyKeyInProgress{
+		commonError: commonError{
+			Message: msg,
+			code:    "idempotency_key_in_progress",
+			field:   "Idempotency-Key",
 		},
 	}
 }
@@ -0,0 +1,47 @@
+package errors
+
+import "strings"
+
+const ProblemContentType = "application/problem+json"
+
+const problemTypePrefix = "urn:pvz:error:"
+
+// Problem is an RFC 7807 problem details object. Code, Field, Errors, RetryAfter and RequestId are extension members.
+type Problem struct {
+	Type       string       `json:"type"`
+	Title      string       `json:"title"`
+	Status     int          `json:"status"`
+	Detail     string       `json:"detail"`
+	Instance   string       `json:"instance,omitempty"`
+	Code       string       `json:"code"`
+	Field      string       `json:"field,omitempty"`
+	Errors     []FieldError `json:"errors,omitempty"`
+	RetryAfter int          `json:"retryAfter,omitempty"`
+	RequestId  string       `json:"requestId,omitempty"`
+}
+
+// NewProblem renders err with its detail and field error messages taken from the catalogue of trans,
+// which may be nil for the English messages.
+func NewProblem(err Coded, status int, trans Translator) Problem {
+	problem := Problem{
+		Type:   problemTypePrefix + err.Code(),
+		Title:  titleOf(err.Code()),
+		Status: status,
+		Detail: Localize(err, trans),
+		Code:   err.Code(),
+		Field:  err.Field(),
+	}
+	if validationFailed, ok := err.(ValidationFailed); ok {
+		problem.Errors = LocalizeFieldErrors(validationFailed.Errors, trans)
+	}
+	return problem
+}
+
+// titleOf spells the code out, so the title stays the same for every occurrence of the problem.
+func titleOf(code string) string {
+	title := strings.ReplaceAll(code, "_", " ")
+	if title == "" {
+		return title
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
@@ -3,22 +3,21 @@ package integration
 import (
 	"context"
 	"database/sql"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	"os"
-	"path/filepath"
 	"pvz/internal/models/product"
 	"pvz/internal/models/pvz"
 	"pvz/internal/models/reception"
 	"testing"
 	"time"
 
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"pvz/configs"
+	"pvz/internal/events"
 	"pvz/internal/logger"
+	"pvz/internal/migrations"
+	"pvz/internal/models/audit"
 	"pvz/internal/models/auth"
 	"pvz/internal/repositories"
 	"pvz/internal/services"
@@ -57,59 +56,57 @@ func TestFullFlow(t *testing.T) {
 	require.NoError(t, db.Ping())
 	defer db.Close()
 
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-
-	wd, _ := os.Getwd()
-	sourceURL := "file://" + filepath.Join(wd, "..", "..", "internal", "migrations")
-
-	m, err := migrate.NewWithDatabaseInstance(sourceURL, "postgres", driver)
+	migrator, err := migrations.New(ctx, db, time.Minute)
 	require.NoError(t, err)
-	require.NoError(t, m.Up())
+	require.NoError(t, migrator.Up())
+	require.NoError(t, migrator.Close())
 
 	userRepo := repositories.NewUserRepository(db)
 	pvzRepo := repositories.NewPvzRepository(db)
 	productRepo := repositories.NewProductRepository(db)
 	receptionRepo := repositories.NewReceptionRepository(db)
+	auditRepo := repositories.NewAuditRepository(db)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(db)
 
-	authService := services.NewAuthService(userRepo, db)
-	pvzService := services.NewPvzService(pvzRepo, productRepo, receptionRepo, db)
-	productService := services.NewProductService(productRepo, pvzRepo, receptionRepo, db)
-	receptionService := services.NewReceptionService(receptionRepo, pvzRepo, db)
+	authService := services.NewAuthService(userRepo, loginAttemptRepo, auditRepo, configs.AuthConfig{Registration: configs.RegistrationEmployees}, db)
+	pvzService := services.NewPvzService(pvzRepo, productRepo, receptionRepo, auditRepo, events.NewBus(1), db)
+	productService := services.NewProductService(productRepo, pvzRepo, receptionRepo, auditRepo, events.NewBus(1), db)
+	receptionService := services.NewReceptionService(receptionRepo, pvzRepo, productRepo, auditRepo, events.NewBus(1), db)
 
 	t.Run("full user flow", func(t *testing.T) {
-		_, err := authService.Register(auth.RegisterRequest{
+		_, err := authService.CreateUser(ctx, audit.Meta{}, auth.RegisterRequest{
 			Email:    "test@example.com",
 			Password: "password123",
 			Role:     "moderator",
 		})
 		require.NoError(t, err)
 
-		pvzResp, err := pvzService.Create(pvz.CreateRequest{
+		pvzResp, err := pvzService.Create(ctx, audit.Meta{}, pvz.CreateRequest{
 			City: "Москва",
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, pvzResp.Id)
 
-		receptionResp, err := receptionService.Create(reception.CreateRequest{
+		receptionResp, err := receptionService.Create(ctx, audit.Meta{}, reception.CreateRequest{
 			PvzId: pvzResp.Id,
 		})
 		require.NoError(t, err)
 		require.Equal(t, reception.InProgressStatus, receptionResp.Status)
 
-		productResp, err := productService.AddInReception(product.AddInReceptionRequest{
+		productResp, err := productService.AddInReception(ctx, audit.Meta{}, product.AddInReceptionRequest{
 			PvzId: pvzResp.Id,
 			Type:  "электроника",
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, productResp.Id)
 
-		closedReception, err := pvzService.CLoseLastReception(pvzResp.Id)
+		closedReception, err := pvzService.CLoseLastReception(ctx, audit.Meta{}, pvzResp.Id)
 		require.NoError(t, err)
 		require.Equal(t, reception.CloseStatus, closedReception.Status)
 
 		start := time.Now().Add(-24 * time.Hour)
 		end := time.Now()
-		list, err := pvzService.ListWithFilterDate(pvz.ListRequest{
+		list, err := pvzService.ListWithFilterDate(ctx, pvz.ListRequest{
 			StartDate: &start,
 			EndDate:   &end,
 			Page:      1,